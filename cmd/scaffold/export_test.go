@@ -3,6 +3,10 @@ package main
 // Export internal functions for testing.
 var (
 	FindMutationDir  = findMutationDir
+	GenerateExternal = generateExternal
 	GenerateFile     = generateFile
 	GenerateRegistry = generateRegistry
 )
+
+// MutatorData exports mutatorData for testing.
+type MutatorData = mutatorData
@@ -9,6 +9,7 @@ import (
 	"go/build"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -25,23 +26,42 @@ var mutatorTemplate string
 //go:embed templates/mutator_test.go.tmpl
 var testTemplate string
 
+//go:embed templates/external/go.mod.tmpl
+var externalModTemplate string
+
+//go:embed templates/external/mutator.go.tmpl
+var externalMutatorTemplate string
+
+//go:embed templates/external/mutator_test.go.tmpl
+var externalTestTemplate string
+
+//go:embed templates/external/main.go.tmpl
+var externalMainTemplate string
+
 type mutatorData struct {
 	LowerName   string
 	StructName  string
 	Description string
+	// Module is the module path of an external mutator module.
+	Module string
 }
 
 // exitFunc allows tests to mock os.Exit.
 var exitFunc = os.Exit
 
 func main() {
-	var mutatorName = flag.String("mutator", "", "Name of the mutator to generate")
+	var (
+		mutatorName = flag.String("mutator", "", "Name of the mutator to generate")
+		module      = flag.String("module", "", "Module path of an external mutator module to generate instead of writing into the gomu tree")
+		dir         = flag.String("dir", "", "Directory of the external mutator module (defaults to the last element of -module)")
+	)
 
 	flag.Parse()
 
 	if *mutatorName == "" {
-		fmt.Fprintf(os.Stderr, "Usage: %s -mutator=<mutator_name>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s -mutator=<mutator_name> [-module=<module_path> [-dir=<dir>]]\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Example: %s -mutator=bitwise\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Example: %s -mutator=money -module=example.com/gomu-money\n", os.Args[0])
 		exitFunc(1)
 
 		return
@@ -54,6 +74,35 @@ func main() {
 		LowerName:   name,
 		StructName:  structName,
 		Description: name + " operators",
+		Module:      *module,
+	}
+
+	if data.Module != "" {
+		if *dir == "" {
+			*dir = path.Base(data.Module)
+		}
+
+		files, err := generateExternal(*dir, data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating external mutator module: %v\n", err)
+			exitFunc(1)
+
+			return
+		}
+
+		fmt.Printf("Generated %s mutator module %s:\n", name, data.Module)
+
+		for _, file := range files {
+			fmt.Printf("  - %s\n", file)
+		}
+
+		fmt.Printf("\nNext steps:\n")
+		fmt.Printf("  1. cd %s && go get github.com/sivchari/gomu@latest && go mod tidy\n", *dir)
+		fmt.Printf("  2. Update the TODO items in %s.go and %s_test.go\n", name, name)
+		fmt.Printf("  3. Run: go test ./...\n")
+		fmt.Printf("  4. Build your gomu binary: go build -o gomu ./cmd/gomu\n")
+
+		return
 	}
 
 	// Find the mutation package directory
@@ -171,6 +220,42 @@ func generateFile(filename, tmplText string, data mutatorData) error {
 	return nil
 }
 
+// generateExternal generates a module in dir that defines the mutator in a
+// package registering it with gomu, and a gomu command that includes it. It
+// returns the generated files and refuses to overwrite an existing module.
+func generateExternal(dir string, data mutatorData) ([]string, error) {
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+		return nil, fmt.Errorf("%s already contains a module", dir)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "cmd", "gomu"), 0750); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	files := []struct {
+		name string
+		tmpl string
+	}{
+		{name: "go.mod", tmpl: externalModTemplate},
+		{name: data.LowerName + ".go", tmpl: externalMutatorTemplate},
+		{name: data.LowerName + "_test.go", tmpl: externalTestTemplate},
+		{name: filepath.Join("cmd", "gomu", "main.go"), tmpl: externalMainTemplate},
+	}
+
+	generated := make([]string, 0, len(files))
+
+	for _, file := range files {
+		filename := filepath.Join(dir, file.name)
+		if err := generateFile(filename, file.tmpl, data); err != nil {
+			return nil, err
+		}
+
+		generated = append(generated, filename)
+	}
+
+	return generated, nil
+}
+
 func generateRegistry(mutationDir string) error {
 	// Try to run `go generate` in the mutation directory
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
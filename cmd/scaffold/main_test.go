@@ -0,0 +1,62 @@
+package main_test
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	scaffold "github.com/sivchari/gomu/cmd/scaffold"
+)
+
+func TestGenerateExternal(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "gomu-money")
+
+	data := scaffold.MutatorData{
+		LowerName:   "money",
+		StructName:  "Money",
+		Description: "money operators",
+		Module:      "example.com/gomu-money",
+	}
+
+	files, err := scaffold.GenerateExternal(dir, data)
+	if err != nil {
+		t.Fatalf("GenerateExternal failed: %v", err)
+	}
+
+	if len(files) != 4 {
+		t.Fatalf("expected 4 generated files, got %v", files)
+	}
+
+	mod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		t.Fatalf("failed to read go.mod: %v", err)
+	}
+
+	if !strings.HasPrefix(string(mod), "module example.com/gomu-money\n") {
+		t.Errorf("unexpected go.mod:\n%s", mod)
+	}
+
+	fset := token.NewFileSet()
+
+	for _, file := range files[1:] {
+		if _, err := parser.ParseFile(fset, file, nil, parser.AllErrors); err != nil {
+			t.Errorf("generated %s does not parse: %v", file, err)
+		}
+	}
+
+	command, err := os.ReadFile(filepath.Join(dir, "cmd", "gomu", "main.go"))
+	if err != nil {
+		t.Fatalf("failed to read cmd/gomu/main.go: %v", err)
+	}
+
+	if !strings.Contains(string(command), "cli.Main()") || !strings.Contains(string(command), `_ "example.com/gomu-money"`) {
+		t.Errorf("cmd/gomu/main.go does not run the gomu command line with the mutator:\n%s", command)
+	}
+
+	if _, err := scaffold.GenerateExternal(dir, data); err == nil {
+		t.Error("expected GenerateExternal to refuse overwriting the module")
+	}
+}
@@ -22,6 +22,9 @@ type Analyzer struct {
 	fileSet      *token.FileSet
 	typeInfo     *types.Info
 	ignoreParser *ignore.Parser
+	buildFlags   []string
+	env          []string
+	loader       *PackageLoader
 }
 
 // Option is a functional option for configuring an Analyzer.
@@ -34,6 +37,22 @@ func WithIgnoreParser(parser *ignore.Parser) Option {
 	}
 }
 
+// WithBuildFlags sets the build flags (e.g. -tags) used when loading packages
+// for type checking.
+func WithBuildFlags(flags []string) Option {
+	return func(a *Analyzer) {
+		a.buildFlags = flags
+	}
+}
+
+// WithEnv sets extra KEY=VALUE environment variables (e.g. GOOS) used when
+// loading packages for type checking.
+func WithEnv(env []string) Option {
+	return func(a *Analyzer) {
+		a.env = env
+	}
+}
+
 // New creates a new analyzer with optional configuration.
 func New(opts ...Option) (*Analyzer, error) {
 	a := &Analyzer{
@@ -50,6 +69,9 @@ func New(opts ...Option) (*Analyzer, error) {
 		opt(a)
 	}
 
+	a.loader = NewPackageLoader(a.fileSet, a.buildFlags...)
+	a.loader.SetEnv(a.env)
+
 	return a, nil
 }
 
@@ -59,6 +81,9 @@ type FileInfo struct {
 	FileAST  *ast.File
 	Hash     string
 	TypeInfo *types.Info
+	// Package is the loaded package containing the file, or nil when type
+	// information comes from the fallback checker or is unavailable.
+	Package *LoadedPackage
 }
 
 // shouldSkipDirectory checks if a directory should be skipped.
@@ -135,7 +160,13 @@ func (a *Analyzer) ParseFile(filePath string) (*FileInfo, error) {
 	// Calculate file hash for incremental analysis
 	hash := calculateFileHash(src)
 
-	// Try to get type information by parsing all package files together
+	// Prefer the package loader, which resolves every import the way the
+	// go command does. Fall back to parsing the directory ourselves for code
+	// outside a module or files excluded from the current build.
+	if fileInfo, ok := a.loadFile(filePath, hash); ok {
+		return fileInfo, nil
+	}
+
 	fileAST, typeInfo, err := a.parseAndTypeCheck(filePath)
 	if err != nil {
 		// Fall back to syntax-only parsing
@@ -155,6 +186,49 @@ func (a *Analyzer) ParseFile(filePath string) (*FileInfo, error) {
 	}, nil
 }
 
+// LoadPackages loads the packages of files at once, so that the dependencies
+// they share are type-checked once rather than for each package. ParseFile
+// loads any package that is not loaded yet on its own.
+func (a *Analyzer) LoadPackages(files []string) error {
+	dirs := make([]string, len(files))
+	for i, file := range files {
+		dirs[i] = filepath.Dir(file)
+	}
+
+	if err := a.loader.Preload(dirs); err != nil {
+		return fmt.Errorf("failed to load packages: %w", err)
+	}
+
+	return nil
+}
+
+// loadFile returns the file from its type-checked package, if the package
+// loads successfully and contains the file.
+func (a *Analyzer) loadFile(filePath, hash string) (*FileInfo, bool) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, false
+	}
+
+	pkg, err := a.loader.Load(filepath.Dir(absPath))
+	if err != nil {
+		return nil, false
+	}
+
+	fileAST, ok := pkg.Files[absPath]
+	if !ok {
+		return nil, false
+	}
+
+	return &FileInfo{
+		Path:     filePath,
+		FileAST:  fileAST,
+		Hash:     hash,
+		TypeInfo: pkg.TypesInfo,
+		Package:  pkg,
+	}, true
+}
+
 // GetPosition returns the position information for a token.
 func (a *Analyzer) GetPosition(pos token.Pos) token.Position {
 	return a.fileSet.Position(pos)
@@ -166,6 +240,7 @@ func (a *Analyzer) GetFileSet() *token.FileSet {
 }
 
 // parseAndTypeCheck parses all package files and type checks them, returning the AST and type info.
+// It is the fallback used when the package loader cannot load the package.
 func (a *Analyzer) parseAndTypeCheck(filePath string) (*ast.File, *types.Info, error) {
 	pkgDir := filepath.Dir(filePath)
 
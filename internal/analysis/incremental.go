@@ -2,7 +2,10 @@ package analysis
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
 )
 
 // HistoryStore defines the interface for history storage.
@@ -27,6 +30,7 @@ type IncrementalAnalyzer struct {
 	baseBranch   string
 	ignoreParser IgnoreParser
 	incremental  bool
+	targetFiles  map[string]bool
 }
 
 // IgnoreParser defines the interface for ignore file parsing.
@@ -65,6 +69,17 @@ func (a *IncrementalAnalyzer) SetIgnoreParser(parser IgnoreParser) {
 	a.git.SetIgnoreParser(parser)
 }
 
+// SetTargetFiles restricts analysis to the given source files, typically the
+// files of the packages resolved from the requested package patterns.
+// When no target files are set, every Go file under the work directory is a
+// candidate.
+func (a *IncrementalAnalyzer) SetTargetFiles(files []string) {
+	a.targetFiles = make(map[string]bool, len(files))
+	for _, file := range files {
+		a.targetFiles[filepath.Clean(file)] = true
+	}
+}
+
 // FileAnalysisResult represents the result of file analysis.
 type FileAnalysisResult struct {
 	FilePath     string
@@ -101,13 +116,57 @@ func (a *IncrementalAnalyzer) AnalyzeFiles() ([]FileAnalysisResult, error) {
 func (a *IncrementalAnalyzer) getFilesToAnalyze() ([]string, error) {
 	if a.incremental && a.git.IsGitRepository() {
 		// Use Git diff to get changed files with intelligent default base branch
-		return a.git.GetChangedFiles(a.baseBranch)
+		files, err := a.git.GetChangedFiles(a.baseBranch)
+		if err != nil {
+			return nil, err
+		}
+
+		return a.filterTargetFiles(files), nil
+	}
+
+	if a.targetFiles != nil {
+		return a.getTargetFiles(), nil
 	}
 
 	// Fallback to all Go files
 	return a.git.GetAllGoFiles()
 }
 
+// filterTargetFiles keeps only the files that belong to the target packages.
+func (a *IncrementalAnalyzer) filterTargetFiles(files []string) []string {
+	if a.targetFiles == nil {
+		return files
+	}
+
+	filtered := make([]string, 0, len(files))
+
+	for _, file := range files {
+		if a.targetFiles[filepath.Clean(file)] {
+			filtered = append(filtered, file)
+		}
+	}
+
+	return filtered
+}
+
+// getTargetFiles returns the target files in a stable order, applying the
+// ignore patterns relative to the work directory.
+func (a *IncrementalAnalyzer) getTargetFiles() []string {
+	files := make([]string, 0, len(a.targetFiles))
+
+	for file := range a.targetFiles {
+		if a.ignoreParser != nil && a.ignoreParser.ShouldIgnore(GetRelativePath(a.workDir, file)) {
+			continue
+		}
+
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+
+	return files
+}
+
 // analyzeFile analyzes a single file to determine if it needs mutation testing.
 func (a *IncrementalAnalyzer) analyzeFile(filePath string) (FileAnalysisResult, error) {
 	result := FileAnalysisResult{
@@ -206,30 +265,30 @@ func (a *IncrementalAnalyzer) GetFilesNeedingUpdate() ([]string, error) {
 	return needsUpdate, nil
 }
 
-// PrintAnalysisReport prints a summary of the analysis results.
-func (a *IncrementalAnalyzer) PrintAnalysisReport(results []FileAnalysisResult) {
+// PrintAnalysisReport writes a summary of the analysis results to w.
+func (a *IncrementalAnalyzer) PrintAnalysisReport(w io.Writer, results []FileAnalysisResult) {
 	needsUpdate := 0
 	skipped := 0
 
-	fmt.Println("Incremental Analysis Report")
-	fmt.Println("==========================")
+	fmt.Fprintln(w, "Incremental Analysis Report")
+	fmt.Fprintln(w, "==========================")
 
 	for _, result := range results {
 		if result.NeedsUpdate {
 			needsUpdate++
 
-			fmt.Printf("✓ %s - %s\n", result.FilePath, result.Reason)
+			fmt.Fprintf(w, "✓ %s - %s\n", result.FilePath, result.Reason)
 		} else {
 			skipped++
 
-			fmt.Printf("- %s - %s\n", result.FilePath, result.Reason)
+			fmt.Fprintf(w, "- %s - %s\n", result.FilePath, result.Reason)
 		}
 	}
 
-	fmt.Printf("\nSummary: %d files need testing, %d files skipped\n", needsUpdate, skipped)
+	fmt.Fprintf(w, "\nSummary: %d files need testing, %d files skipped\n", needsUpdate, skipped)
 
 	if needsUpdate > 0 {
-		fmt.Printf("Performance improvement: %.1f%% files skipped\n",
+		fmt.Fprintf(w, "Performance improvement: %.1f%% files skipped\n",
 			float64(skipped)/float64(len(results))*100)
 	}
 }
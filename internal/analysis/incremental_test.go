@@ -1,8 +1,10 @@
 package analysis
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -209,8 +211,18 @@ func TestIncrementalAnalyzer_PrintAnalysisReport(t *testing.T) {
 		},
 	}
 
-	// This should not panic
-	analyzer.PrintAnalysisReport(results)
+	var buf bytes.Buffer
+
+	analyzer.PrintAnalysisReport(&buf, results)
+
+	report := buf.String()
+	if !strings.Contains(report, "✓ /test/file1.go - File content changed") {
+		t.Errorf("report does not list the file needing testing:\n%s", report)
+	}
+
+	if !strings.Contains(report, "Summary: 1 files need testing, 1 files skipped") {
+		t.Errorf("report does not contain the summary:\n%s", report)
+	}
 }
 
 func TestIncrementalAnalyzer_EdgeCases(t *testing.T) {
@@ -412,3 +424,36 @@ func TestAdd(t *testing.T) {
 		t.Error("Expected hasTestFilesChanged to return false for file with no test files")
 	}
 }
+
+func TestIncrementalAnalyzer_SetTargetFiles(t *testing.T) {
+	tempDir := t.TempDir()
+
+	targetFile := filepath.Join(tempDir, "target.go")
+	otherFile := filepath.Join(tempDir, "other.go")
+
+	for _, file := range []string{targetFile, otherFile} {
+		if err := os.WriteFile(file, []byte(testContent), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	analyzer, err := NewIncrementalAnalyzer(tempDir, NewMockHistoryStore(), false, "")
+	if err != nil {
+		t.Fatalf("Failed to create incremental analyzer: %v", err)
+	}
+
+	analyzer.SetTargetFiles([]string{targetFile})
+
+	files, err := analyzer.GetFilesNeedingUpdate()
+	if err != nil {
+		t.Fatalf("Failed to get files needing update: %v", err)
+	}
+
+	if len(files) != 1 || files[0] != targetFile {
+		t.Errorf("Expected only %s, got %v", targetFile, files)
+	}
+
+	if got := analyzer.filterTargetFiles([]string{otherFile, targetFile}); len(got) != 1 || got[0] != targetFile {
+		t.Errorf("filterTargetFiles() = %v, want [%s]", got, targetFile)
+	}
+}
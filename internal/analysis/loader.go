@@ -0,0 +1,192 @@
+package analysis
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// loadMode is the information loaded for each mutated package. Imports are
+// resolved exactly as `go build` resolves them. Dependencies are type-checked
+// from source rather than export data so that loading does not depend on the
+// export data format of the installed toolchain; Preload loads all mutated
+// packages together so that each dependency is type-checked once.
+const loadMode = packages.NeedName |
+	packages.NeedFiles |
+	packages.NeedCompiledGoFiles |
+	packages.NeedImports |
+	packages.NeedDeps |
+	packages.NeedTypes |
+	packages.NeedSyntax |
+	packages.NeedTypesInfo
+
+// LoadedPackage holds the syntax and full type information of a package.
+type LoadedPackage struct {
+	Dir       string
+	Files     map[string]*ast.File // Keyed by absolute file path
+	Types     *types.Package
+	TypesInfo *types.Info
+	// Imports maps import paths as written in the package's source to the
+	// type-checked imported packages.
+	Imports map[string]*types.Package
+	// Errors holds type errors reported for the package, if any.
+	Errors []packages.Error
+}
+
+// PackageLoader loads packages with golang.org/x/tools/go/packages and caches
+// the result per directory so all files of a package share one type check.
+type PackageLoader struct {
+	fileSet    *token.FileSet
+	buildFlags []string
+	env        []string
+
+	mu    sync.Mutex
+	cache map[string]*LoadedPackage
+}
+
+// NewPackageLoader creates a loader that records positions in fileSet.
+func NewPackageLoader(fileSet *token.FileSet, buildFlags ...string) *PackageLoader {
+	return &PackageLoader{
+		fileSet:    fileSet,
+		buildFlags: buildFlags,
+		cache:      make(map[string]*LoadedPackage),
+	}
+}
+
+// SetEnv sets extra KEY=VALUE environment variables, such as GOOS, used when
+// loading packages.
+func (l *PackageLoader) SetEnv(env []string) {
+	l.env = env
+}
+
+// Load returns the package in dir, loading it on first use.
+func (l *PackageLoader) Load(dir string) (*LoadedPackage, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if pkg, ok := l.cache[absDir]; ok {
+		return pkg, nil
+	}
+
+	pkgs, err := l.load(absDir, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected 1 package in %s, got %d", absDir, len(pkgs))
+	}
+
+	pkg, err := l.newLoadedPackage(absDir, pkgs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	l.cache[absDir] = pkg
+
+	return pkg, nil
+}
+
+// Preload loads the packages in dirs with a single run of the package loader,
+// so that the dependencies they share are type-checked once, and caches those
+// that load successfully. Load reports the errors of the others.
+func (l *PackageLoader) Preload(dirs []string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var patterns []string
+
+	seen := make(map[string]bool)
+
+	for _, dir := range dirs {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("failed to get absolute path: %w", err)
+		}
+
+		if _, ok := l.cache[absDir]; ok || seen[absDir] {
+			continue
+		}
+
+		seen[absDir] = true
+
+		patterns = append(patterns, absDir)
+	}
+
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	pkgs, err := l.load(patterns[0], patterns...)
+	if err != nil {
+		return err
+	}
+
+	for _, pkg := range pkgs {
+		if !seen[pkg.Dir] {
+			continue
+		}
+
+		if loaded, err := l.newLoadedPackage(pkg.Dir, pkg); err == nil {
+			l.cache[pkg.Dir] = loaded
+		}
+	}
+
+	return nil
+}
+
+// load runs the package loader in dir for patterns.
+func (l *PackageLoader) load(dir string, patterns ...string) ([]*packages.Package, error) {
+	cfg := &packages.Config{
+		Mode:       loadMode,
+		Dir:        dir,
+		Fset:       l.fileSet,
+		BuildFlags: l.buildFlags,
+		Env:        commandEnv(l.env),
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load package in %s: %w", dir, err)
+	}
+
+	return pkgs, nil
+}
+
+// newLoadedPackage converts pkg, the package in dir, into a LoadedPackage.
+func (l *PackageLoader) newLoadedPackage(dir string, pkg *packages.Package) (*LoadedPackage, error) {
+	if len(pkg.Syntax) == 0 || pkg.TypesInfo == nil {
+		return nil, fmt.Errorf("failed to load package in %s: %v", dir, pkg.Errors)
+	}
+
+	files := make(map[string]*ast.File, len(pkg.Syntax))
+	for _, file := range pkg.Syntax {
+		files[l.fileSet.File(file.Pos()).Name()] = file
+	}
+
+	imports := make(map[string]*types.Package, len(pkg.Imports))
+	for path, imported := range pkg.Imports {
+		if imported.Types != nil {
+			imports[path] = imported.Types
+		}
+	}
+
+	return &LoadedPackage{
+		Dir:       dir,
+		Files:     files,
+		Types:     pkg.Types,
+		TypesInfo: pkg.TypesInfo,
+		Imports:   imports,
+		Errors:    pkg.Errors,
+	}, nil
+}
@@ -0,0 +1,171 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPackageLoader_Load(t *testing.T) {
+	t.Parallel()
+
+	tempDir := createPackagesTestModule(t)
+	innerDir := filepath.Join(tempDir, "inner")
+
+	loader := NewPackageLoader(token.NewFileSet())
+
+	pkg, err := loader.Load(innerDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(pkg.Errors) != 0 {
+		t.Errorf("Expected no type errors, got %v", pkg.Errors)
+	}
+
+	file, ok := pkg.Files[filepath.Join(innerDir, "inner.go")]
+	if !ok {
+		t.Fatalf("Expected inner.go in loaded files, got %v", pkg.Files)
+	}
+
+	// The in-module import must resolve to a complete type.
+	var call *ast.CallExpr
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if c, ok := n.(*ast.CallExpr); ok {
+			call = c
+		}
+
+		return call == nil
+	})
+
+	if call == nil {
+		t.Fatal("Expected a call expression in inner.go")
+	}
+
+	tv, ok := pkg.TypesInfo.Types[call]
+	if !ok || !types.Identical(tv.Type, types.Typ[types.Int]) {
+		t.Errorf("Expected root.Root() to have type int, got %v", tv.Type)
+	}
+
+	cached, err := loader.Load(innerDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cached != pkg {
+		t.Error("Expected the second Load to return the cached package")
+	}
+}
+
+func TestPackageLoader_BuildFlags(t *testing.T) {
+	t.Parallel()
+
+	tempDir := createPackagesTestModule(t)
+
+	tests := []struct {
+		name       string
+		buildFlags []string
+		wantFile   bool
+	}{
+		{
+			name:       "constrained file excluded by default",
+			buildFlags: nil,
+			wantFile:   false,
+		},
+		{
+			name:       "constrained file included with tags",
+			buildFlags: []string{"-tags", "gomu_never"},
+			wantFile:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg, err := NewPackageLoader(token.NewFileSet(), tt.buildFlags...).Load(tempDir)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			_, ok := pkg.Files[filepath.Join(tempDir, "constrained.go")]
+			if ok != tt.wantFile {
+				t.Errorf("constrained.go loaded = %t, want %t", ok, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestPackageLoader_Preload(t *testing.T) {
+	t.Parallel()
+
+	tempDir := createPackagesTestModule(t)
+	innerDir := filepath.Join(tempDir, "inner")
+	emptyDir := filepath.Join(tempDir, "empty")
+
+	if err := os.Mkdir(emptyDir, 0750); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	loader := NewPackageLoader(token.NewFileSet())
+
+	if err := loader.Preload([]string{tempDir, innerDir, innerDir, emptyDir}); err != nil {
+		t.Fatalf("Preload() error = %v", err)
+	}
+
+	root, err := loader.Load(tempDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	inner, err := loader.Load(innerDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	// Both packages come from one type check, so inner imports root itself.
+	if inner.Imports["example.com/pkgs"] != root.Types {
+		t.Error("Expected inner to import the preloaded root package")
+	}
+
+	if _, err := loader.Load(emptyDir); err == nil {
+		t.Error("Expected error for a directory without Go files")
+	}
+}
+
+func TestPackageLoader_LoadError(t *testing.T) {
+	t.Parallel()
+
+	// A directory outside any module cannot be loaded.
+	if _, err := NewPackageLoader(token.NewFileSet()).Load(t.TempDir()); err == nil {
+		t.Error("Expected error for a directory without Go files")
+	}
+}
+
+func TestParseFile_UsesPackageLoader(t *testing.T) {
+	t.Parallel()
+
+	tempDir := createPackagesTestModule(t)
+
+	analyzer, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	fileInfo, err := analyzer.ParseFile(filepath.Join(tempDir, "inner", "inner.go"))
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	if fileInfo.Package == nil {
+		t.Fatal("Expected file to be loaded through the package loader")
+	}
+
+	if fileInfo.TypeInfo != fileInfo.Package.TypesInfo {
+		t.Error("Expected TypeInfo to be the loaded package's type info")
+	}
+}
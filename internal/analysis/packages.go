@@ -0,0 +1,266 @@
+package analysis
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultPatterns are the package patterns used when none are given.
+var DefaultPatterns = []string{"./..."}
+
+// Package describes a Go package resolved by `go list`.
+type Package struct {
+	ImportPath   string
+	Name         string
+	Dir          string
+	GoFiles      []string // Absolute paths of non-test files built for the target platform
+	TestGoFiles  []string // Absolute paths of in-package test files
+	XTestGoFiles []string // Absolute paths of external (package foo_test) test files
+	Imports      []string
+	TestImports  []string
+	XTestImports []string
+}
+
+// listedPackage mirrors the subset of `go list -json` output used by gomu.
+type listedPackage struct {
+	ImportPath   string
+	Name         string
+	Dir          string
+	GoFiles      []string
+	TestGoFiles  []string
+	XTestGoFiles []string
+	Imports      []string
+	TestImports  []string
+	XTestImports []string
+	Error        *struct {
+		Err string
+	}
+}
+
+// ListPackages resolves Go package patterns (e.g. "./...", "./internal/...",
+// or full import paths) relative to dir using `go list`.
+//
+// Only files that match the current build context are returned, so build
+// constraints, GOOS/GOARCH and build flags such as -tags are respected. env
+// contains extra KEY=VALUE environment variables for `go list`, such as GOOS.
+func ListPackages(dir string, patterns, env []string, buildFlags ...string) ([]Package, error) {
+	if len(patterns) == 0 {
+		patterns = DefaultPatterns
+	}
+
+	args := []string{"list", "-e", "-json=ImportPath,Name,Dir,GoFiles,TestGoFiles,XTestGoFiles,Imports,TestImports,XTestImports,Error"}
+	args = append(args, buildFlags...)
+	args = append(args, patterns...)
+
+	ctx := context.Background()
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	cmd.Env = commandEnv(env)
+
+	var stderr bytes.Buffer
+
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packages %s: %w: %s",
+			strings.Join(patterns, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return decodePackages(output)
+}
+
+// commandEnv returns the environment for go commands with the extra env
+// variables. A nil result makes the command inherit the current process
+// environment unchanged.
+func commandEnv(env []string) []string {
+	if len(env) == 0 {
+		return nil
+	}
+
+	return append(os.Environ(), env...)
+}
+
+// decodePackages decodes the concatenated JSON objects printed by `go list -json`.
+func decodePackages(data []byte) ([]Package, error) {
+	var packages []Package
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	for {
+		var listed listedPackage
+		if err := decoder.Decode(&listed); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, fmt.Errorf("failed to decode go list output: %w", err)
+		}
+
+		// Packages that failed to load entirely cannot be mutated; surface the
+		// error instead of silently dropping the pattern.
+		if listed.Error != nil && len(listed.GoFiles) == 0 {
+			return nil, fmt.Errorf("failed to load package %s: %s", listed.ImportPath, listed.Error.Err)
+		}
+
+		packages = append(packages, Package{
+			ImportPath:   listed.ImportPath,
+			Name:         listed.Name,
+			Dir:          listed.Dir,
+			GoFiles:      absFiles(listed.Dir, listed.GoFiles),
+			TestGoFiles:  absFiles(listed.Dir, listed.TestGoFiles),
+			XTestGoFiles: absFiles(listed.Dir, listed.XTestGoFiles),
+			Imports:      listed.Imports,
+			TestImports:  listed.TestImports,
+			XTestImports: listed.XTestImports,
+		})
+	}
+
+	return packages, nil
+}
+
+// absFiles joins file names reported by `go list` with their package directory.
+func absFiles(dir string, names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+
+	files := make([]string, 0, len(names))
+	for _, name := range names {
+		files = append(files, filepath.Join(dir, name))
+	}
+
+	return files
+}
+
+// SourceFiles returns the mutable source files of the given packages, skipping
+// files under excluded directories (vendor, testdata).
+func SourceFiles(packages []Package) []string {
+	var files []string
+
+	for _, pkg := range packages {
+		for _, file := range pkg.GoFiles {
+			if IsExcludedPath(file) {
+				continue
+			}
+
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
+
+// HasTests reports whether the package has any in-package or external tests.
+func (p Package) HasTests() bool {
+	return len(p.TestGoFiles) > 0 || len(p.XTestGoFiles) > 0
+}
+
+// TestTargets returns, for each package directory, the additional test
+// packages that should run when a mutant is placed in that package.
+//
+// mapping maps a source package (import path or directory relative to root,
+// e.g. "./internal/store") to test packages (import paths or patterns relative
+// to root, e.g. "./internal/api/..."). When reverseImports is true, every
+// listed package with tests that directly imports a package is added as well,
+// so mutants in thinly tested lower layers can be killed by higher-level tests.
+func TestTargets(root string, packages []Package, mapping map[string][]string, reverseImports bool) map[string][]string {
+	targets := make(map[string]map[string]bool)
+
+	add := func(dir, target string) {
+		if targets[dir] == nil {
+			targets[dir] = make(map[string]bool)
+		}
+
+		targets[dir][target] = true
+	}
+
+	for key, testPackages := range mapping {
+		for _, pkg := range packages {
+			if !matchesPackage(root, pkg, key) {
+				continue
+			}
+
+			for _, testPackage := range testPackages {
+				add(pkg.Dir, resolvePattern(root, testPackage))
+			}
+		}
+	}
+
+	if reverseImports {
+		dirs := make(map[string]string, len(packages))
+		for _, pkg := range packages {
+			dirs[pkg.ImportPath] = pkg.Dir
+		}
+
+		for _, pkg := range packages {
+			if !pkg.HasTests() {
+				continue
+			}
+
+			for _, imports := range [][]string{pkg.Imports, pkg.TestImports, pkg.XTestImports} {
+				for _, imported := range imports {
+					dir, ok := dirs[imported]
+					if !ok || imported == pkg.ImportPath {
+						continue
+					}
+
+					add(dir, pkg.ImportPath)
+				}
+			}
+		}
+	}
+
+	result := make(map[string][]string, len(targets))
+
+	for dir, set := range targets {
+		list := make([]string, 0, len(set))
+		for target := range set {
+			list = append(list, target)
+		}
+
+		sort.Strings(list)
+		result[dir] = list
+	}
+
+	return result
+}
+
+// matchesPackage reports whether key names pkg either by import path or by
+// directory relative to root.
+func matchesPackage(root string, pkg Package, key string) bool {
+	if key == pkg.ImportPath {
+		return true
+	}
+
+	if !isRelativePattern(key) {
+		return false
+	}
+
+	return filepath.Clean(filepath.Join(root, key)) == filepath.Clean(pkg.Dir)
+}
+
+// resolvePattern makes relative package patterns absolute so they can be
+// passed to go test from any package directory.
+func resolvePattern(root, pattern string) string {
+	if !isRelativePattern(pattern) {
+		return pattern
+	}
+
+	return filepath.Join(root, pattern)
+}
+
+// isRelativePattern reports whether pattern is a relative directory pattern.
+func isRelativePattern(pattern string) bool {
+	return pattern == "." || pattern == ".." ||
+		strings.HasPrefix(pattern, "./") || strings.HasPrefix(pattern, "../")
+}
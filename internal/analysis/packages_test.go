@@ -0,0 +1,314 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// createPackagesTestModule creates a module with a root package, a nested
+// package, a file excluded by a build constraint, and a plan9-only file.
+func createPackagesTestModule(t *testing.T) string {
+	t.Helper()
+
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":                "module example.com/pkgs\n\ngo 1.21\n",
+		"root.go":               "package root\n\nfunc Root() int { return 1 }\n",
+		"root_test.go":          "package root\n\nimport \"testing\"\n\nfunc TestRoot(t *testing.T) { _ = Root() }\n",
+		"constrained.go":        "//go:build gomu_never\n\npackage root\n\nfunc Never() int { return 2 }\n",
+		"root_plan9.go":         "package root\n\nfunc Plan9() int { return 3 }\n",
+		"inner/inner.go":        "package inner\n\nimport \"example.com/pkgs\"\n\nfunc Inner() int { return root.Root() }\n",
+		"inner/inner_x_test.go": "package inner_test\n\nimport \"testing\"\n\nfunc TestInner(t *testing.T) {}\n",
+		"testdata/data.go":      "package data\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	return tempDir
+}
+
+func TestListPackages(t *testing.T) {
+	t.Parallel()
+
+	tempDir := createPackagesTestModule(t)
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+	}{
+		{
+			name:     "default pattern lists all packages",
+			patterns: nil,
+			want:     []string{"example.com/pkgs", "example.com/pkgs/inner"},
+		},
+		{
+			name:     "relative pattern",
+			patterns: []string{"./inner/..."},
+			want:     []string{"example.com/pkgs/inner"},
+		},
+		{
+			name:     "import path pattern",
+			patterns: []string{"example.com/pkgs"},
+			want:     []string{"example.com/pkgs"},
+		},
+		{
+			name:     "multiple patterns",
+			patterns: []string{".", "./inner"},
+			want:     []string{"example.com/pkgs", "example.com/pkgs/inner"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			packages, err := ListPackages(tempDir, tt.patterns, nil)
+			if err != nil {
+				t.Fatalf("ListPackages() error = %v", err)
+			}
+
+			got := make([]string, 0, len(packages))
+			for _, pkg := range packages {
+				got = append(got, pkg.ImportPath)
+			}
+
+			sort.Strings(got)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("import paths mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListPackages_RespectsBuildConstraints(t *testing.T) {
+	t.Parallel()
+
+	tempDir := createPackagesTestModule(t)
+
+	packages, err := ListPackages(tempDir, []string{"."}, nil)
+	if err != nil {
+		t.Fatalf("ListPackages() error = %v", err)
+	}
+
+	if len(packages) != 1 {
+		t.Fatalf("Expected 1 package, got %d", len(packages))
+	}
+
+	pkg := packages[0]
+
+	if diff := cmp.Diff([]string{filepath.Join(pkg.Dir, "root.go")}, pkg.GoFiles); diff != "" {
+		t.Errorf("GoFiles mismatch (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{filepath.Join(pkg.Dir, "root_test.go")}, pkg.TestGoFiles); diff != "" {
+		t.Errorf("TestGoFiles mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestListPackages_BuildFlags(t *testing.T) {
+	t.Parallel()
+
+	tempDir := createPackagesTestModule(t)
+
+	packages, err := ListPackages(tempDir, []string{"."}, nil, "-tags", "gomu_never")
+	if err != nil {
+		t.Fatalf("ListPackages() error = %v", err)
+	}
+
+	if len(packages) != 1 {
+		t.Fatalf("Expected 1 package, got %d", len(packages))
+	}
+
+	want := []string{
+		filepath.Join(packages[0].Dir, "constrained.go"),
+		filepath.Join(packages[0].Dir, "root.go"),
+	}
+
+	if diff := cmp.Diff(want, packages[0].GoFiles); diff != "" {
+		t.Errorf("GoFiles mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestListPackages_Imports(t *testing.T) {
+	t.Parallel()
+
+	tempDir := createPackagesTestModule(t)
+
+	packages, err := ListPackages(tempDir, []string{"./inner"}, nil)
+	if err != nil {
+		t.Fatalf("ListPackages() error = %v", err)
+	}
+
+	if len(packages) != 1 {
+		t.Fatalf("Expected 1 package, got %d", len(packages))
+	}
+
+	if diff := cmp.Diff([]string{"example.com/pkgs"}, packages[0].Imports); diff != "" {
+		t.Errorf("Imports mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(packages[0].XTestGoFiles) != 1 {
+		t.Errorf("Expected 1 external test file, got %v", packages[0].XTestGoFiles)
+	}
+}
+
+func TestListPackages_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dir      func(t *testing.T) string
+		patterns []string
+		errMsg   string
+	}{
+		{
+			name:     "package that does not exist",
+			dir:      createPackagesTestModule,
+			patterns: []string{"./missing"},
+			errMsg:   "failed to load package",
+		},
+		{
+			name:     "directory that does not exist",
+			dir:      func(_ *testing.T) string { return "/nonexistent/gomu/path" },
+			patterns: nil,
+			errMsg:   "failed to list packages",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ListPackages(tt.dir(t), tt.patterns, nil)
+			if err == nil {
+				t.Fatal("Expected error but got none")
+			}
+
+			if !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error to contain %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestSourceFiles(t *testing.T) {
+	t.Parallel()
+
+	packages := []Package{
+		{GoFiles: []string{"/repo/a.go", "/repo/b.go"}},
+		{GoFiles: []string{"/repo/vendor/x/x.go"}},
+		{GoFiles: []string{"/repo/testdata/t.go"}},
+		{GoFiles: nil},
+	}
+
+	want := []string{"/repo/a.go", "/repo/b.go"}
+
+	if diff := cmp.Diff(want, SourceFiles(packages)); diff != "" {
+		t.Errorf("SourceFiles() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTestTargets(t *testing.T) {
+	t.Parallel()
+
+	root := "/repo"
+	packages := []Package{
+		{ImportPath: "example.com/repo/store", Dir: "/repo/store"},
+		{
+			ImportPath:  "example.com/repo/api",
+			Dir:         "/repo/api",
+			Imports:     []string{"example.com/repo/store", "fmt"},
+			TestGoFiles: []string{"/repo/api/api_test.go"},
+		},
+		{
+			ImportPath:   "example.com/repo/e2e",
+			Dir:          "/repo/e2e",
+			XTestImports: []string{"example.com/repo/api", "example.com/repo/store"},
+			XTestGoFiles: []string{"/repo/e2e/e2e_test.go"},
+		},
+		{
+			// Packages without tests never become reverse-import targets.
+			ImportPath: "example.com/repo/cmd",
+			Dir:        "/repo/cmd",
+			Imports:    []string{"example.com/repo/api"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mapping        map[string][]string
+		reverseImports bool
+		want           map[string][]string
+	}{
+		{
+			name:    "no mapping and no reverse imports",
+			mapping: nil,
+			want:    map[string][]string{},
+		},
+		{
+			name: "mapping by relative directory",
+			mapping: map[string][]string{
+				"./store": {"./api/...", "example.com/repo/e2e"},
+			},
+			want: map[string][]string{
+				"/repo/store": {"/repo/api/...", "example.com/repo/e2e"},
+			},
+		},
+		{
+			name: "mapping by import path",
+			mapping: map[string][]string{
+				"example.com/repo/api": {"./e2e"},
+			},
+			want: map[string][]string{
+				"/repo/api": {"/repo/e2e"},
+			},
+		},
+		{
+			name:           "reverse imports",
+			reverseImports: true,
+			want: map[string][]string{
+				"/repo/store": {"example.com/repo/api", "example.com/repo/e2e"},
+				"/repo/api":   {"example.com/repo/e2e"},
+			},
+		},
+		{
+			name: "mapping merged with reverse imports",
+			mapping: map[string][]string{
+				"./store": {"example.com/repo/api", "./cmd"},
+			},
+			reverseImports: true,
+			want: map[string][]string{
+				"/repo/store": {"/repo/cmd", "example.com/repo/api", "example.com/repo/e2e"},
+				"/repo/api":   {"example.com/repo/e2e"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := TestTargets(root, packages, tt.mapping, tt.reverseImports)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("TestTargets() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/sivchari/gomu/internal/mutation"
 	"github.com/sivchari/gomu/internal/report"
 )
 
@@ -20,6 +22,7 @@ type GitHubIntegration struct {
 	prNumber   int
 	client     *http.Client
 	apiBase    string
+	out        io.Writer
 }
 
 // NewGitHubIntegration creates a new GitHub integration.
@@ -30,9 +33,15 @@ func NewGitHubIntegration(token, repository string, prNumber int) *GitHubIntegra
 		prNumber:   prNumber,
 		client:     &http.Client{Timeout: 30 * time.Second},
 		apiBase:    "https://api.github.com",
+		out:        os.Stdout,
 	}
 }
 
+// SetOutput sets the writer for warnings. It defaults to standard output.
+func (g *GitHubIntegration) SetOutput(w io.Writer) {
+	g.out = w
+}
+
 // PRComment represents a GitHub PR comment.
 type PRComment struct {
 	Body string `json:"body"`
@@ -115,7 +124,7 @@ func (g *GitHubIntegration) CreatePRComment(ctx context.Context, summary *report
 	// Delete existing mutation testing comments
 	if err := g.deleteExistingMutationComments(ctx); err != nil {
 		// Log error but don't fail the entire process
-		fmt.Printf("Warning: failed to delete existing comments: %v\n", err)
+		fmt.Fprintf(g.out, "Warning: failed to delete existing comments: %v\n", err)
 	}
 
 	comment := g.formatPRComment(summary, qualityResult)
@@ -291,6 +300,8 @@ func (g *GitHubIntegration) formatPRComment(summary *report.Summary, qualityResu
 		buf.WriteString("No files analyzed.\n\n")
 	}
 
+	writeSurvivedMutants(&buf, summary.Results)
+
 	// Quality gate reason
 	if !passed {
 		fmt.Fprintf(&buf, "**Failure Reason:** %s\n", reason)
@@ -301,3 +312,49 @@ func (g *GitHubIntegration) formatPRComment(summary *report.Summary, qualityResu
 
 	return buf.String()
 }
+
+// maxCommentSurvivors is the maximum number of survived mutants whose diff is
+// shown in a PR comment, keeping the comment within GitHub's size limit.
+const maxCommentSurvivors = 10
+
+// writeSurvivedMutants writes the diffs of the survived mutants in results
+// as collapsed sections.
+func writeSurvivedMutants(buf *strings.Builder, results []mutation.Result) {
+	var survived []mutation.Result
+
+	for _, result := range results {
+		path := result.Mutant.FilePath
+		if result.Status != mutation.StatusSurvived || strings.Contains(path, "/cmd/") || strings.HasPrefix(path, "cmd/") {
+			continue
+		}
+
+		survived = append(survived, result)
+	}
+
+	if len(survived) == 0 {
+		return
+	}
+
+	buf.WriteString("### Survived Mutants\n\n")
+
+	for i, result := range survived {
+		if i == maxCommentSurvivors {
+			fmt.Fprintf(buf, "...and %d more survived mutants.\n\n", len(survived)-maxCommentSurvivors)
+
+			break
+		}
+
+		mutant := result.Mutant
+
+		fmt.Fprintf(buf, "<details>\n<summary><code>%s:%d:%d</code> %s</summary>\n\n",
+			mutant.FilePath, mutant.Line, mutant.Column, mutant.Description)
+
+		if mutant.Diff != "" {
+			fmt.Fprintf(buf, "```diff\n%s```\n\n", mutant.Diff)
+		} else {
+			fmt.Fprintf(buf, "`%s` → `%s`\n\n", mutant.Original, mutant.Mutated)
+		}
+
+		buf.WriteString("</details>\n\n")
+	}
+}
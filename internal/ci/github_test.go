@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/sivchari/gomu/internal/mutation"
 	"github.com/sivchari/gomu/internal/report"
 )
 
@@ -22,6 +23,7 @@ func TestGitHubIntegration_CreatePRComment(t *testing.T) {
 		qualityResult *QualityGateResult
 		expectError   bool
 		errorContains string
+		wantOutput    string
 	}{
 		{
 			name:     "missing token",
@@ -113,6 +115,7 @@ func TestGitHubIntegration_CreatePRComment(t *testing.T) {
 			qualityResult: &QualityGateResult{},
 			expectError:   true,
 			errorContains: "failed to create comment",
+			wantOutput:    "Warning: failed to delete existing comments",
 		},
 		{
 			name:     "API error when creating comment",
@@ -182,7 +185,10 @@ func TestGitHubIntegration_CreatePRComment(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+
 			github := NewGitHubIntegration(tt.token, "owner/repo", tt.prNumber)
+			github.SetOutput(&out)
 
 			if tt.setupServer != nil {
 				server := tt.setupServer()
@@ -204,6 +210,10 @@ func TestGitHubIntegration_CreatePRComment(t *testing.T) {
 					t.Errorf("Unexpected error: %v", err)
 				}
 			}
+
+			if !strings.Contains(out.String(), tt.wantOutput) {
+				t.Errorf("Expected output to contain '%s', got: %s", tt.wantOutput, out.String())
+			}
 		})
 	}
 }
@@ -293,6 +303,60 @@ func TestGitHubIntegration_formatPRComment_EmptyFiles(t *testing.T) {
 	}
 }
 
+func TestGitHubIntegration_formatPRComment_SurvivedMutants(t *testing.T) {
+	github := NewGitHubIntegration("token", "owner/repo", 123)
+
+	results := []mutation.Result{
+		{
+			Mutant: mutation.Mutant{
+				FilePath:    "calc.go",
+				Line:        4,
+				Column:      9,
+				Description: "Replace + with -",
+				Diff:        "--- a/calc.go\n+++ b/calc.go\n@@ -4 +4 @@\n-\treturn a + b\n+\treturn a - b\n",
+			},
+			Status: mutation.StatusSurvived,
+		},
+		{
+			Mutant: mutation.Mutant{FilePath: "calc.go", Line: 8, Column: 9, Description: "Replace * with /"},
+			Status: mutation.StatusKilled,
+		},
+		{
+			Mutant: mutation.Mutant{FilePath: "cmd/tool/main.go", Line: 3, Column: 2, Description: "Remove call"},
+			Status: mutation.StatusSurvived,
+		},
+	}
+
+	for range maxCommentSurvivors + 1 {
+		results = append(results, mutation.Result{
+			Mutant: mutation.Mutant{FilePath: "calc.go", Line: 12, Column: 5, Original: "<", Mutated: "<="},
+			Status: mutation.StatusSurvived,
+		})
+	}
+
+	comment := github.formatPRComment(&report.Summary{Results: results}, nil)
+
+	expectedElements := []string{
+		"### Survived Mutants",
+		"<summary><code>calc.go:4:9</code> Replace + with -</summary>",
+		"```diff\n--- a/calc.go\n+++ b/calc.go\n@@ -4 +4 @@\n-\treturn a + b\n+\treturn a - b\n```",
+		"`<` → `<=`",
+		"...and 2 more survived mutants.",
+	}
+
+	for _, element := range expectedElements {
+		if !strings.Contains(comment, element) {
+			t.Errorf("Expected comment to contain %q, got:\n%s", element, comment)
+		}
+	}
+
+	for _, unexpected := range []string{"Replace * with /", "cmd/tool/main.go"} {
+		if strings.Contains(comment, unexpected) {
+			t.Errorf("Expected comment not to contain %q", unexpected)
+		}
+	}
+}
+
 func TestNewGitHubIntegration(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -3,6 +3,7 @@ package ci
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
@@ -16,6 +17,7 @@ const noQualityGateMessage = "No quality gate configured"
 type Reporter struct {
 	outputDir    string
 	outputFormat string
+	out          io.Writer
 }
 
 // NewReporter creates a new Reporter.
@@ -23,9 +25,16 @@ func NewReporter(outputDir, outputFormat string) *Reporter {
 	return &Reporter{
 		outputDir:    outputDir,
 		outputFormat: outputFormat,
+		out:          os.Stdout,
 	}
 }
 
+// SetOutput sets the writer for console reports. It defaults to standard
+// output.
+func (r *Reporter) SetOutput(w io.Writer) {
+	r.out = w
+}
+
 // Report represents a CI-specific mutation testing report.
 type Report struct {
 	Summary            *report.Summary     `json:"summary"`
@@ -220,10 +229,10 @@ func (r *Reporter) generateHTMLReport(summary *report.Summary, qualityResult *Qu
 
 // generateConsoleReport prints a console report.
 func (r *Reporter) generateConsoleReport(summary *report.Summary, qualityResult *QualityGateResult) {
-	fmt.Printf("Mutation Score: %.1f%%\n", qualityResult.MutationScore)
-	fmt.Printf("Quality Gate: %s\n", map[bool]string{true: "PASSED", false: "FAILED"}[qualityResult.Pass])
-	fmt.Printf("Total Mutants: %d\n", summary.TotalMutants)
-	fmt.Printf("Killed: %d\n", summary.KilledMutants)
+	fmt.Fprintf(r.out, "Mutation Score: %.1f%%\n", qualityResult.MutationScore)
+	fmt.Fprintf(r.out, "Quality Gate: %s\n", map[bool]string{true: "PASSED", false: "FAILED"}[qualityResult.Pass])
+	fmt.Fprintf(r.out, "Total Mutants: %d\n", summary.TotalMutants)
+	fmt.Fprintf(r.out, "Killed: %d\n", summary.KilledMutants)
 }
 
 // getScoreColor returns color based on score.
@@ -0,0 +1,140 @@
+package execution
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sivchari/gomu/internal/mutation"
+)
+
+// AppliedFile is the file recording the mutant applied to the working tree.
+const AppliedFile = ".gomu_applied.json"
+
+var (
+	// ErrAlreadyApplied is returned when a mutant is applied to the working
+	// tree while another one has not been reverted.
+	ErrAlreadyApplied = errors.New("a mutant is already applied")
+	// ErrNotApplied is returned when reverting without an applied mutant.
+	ErrNotApplied = errors.New("no mutant is applied")
+)
+
+// AppliedMutant records a mutant applied to the working tree and the content
+// of its file before, so that it can be reverted.
+type AppliedMutant struct {
+	ID       string `json:"id"`
+	FilePath string `json:"filePath"`
+	Original []byte `json:"original"`
+}
+
+// ApplyMutant writes mutant into its source file in place and records the
+// original content in statePath for RevertMutant. Only one mutant can be
+// applied at a time. It returns the unified diff of the change.
+func ApplyMutant(mutant mutation.Mutant, statePath string) (string, error) {
+	if applied, err := ReadApplied(statePath); err == nil {
+		return "", fmt.Errorf("%w: %s; revert it first", ErrAlreadyApplied, applied.ID)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	path, err := filepath.Abs(mutant.FilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read source file: %w", err)
+	}
+
+	data, err := json.MarshalIndent(AppliedMutant{ID: mutant.ID, FilePath: path, Original: original}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal applied mutant: %w", err)
+	}
+
+	// The original content is saved before the file is overwritten, so
+	// that a failed write can still be reverted.
+	if err := os.WriteFile(statePath, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write applied mutant: %w", err)
+	}
+
+	diff, err := writeMutatedFile(mutant, path, path, mutation.Mutators())
+	if err != nil {
+		if removeErr := os.Remove(statePath); removeErr != nil {
+			return "", errors.Join(err, fmt.Errorf("failed to cleanup applied mutant: %w", removeErr))
+		}
+
+		return "", err
+	}
+
+	return diff, nil
+}
+
+// WriteMutant writes the mutated version of mutant's source file into dir,
+// at the file's path relative to the current directory, or at its base name
+// for files outside of it. It returns the path of the written file and the
+// unified diff of the change.
+func WriteMutant(mutant mutation.Mutant, dir string) (string, string, error) {
+	path, err := filepath.Abs(mutant.FilePath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	rel := filepath.FromSlash(displayPath(path))
+	if filepath.IsAbs(rel) {
+		rel = filepath.Base(path)
+	}
+
+	dst := filepath.Join(dir, rel)
+	if err := os.MkdirAll(filepath.Dir(dst), 0750); err != nil {
+		return "", "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	diff, err := writeMutatedFile(mutant, path, dst, mutation.Mutators())
+	if err != nil {
+		return "", "", err
+	}
+
+	return dst, diff, nil
+}
+
+// RevertMutant restores the file of the mutant recorded in statePath and
+// removes statePath. It returns the reverted mutant.
+func RevertMutant(statePath string) (*AppliedMutant, error) {
+	applied, err := ReadApplied(statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotApplied
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(applied.FilePath, applied.Original, 0600); err != nil {
+		return nil, fmt.Errorf("failed to restore %s: %w", applied.FilePath, err)
+	}
+
+	if err := os.Remove(statePath); err != nil {
+		return nil, fmt.Errorf("failed to remove applied mutant: %w", err)
+	}
+
+	return applied, nil
+}
+
+// ReadApplied reads the applied mutant recorded in statePath. The error wraps
+// os.ErrNotExist if no mutant is applied.
+func ReadApplied(statePath string) (*AppliedMutant, error) {
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied mutant: %w", err)
+	}
+
+	var applied AppliedMutant
+	if err := json.Unmarshal(data, &applied); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal applied mutant: %w", err)
+	}
+
+	return &applied, nil
+}
@@ -0,0 +1,141 @@
+package execution
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sivchari/gomu/internal/mutation"
+)
+
+const applySrc = `package calc
+
+func Add(a, b int) int {
+	return a + b
+}
+`
+
+func TestApplyAndRevertMutant(t *testing.T) {
+	tempDir := t.TempDir()
+	srcPath := filepath.Join(tempDir, "calc.go")
+	statePath := filepath.Join(tempDir, AppliedFile)
+
+	if err := os.WriteFile(srcPath, []byte(applySrc), 0600); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	mutant := mutation.Mutant{
+		ID:       "calc.go_0",
+		FilePath: srcPath,
+		Line:     4,
+		Column:   9,
+		Type:     "arithmetic_binary",
+		Original: "+",
+		Mutated:  "-",
+	}
+
+	diff, err := ApplyMutant(mutant, statePath)
+	if err != nil {
+		t.Fatalf("ApplyMutant failed: %v", err)
+	}
+
+	if !strings.Contains(diff, "-\treturn a + b\n+\treturn a - b\n") {
+		t.Errorf("unexpected diff:\n%s", diff)
+	}
+
+	got, err := os.ReadFile(srcPath)
+	if err != nil {
+		t.Fatalf("failed to read source: %v", err)
+	}
+
+	if !strings.Contains(string(got), "return a - b") {
+		t.Errorf("mutant was not applied:\n%s", got)
+	}
+
+	if _, err := ApplyMutant(mutant, statePath); !errors.Is(err, ErrAlreadyApplied) {
+		t.Errorf("expected ErrAlreadyApplied, got %v", err)
+	}
+
+	applied, err := RevertMutant(statePath)
+	if err != nil {
+		t.Fatalf("RevertMutant failed: %v", err)
+	}
+
+	if applied.ID != mutant.ID {
+		t.Errorf("expected reverted mutant %s, got %s", mutant.ID, applied.ID)
+	}
+
+	got, err = os.ReadFile(srcPath)
+	if err != nil {
+		t.Fatalf("failed to read source: %v", err)
+	}
+
+	if string(got) != applySrc {
+		t.Errorf("source was not restored:\n%s", got)
+	}
+
+	if _, err := RevertMutant(statePath); !errors.Is(err, ErrNotApplied) {
+		t.Errorf("expected ErrNotApplied, got %v", err)
+	}
+}
+
+func TestApplyMutant_NotFound(t *testing.T) {
+	tempDir := t.TempDir()
+	srcPath := filepath.Join(tempDir, "calc.go")
+	statePath := filepath.Join(tempDir, AppliedFile)
+
+	if err := os.WriteFile(srcPath, []byte(applySrc), 0600); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	mutant := mutation.Mutant{FilePath: srcPath, Line: 2, Column: 1, Type: "arithmetic_binary", Original: "+", Mutated: "-"}
+
+	if _, err := ApplyMutant(mutant, statePath); err == nil {
+		t.Fatal("expected ApplyMutant to fail")
+	}
+
+	if _, err := os.Stat(statePath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected no applied mutant to be recorded, got %v", err)
+	}
+}
+
+func TestWriteMutant(t *testing.T) {
+	tempDir := t.TempDir()
+	srcPath := filepath.Join(tempDir, "calc.go")
+	outDir := filepath.Join(tempDir, "out")
+
+	if err := os.WriteFile(srcPath, []byte(applySrc), 0600); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	mutant := mutation.Mutant{FilePath: srcPath, Line: 4, Column: 9, Type: "arithmetic_binary", Original: "+", Mutated: "-"}
+
+	path, _, err := WriteMutant(mutant, outDir)
+	if err != nil {
+		t.Fatalf("WriteMutant failed: %v", err)
+	}
+
+	if filepath.Dir(path) != outDir {
+		t.Errorf("expected mutated file in %s, got %s", outDir, path)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read mutated file: %v", err)
+	}
+
+	if !strings.Contains(string(got), "return a - b") {
+		t.Errorf("mutated file does not contain the mutant:\n%s", got)
+	}
+
+	original, err := os.ReadFile(srcPath)
+	if err != nil {
+		t.Fatalf("failed to read source: %v", err)
+	}
+
+	if string(original) != applySrc {
+		t.Errorf("source was modified:\n%s", original)
+	}
+}
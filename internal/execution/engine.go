@@ -2,11 +2,17 @@
 package execution
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"sync"
 	"time"
 
@@ -15,19 +21,120 @@ import (
 
 // Engine handles test execution using overlay-based mutation.
 type Engine struct {
-	overlay *OverlayMutator
+	overlay    *OverlayMutator
+	buildFlags []string
+	testFlags  []string
+	env        []string
+	// testPackages maps a package directory to additional test packages
+	// that run alongside the package's own tests.
+	testPackages map[string][]string
+	// observer is notified when mutants start and finish executing.
+	observer Observer
+	// out receives warnings.
+	out io.Writer
+
+	// originals caches the object code hash of the original package per
+	// mutated file, for trivial compiler equivalence detection.
+	originals   map[string]*originalBuild
+	originalsMu sync.Mutex
 }
 
-// New creates a new execution engine.
-func New() (*Engine, error) {
+// originalBuild is the object code hash of an original package, computed
+// once.
+type originalBuild struct {
+	once sync.Once
+	hash string
+}
+
+// Option is a functional option for configuring an Engine.
+type Option func(*Engine)
+
+// Observer is notified of the execution of each mutant. Its methods are
+// called concurrently from the worker goroutines.
+type Observer interface {
+	MutantStarted(mutant mutation.Mutant)
+	MutantFinished(result mutation.Result)
+}
+
+// WithBuildFlags sets flags (e.g. -tags, -race, -ldflags) passed to both
+// go build and go test.
+func WithBuildFlags(flags []string) Option {
+	return func(e *Engine) {
+		e.buildFlags = flags
+	}
+}
+
+// WithTestFlags sets flags (e.g. -count=1, -short, -p) passed only to go test.
+func WithTestFlags(flags []string) Option {
+	return func(e *Engine) {
+		e.testFlags = flags
+	}
+}
+
+// WithEnv sets additional environment variables in KEY=VALUE form for the
+// go build and go test processes.
+func WithEnv(env []string) Option {
+	return func(e *Engine) {
+		e.env = env
+	}
+}
+
+// WithTestPackages sets additional test packages to run per package
+// directory, so mutants can be killed by tests living in other packages.
+func WithTestPackages(testPackages map[string][]string) Option {
+	return func(e *Engine) {
+		e.testPackages = testPackages
+	}
+}
+
+// WithObserver sets the observer notified when mutants start and finish
+// executing.
+func WithObserver(observer Observer) Option {
+	return func(e *Engine) {
+		e.observer = observer
+	}
+}
+
+// WithMutators sets the mutators that apply the mutants, which must include
+// the ones that generated them. The built-in and registered mutators are used
+// by default.
+func WithMutators(mutators []mutation.Mutator) Option {
+	return func(e *Engine) {
+		e.overlay.mutators = mutators
+	}
+}
+
+// WithOutput sets the writer for warnings. It defaults to standard output.
+func WithOutput(w io.Writer) Option {
+	return func(e *Engine) {
+		e.out = w
+	}
+}
+
+// New creates a new execution engine with optional configuration.
+func New(opts ...Option) (*Engine, error) {
 	overlay, err := NewOverlayMutator()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create overlay mutator: %w", err)
 	}
 
-	return &Engine{
-		overlay: overlay,
-	}, nil
+	e := &Engine{
+		overlay:   overlay,
+		out:       os.Stdout,
+		originals: make(map[string]*originalBuild),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e, nil
+}
+
+// SetTestPackages replaces the additional test packages to run per package
+// directory. See WithTestPackages.
+func (e *Engine) SetTestPackages(testPackages map[string][]string) {
+	e.testPackages = testPackages
 }
 
 // Close cleans up the execution engine.
@@ -46,6 +153,13 @@ func (e *Engine) RunMutations(mutants []mutation.Mutant) ([]mutation.Result, err
 
 // RunMutationsWithOptions executes tests for all mutants in parallel with custom options.
 func (e *Engine) RunMutationsWithOptions(mutants []mutation.Mutant, workers, timeout int) ([]mutation.Result, error) {
+	return e.RunMutationsContext(context.Background(), mutants, workers, timeout)
+}
+
+// RunMutationsContext executes tests for all mutants in parallel like
+// RunMutationsWithOptions. If ctx is canceled, the running builds and tests
+// are killed, the remaining mutants are skipped and ctx's error is returned.
+func (e *Engine) RunMutationsContext(ctx context.Context, mutants []mutation.Mutant, workers, timeout int) ([]mutation.Result, error) {
 	if len(mutants) == 0 {
 		return nil, nil
 	}
@@ -64,11 +178,30 @@ func (e *Engine) RunMutationsWithOptions(mutants []mutation.Mutant, workers, tim
 		go func(index int, m mutation.Mutant) {
 			defer wg.Done()
 
-			semaphore <- struct{}{}
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
 
 			defer func() { <-semaphore }()
 
-			result := e.runSingleMutation(m, timeout)
+			if ctx.Err() != nil {
+				return
+			}
+
+			if e.observer != nil {
+				e.observer.MutantStarted(m)
+			}
+
+			start := time.Now()
+			result := e.runSingleMutation(ctx, m, timeout)
+			result.ExecutionTime = time.Since(start).Milliseconds()
+
+			if e.observer != nil {
+				e.observer.MutantFinished(result)
+			}
+
 			resultsChan <- indexedResult{index: index, result: result}
 		}(i, mutant)
 	}
@@ -82,6 +215,10 @@ func (e *Engine) RunMutationsWithOptions(mutants []mutation.Mutant, workers, tim
 		results[indexedRes.index] = indexedRes.result
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("mutation execution canceled: %w", err)
+	}
+
 	return results, nil
 }
 
@@ -91,7 +228,7 @@ type indexedResult struct {
 }
 
 // runSingleMutation executes tests for a single mutant using overlay.
-func (e *Engine) runSingleMutation(mutant mutation.Mutant, timeout int) mutation.Result {
+func (e *Engine) runSingleMutation(ctx context.Context, mutant mutation.Mutant, timeout int) mutation.Result {
 	result := mutation.Result{
 		Mutant: mutant,
 		Status: mutation.StatusError,
@@ -105,14 +242,18 @@ func (e *Engine) runSingleMutation(mutant mutation.Mutant, timeout int) mutation
 		return result
 	}
 
+	mutant.Diff = mutCtx.Diff
+	result.Mutant = mutant
+
 	defer func() {
 		if cleanupErr := e.overlay.CleanupMutation(mutCtx); cleanupErr != nil {
-			fmt.Printf("Warning: failed to cleanup mutation: %v\n", cleanupErr)
+			fmt.Fprintf(e.out, "Warning: failed to cleanup mutation: %v\n", cleanupErr)
 		}
 	}()
 
 	// 2. Check if the mutated code compiles using overlay
-	if err := e.checkCompilationWithOverlay(mutCtx); err != nil {
+	hash, err := e.checkCompilationWithOverlay(ctx, mutCtx)
+	if err != nil {
 		result.Status = mutation.StatusNotViable
 		result.Error = fmt.Sprintf("Compilation failed: %v", err)
 		result.Output = err.Error()
@@ -120,43 +261,136 @@ func (e *Engine) runSingleMutation(mutant mutation.Mutant, timeout int) mutation
 		return result
 	}
 
-	// 3. Run tests using overlay
-	return e.runTestWithOverlay(mutCtx, mutant, timeout)
+	// 3. Trivial compiler equivalence: a mutant that compiles to the same
+	// object code as the original cannot be killed by any test
+	if hash != "" && hash == e.originalHash(ctx, mutCtx.OriginalPath) {
+		result.Status = mutation.StatusEquivalent
+
+		return result
+	}
+
+	// 4. Run tests using overlay
+	return e.runTestWithOverlay(ctx, mutCtx, mutant, timeout)
 }
 
-// checkCompilationWithOverlay verifies that the mutated code compiles using overlay.
-// No timeout is applied because compilation always terminates.
-func (e *Engine) checkCompilationWithOverlay(mutCtx *MutationContext) error {
+// checkCompilationWithOverlay verifies that the mutated code compiles using
+// overlay and returns the hash of the compiled package, or "" if the output
+// cannot be compared. No timeout is applied because compilation always
+// terminates, but canceling ctx kills it.
+func (e *Engine) checkCompilationWithOverlay(ctx context.Context, mutCtx *MutationContext) (string, error) {
 	// Get the directory containing the original file for compilation
 	compileDir := filepath.Dir(mutCtx.OriginalPath)
+	outputPath := filepath.Join(mutCtx.MutantDir, "package.a")
 
-	// Build the entire package with overlay to properly resolve dependencies
-	cmd := exec.Command("go", "build", "-overlay="+mutCtx.OverlayPath, ".")
+	// Build the entire package with overlay to properly resolve dependencies.
+	// -trimpath records the original file path instead of the overlay path,
+	// so that the object code only depends on the source content.
+	args := []string{"build", "-trimpath", "-o", outputPath, "-overlay=" + mutCtx.OverlayPath}
+	args = append(args, e.buildFlags...)
+	args = append(args, ".")
+
+	cmd := exec.CommandContext(ctx, "go", args...)
 	cmd.Dir = compileDir
+	cmd.Env = e.commandEnv()
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("compilation error: %s", string(output))
+		return "", fmt.Errorf("compilation error: %s", string(output))
 	}
 
-	return nil
+	hash, err := objectHash(outputPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash compiled package: %w", err)
+	}
+
+	return hash, nil
+}
+
+// originalHash returns the hash of the original package containing path,
+// compiled the same way as its mutants, or "" if it cannot be compiled.
+func (e *Engine) originalHash(ctx context.Context, path string) string {
+	e.originalsMu.Lock()
+
+	build, ok := e.originals[path]
+	if !ok {
+		build = &originalBuild{}
+		e.originals[path] = build
+	}
+
+	e.originalsMu.Unlock()
+
+	build.once.Do(func() {
+		origCtx, err := e.overlay.PrepareOriginal(path)
+		if err != nil {
+			return
+		}
+
+		defer func() {
+			if cleanupErr := e.overlay.CleanupMutation(origCtx); cleanupErr != nil {
+				fmt.Fprintf(e.out, "Warning: failed to cleanup original build: %v\n", cleanupErr)
+			}
+		}()
+
+		build.hash, _ = e.checkCompilationWithOverlay(ctx, origCtx)
+	})
+
+	return build.hash
+}
+
+// buildIDPattern matches the build ID lines of the headers of a package
+// archive. Build IDs hash the inputs of the build, including the source file
+// content, so they differ even if the object code is identical.
+var buildIDPattern = regexp.MustCompile(`build id "[^"]*"\n`)
+
+// objectHash returns the SHA-256 hash of the package archive at path without
+// its build IDs, or "" if path is not a package archive, e.g. an executable
+// built from a main package.
+func objectHash(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if !bytes.HasPrefix(data, []byte("!<arch>\n")) {
+		return "", nil
+	}
+
+	sum := sha256.Sum256(buildIDPattern.ReplaceAll(data, nil))
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// commandEnv returns the environment for go commands. A nil result makes the
+// command inherit the current process environment unchanged.
+func (e *Engine) commandEnv() []string {
+	if len(e.env) == 0 {
+		return nil
+	}
+
+	return append(os.Environ(), e.env...)
 }
 
 // runTestWithOverlay runs tests using the overlay configuration.
-func (e *Engine) runTestWithOverlay(mutCtx *MutationContext, mutant mutation.Mutant, timeout int) mutation.Result {
+func (e *Engine) runTestWithOverlay(ctx context.Context, mutCtx *MutationContext, mutant mutation.Mutant, timeout int) mutation.Result {
 	result := mutation.Result{
 		Mutant: mutant,
 		Status: mutation.StatusError,
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
 	defer cancel()
 
 	// Get the directory containing the original file for running tests
 	testDir := filepath.Dir(mutCtx.OriginalPath)
 
-	cmd := exec.CommandContext(ctx, "go", "test", "-overlay="+mutCtx.OverlayPath, ".")
+	args := append([]string{"test", "-overlay=" + mutCtx.OverlayPath}, e.buildFlags...)
+	args = append(args, e.testFlags...)
+	args = append(args, ".")
+	args = append(args, e.testPackages[testDir]...)
+
+	cmd := exec.CommandContext(ctx, "go", args...)
 	cmd.Dir = testDir
+	cmd.Env = e.commandEnv()
 
 	output, err := cmd.CombinedOutput()
 
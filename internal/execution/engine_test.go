@@ -1,9 +1,13 @@
 package execution
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/sivchari/gomu/internal/mutation"
@@ -305,6 +309,84 @@ func TestRunMutationsWithOptions(t *testing.T) {
 	}
 }
 
+// recordingObserver records the mutants it is notified of.
+type recordingObserver struct {
+	mu       sync.Mutex
+	started  []string
+	finished []mutation.Result
+}
+
+func (o *recordingObserver) MutantStarted(mutant mutation.Mutant) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.started = append(o.started, mutant.ID)
+}
+
+func (o *recordingObserver) MutantFinished(result mutation.Result) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.finished = append(o.finished, result)
+}
+
+func TestRunMutationsWithObserver(t *testing.T) {
+	tempDir := createTempTestProject(t)
+	observer := &recordingObserver{}
+
+	engine, err := New(WithObserver(observer))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	defer engine.Close()
+
+	mutants := []mutation.Mutant{
+		{ID: "test-1", Type: "arithmetic_binary", FilePath: filepath.Join(tempDir, "valid.go"), Line: 4, Column: 9, Original: "+", Mutated: "-"},
+		{ID: "test-2", Type: "arithmetic_binary", FilePath: filepath.Join(tempDir, "valid.go"), Line: 4, Column: 9, Original: "+", Mutated: "*"},
+	}
+
+	results, err := engine.RunMutationsWithOptions(mutants, 2, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(observer.started) != len(mutants) || len(observer.finished) != len(mutants) {
+		t.Fatalf("expected %d started and finished mutants, got %v and %d", len(mutants), observer.started, len(observer.finished))
+	}
+
+	for _, result := range results {
+		if result.ExecutionTime <= 0 {
+			t.Errorf("expected the execution time of %s to be set, got %d", result.Mutant.ID, result.ExecutionTime)
+		}
+	}
+}
+
+func TestRunMutationsContextCanceled(t *testing.T) {
+	tempDir := createTempTestProject(t)
+	observer := &recordingObserver{}
+
+	engine, err := New(WithObserver(observer))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	defer engine.Close()
+
+	mutants := []mutation.Mutant{
+		{ID: "test-1", Type: "arithmetic_binary", FilePath: filepath.Join(tempDir, "valid.go"), Line: 4, Column: 9, Original: "+", Mutated: "-"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := engine.RunMutationsContext(ctx, mutants, 1, 10); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	if len(observer.started) != 0 {
+		t.Errorf("expected no mutant to start, got %v", observer.started)
+	}
+}
+
 func TestRunSingleMutation(t *testing.T) {
 	tempDir := createTempTestProject(t)
 
@@ -373,7 +455,7 @@ func TestRunSingleMutation(t *testing.T) {
 			}
 			defer engine.Close()
 
-			result := engine.runSingleMutation(tt.mutant, tt.timeout)
+			result := engine.runSingleMutation(context.Background(), tt.mutant, tt.timeout)
 
 			if result.Mutant.ID != tt.mutant.ID {
 				t.Errorf("expected mutant ID %s, got %s", tt.mutant.ID, result.Mutant.ID)
@@ -421,13 +503,317 @@ func TestCheckCompilationWithOverlay(t *testing.T) {
 		}
 		defer engine.overlay.CleanupMutation(ctx)
 
-		err = engine.checkCompilationWithOverlay(ctx)
+		_, err = engine.checkCompilationWithOverlay(context.Background(), ctx)
 		if err != nil {
 			t.Errorf("unexpected compilation error: %v", err)
 		}
 	})
 }
 
+func TestRunSingleMutationEquivalent(t *testing.T) {
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod": "module test\n\ngo 1.21\n",
+		// Scale is not inlined, so its body is not part of the export data.
+		"scale.go": `package scale
+
+//go:noinline
+func Scale(n int) int {
+	return n * 1
+}
+`,
+		"scale_test.go": `package scale
+
+import "testing"
+
+func TestScale(t *testing.T) {
+	if Scale(2) != 2 {
+		t.Error("Scale failed")
+	}
+}
+`,
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		mutated      string
+		expectStatus mutation.Status
+	}{
+		{
+			name:         "same object code is equivalent",
+			mutated:      "/",
+			expectStatus: mutation.StatusEquivalent,
+		},
+		{
+			name:         "different object code is tested",
+			mutated:      "+",
+			expectStatus: mutation.StatusKilled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := New()
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			defer engine.Close()
+
+			mutant := mutation.Mutant{
+				ID:       "equivalent-" + tt.mutated,
+				Type:     "arithmetic_binary",
+				FilePath: filepath.Join(tempDir, "scale.go"),
+				Line:     5,
+				Column:   9,
+				Original: "*",
+				Mutated:  tt.mutated,
+			}
+
+			result := engine.runSingleMutation(context.Background(), mutant, 30)
+
+			if result.Status != tt.expectStatus {
+				t.Errorf("expected status %v, got %v\nError: %s\nOutput: %s",
+					tt.expectStatus, result.Status, result.Error, result.Output)
+			}
+
+			if !strings.Contains(result.Mutant.Diff, "+\treturn n "+tt.mutated+" 1") {
+				t.Errorf("expected result to carry the mutant's diff, got:\n%s", result.Mutant.Diff)
+			}
+		})
+	}
+}
+
+func TestObjectHash(t *testing.T) {
+	tempDir := t.TempDir()
+
+	archive := []byte("!<arch>\n__.PKGDEF\ngo object\nbuild id \"abc/def\"\ncode")
+	rebuilt := []byte("!<arch>\n__.PKGDEF\ngo object\nbuild id \"xyz/uvw\"\ncode")
+	executable := []byte("\x7fELF")
+
+	hashes := make([]string, 0, 3)
+
+	for i, data := range [][]byte{archive, rebuilt, executable} {
+		path := filepath.Join(tempDir, fmt.Sprintf("out%d", i))
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+
+		hash, err := objectHash(path)
+		if err != nil {
+			t.Fatalf("objectHash() error = %v", err)
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	if hashes[0] == "" || hashes[0] != hashes[1] {
+		t.Errorf("expected archives differing only in build IDs to hash the same, got %q and %q", hashes[0], hashes[1])
+	}
+
+	if hashes[2] != "" {
+		t.Errorf("expected no hash for an executable, got %q", hashes[2])
+	}
+
+	if _, err := objectHash(filepath.Join(tempDir, "missing")); err == nil {
+		t.Error("expected error for a missing file")
+	}
+}
+
+func TestRunSingleMutationWithFlags(t *testing.T) {
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod": "module test\n\ngo 1.21\n",
+		"calc.go": `package calc
+
+func Add(a, b int) int {
+	return a + b
+}
+`,
+		// Only compiled with -tags integration.
+		"calc_integration_test.go": `//go:build integration
+
+package calc
+
+import "testing"
+
+func TestAddIntegration(t *testing.T) {
+	if Add(1, 2) != 3 {
+		t.Error("Add failed")
+	}
+}
+`,
+		// Only asserts when GOMU_STRICT=1 is injected into the test process.
+		"calc_env_test.go": `package calc
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAddEnv(t *testing.T) {
+	if os.Getenv("GOMU_STRICT") != "1" {
+		t.Skip("GOMU_STRICT not set")
+	}
+
+	if Add(2, 2) != 4 {
+		t.Error("Add failed")
+	}
+}
+`,
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	mutant := mutation.Mutant{
+		ID:       "flags-1",
+		Type:     "arithmetic_binary",
+		FilePath: filepath.Join(tempDir, "calc.go"),
+		Line:     4,
+		Column:   9,
+		Original: "+",
+		Mutated:  "-",
+	}
+
+	tests := []struct {
+		name         string
+		opts         []Option
+		expectStatus mutation.Status
+	}{
+		{
+			name:         "without flags the mutant survives",
+			opts:         nil,
+			expectStatus: mutation.StatusSurvived,
+		},
+		{
+			name:         "build tags enable the killing test",
+			opts:         []Option{WithBuildFlags([]string{"-tags", "integration"})},
+			expectStatus: mutation.StatusKilled,
+		},
+		{
+			name:         "env enables the killing test",
+			opts:         []Option{WithEnv([]string{"GOMU_STRICT=1"}), WithTestFlags([]string{"-count=1"})},
+			expectStatus: mutation.StatusKilled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := New(tt.opts...)
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			defer engine.Close()
+
+			result := engine.runSingleMutation(context.Background(), mutant, 60)
+			if result.Status != tt.expectStatus {
+				t.Errorf("expected status %v, got %v\nError: %s\nOutput: %s",
+					tt.expectStatus, result.Status, result.Error, result.Output)
+			}
+		})
+	}
+}
+
+func TestRunSingleMutationWithTestPackages(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// store has no tests of its own; it is only exercised by api's tests.
+	files := map[string]string{
+		"go.mod": "module example.com/layers\n\ngo 1.21\n",
+		"store/store.go": `package store
+
+func Add(a, b int) int {
+	return a + b
+}
+`,
+		"api/api.go": `package api
+
+import "example.com/layers/store"
+
+func Sum(a, b int) int {
+	return store.Add(a, b)
+}
+`,
+		"api/api_test.go": `package api
+
+import "testing"
+
+func TestSum(t *testing.T) {
+	if Sum(1, 2) != 3 {
+		t.Error("Sum failed")
+	}
+}
+`,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatalf("failed to create directory for %s: %v", name, err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	storeDir := filepath.Join(tempDir, "store")
+
+	mutant := mutation.Mutant{
+		ID:       "layers-1",
+		Type:     "arithmetic_binary",
+		FilePath: filepath.Join(storeDir, "store.go"),
+		Line:     4,
+		Column:   9,
+		Original: "+",
+		Mutated:  "-",
+	}
+
+	tests := []struct {
+		name         string
+		testPackages map[string][]string
+		expectStatus mutation.Status
+	}{
+		{
+			name:         "only the package's own tests run",
+			testPackages: nil,
+			expectStatus: mutation.StatusSurvived,
+		},
+		{
+			name:         "tests of another package kill the mutant",
+			testPackages: map[string][]string{storeDir: {"example.com/layers/api"}},
+			expectStatus: mutation.StatusKilled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := New(WithTestPackages(tt.testPackages))
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			defer engine.Close()
+
+			result := engine.runSingleMutation(context.Background(), mutant, 60)
+			if result.Status != tt.expectStatus {
+				t.Errorf("expected status %v, got %v\nError: %s\nOutput: %s",
+					tt.expectStatus, result.Status, result.Error, result.Output)
+			}
+		})
+	}
+}
+
 func TestIndexedResult(t *testing.T) {
 	tests := []struct {
 		name   string
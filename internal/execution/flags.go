@@ -0,0 +1,55 @@
+package execution
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SplitFlags splits a command-line flag string such as
+// `-tags integration -ldflags "-X main.version=dev"` into individual
+// arguments. Single and double quotes group words; quotes are removed.
+func SplitFlags(s string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		quote   rune
+		inWord  bool
+	)
+
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+
+				continue
+			}
+
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				args = append(args, current.String())
+				current.Reset()
+
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in flags: %s", s)
+	}
+
+	if inWord {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
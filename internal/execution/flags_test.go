@@ -0,0 +1,77 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSplitFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "empty string",
+			input: "",
+			want:  nil,
+		},
+		{
+			name:  "simple flags",
+			input: "-tags integration -race",
+			want:  []string{"-tags", "integration", "-race"},
+		},
+		{
+			name:  "extra whitespace",
+			input: "  -count=1 \t -short  ",
+			want:  []string{"-count=1", "-short"},
+		},
+		{
+			name:  "double quoted value",
+			input: `-ldflags "-X main.version=dev -s"`,
+			want:  []string{"-ldflags", "-X main.version=dev -s"},
+		},
+		{
+			name:  "single quoted value inside word",
+			input: `-ldflags='-s -w' -p 2`,
+			want:  []string{"-ldflags=-s -w", "-p", "2"},
+		},
+		{
+			name:  "empty quoted value",
+			input: `-tags ""`,
+			want:  []string{"-tags", ""},
+		},
+		{
+			name:    "unterminated quote",
+			input:   `-ldflags "-s`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := SplitFlags(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("SplitFlags() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -3,22 +3,19 @@ package execution
 import (
 	"encoding/json"
 	"fmt"
-	"go/ast"
 	"go/format"
-	"go/parser"
-	"go/token"
 	"os"
 	"path/filepath"
 	"time"
 
-	"golang.org/x/tools/go/ast/astutil"
-
 	"github.com/sivchari/gomu/internal/mutation"
 )
 
 // OverlayMutator manages overlay-based mutation without modifying original files.
 type OverlayMutator struct {
 	baseDir string
+	// mutators apply the mutants.
+	mutators []mutation.Mutator
 }
 
 // OverlayConfig represents the JSON structure for go build/test -overlay option.
@@ -33,6 +30,7 @@ type MutationContext struct {
 	MutatedPath  string // Path to the mutated file in temp directory
 	OverlayPath  string // Path to the overlay.json file
 	MutantDir    string // Directory containing this mutant's files
+	Diff         string // Unified diff from the formatted original to the mutated file
 }
 
 // NewOverlayMutator creates a new overlay-based mutator.
@@ -43,7 +41,8 @@ func NewOverlayMutator() (*OverlayMutator, error) {
 	}
 
 	return &OverlayMutator{
-		baseDir: baseDir,
+		baseDir:  baseDir,
+		mutators: mutation.Mutators(),
 	}, nil
 }
 
@@ -64,7 +63,8 @@ func (om *OverlayMutator) PrepareMutation(mutant mutation.Mutant) (*MutationCont
 	// Create mutated file
 	mutatedPath := filepath.Join(mutantDir, filepath.Base(mutant.FilePath))
 
-	if err := om.createMutatedFile(mutant, originalPath, mutatedPath); err != nil {
+	diff, err := writeMutatedFile(mutant, originalPath, mutatedPath, om.mutators)
+	if err != nil {
 		// Cleanup on failure
 		os.RemoveAll(mutantDir)
 
@@ -85,6 +85,55 @@ func (om *OverlayMutator) PrepareMutation(mutant mutation.Mutant) (*MutationCont
 		MutatedPath:  mutatedPath,
 		OverlayPath:  overlayPath,
 		MutantDir:    mutantDir,
+		Diff:         diff,
+	}, nil
+}
+
+// PrepareOriginal prepares an overlay that replaces the file at path with its
+// gofmt-formatted content, so that the original package compiles from the
+// same layout as the mutated files, which are always formatted.
+func (om *OverlayMutator) PrepareOriginal(path string) (*MutationContext, error) {
+	originalPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	src, err := os.ReadFile(originalPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source file: %w", err)
+	}
+
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format source file: %w", err)
+	}
+
+	originalDir, err := os.MkdirTemp(om.baseDir, "original_")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create original directory: %w", err)
+	}
+
+	formattedPath := filepath.Join(originalDir, filepath.Base(originalPath))
+
+	if err := os.WriteFile(formattedPath, formatted, 0600); err != nil {
+		os.RemoveAll(originalDir)
+
+		return nil, fmt.Errorf("failed to write formatted file: %w", err)
+	}
+
+	overlayPath := filepath.Join(originalDir, "overlay.json")
+
+	if err := om.generateOverlayJSON(originalPath, formattedPath, overlayPath); err != nil {
+		os.RemoveAll(originalDir)
+
+		return nil, fmt.Errorf("failed to generate overlay.json: %w", err)
+	}
+
+	return &MutationContext{
+		OriginalPath: originalPath,
+		MutatedPath:  formattedPath,
+		OverlayPath:  overlayPath,
+		MutantDir:    originalDir,
 	}, nil
 }
 
@@ -110,82 +159,45 @@ func (om *OverlayMutator) Cleanup() error {
 	return nil
 }
 
-// createMutatedFile creates a mutated version of the source file.
-func (om *OverlayMutator) createMutatedFile(mutant mutation.Mutant, originalPath, mutatedPath string) error {
+// writeMutatedFile writes the mutated version of the source file at
+// originalPath to mutatedPath, which may be the same path, and returns its
+// unified diff from the formatted source file. It fails if the mutant cannot
+// be located or does not change the expected span. The mutant is applied with
+// mutators.
+func writeMutatedFile(mutant mutation.Mutant, originalPath, mutatedPath string, mutators []mutation.Mutator) (string, error) {
 	src, err := os.ReadFile(originalPath)
 	if err != nil {
-		return fmt.Errorf("failed to read source file: %w", err)
+		return "", fmt.Errorf("failed to read source file: %w", err)
 	}
 
-	fset := token.NewFileSet()
-
-	file, err := parser.ParseFile(fset, originalPath, src, parser.ParseComments)
+	mutated, err := mutation.MutateSource(originalPath, src, mutant, mutators)
 	if err != nil {
-		return fmt.Errorf("failed to parse file: %w", err)
+		return "", fmt.Errorf("failed to mutate source: %w", err)
 	}
 
-	mutated := false
-
-	astutil.Apply(file, nil, func(c *astutil.Cursor) bool {
-		if mutated {
-			return false
-		}
-
-		node := c.Node()
-		if node == nil {
-			return true
-		}
-
-		pos := fset.Position(node.Pos())
-		if pos.Line == mutant.Line && pos.Column == mutant.Column {
-			mutated = om.applyMutationToNode(node, func(replacement ast.Node) {
-				c.Replace(replacement)
-			}, mutant)
-		}
-
-		return !mutated
-	})
-
-	if !mutated {
-		return fmt.Errorf("failed to find mutation target at %s:%d:%d", originalPath, mutant.Line, mutant.Column)
-	}
-
-	f, err := os.Create(mutatedPath)
-	if err != nil {
-		return fmt.Errorf("failed to create mutated file: %w", err)
+	if err := os.WriteFile(mutatedPath, mutated, 0600); err != nil {
+		return "", fmt.Errorf("failed to write mutated file: %w", err)
 	}
 
-	defer f.Close()
-
-	if err := format.Node(f, fset, file); err != nil {
-		return fmt.Errorf("failed to write mutated file: %w", err)
+	// The mutated file is formatted, so it is compared with the formatted
+	// source to only show the mutation.
+	if formatted, err := format.Source(src); err == nil {
+		src = formatted
 	}
 
-	return nil
+	return mutation.UnifiedDiff(displayPath(originalPath), src, mutated), nil
 }
 
-// applyMutationToNode applies the mutation to a specific AST node.
-func (om *OverlayMutator) applyMutationToNode(node ast.Node, replaceFunc func(ast.Node), mutant mutation.Mutant) bool {
-	engine, err := mutation.New()
-	if err != nil {
-		return false
-	}
-
-	for _, m := range engine.GetMutators() {
-		if ca, ok := m.(mutation.CursorApplier); ok {
-			if ca.ApplyWithCursor(node, replaceFunc, mutant) {
-				return true
-			}
-		}
-	}
-
-	for _, m := range engine.GetMutators() {
-		if m.Apply(node, mutant) {
-			return true
+// displayPath returns path relative to the current directory if it is inside
+// it, or path itself, with forward slashes.
+func displayPath(path string) string {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, path); err == nil && filepath.IsLocal(rel) {
+			path = rel
 		}
 	}
 
-	return false
+	return filepath.ToSlash(path)
 }
 
 // generateOverlayJSON creates the overlay.json file for go build/test.
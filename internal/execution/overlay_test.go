@@ -3,7 +3,6 @@ package execution
 import (
 	_ "embed"
 	"encoding/json"
-	"go/ast"
 	"os"
 	"path/filepath"
 	"strings"
@@ -26,6 +25,90 @@ var errorhandlingSrc string
 //go:embed testdata/errorhandling_nil.go
 var errorhandlingNilSrc string
 
+//go:embed testdata/errorpaths.go
+var errorpathsSrc string
+
+//go:embed testdata/errorpaths_nil.go
+var errorpathsNilSrc string
+
+//go:embed testdata/errorpaths_wrapv.go
+var errorpathsWrapVSrc string
+
+//go:embed testdata/errorpaths_notis.go
+var errorpathsNotIsSrc string
+
+//go:embed testdata/errorpaths_nocheck.go
+var errorpathsNoCheckSrc string
+
+//go:embed testdata/errorsentinel.go
+var errorsentinelSrc string
+
+//go:embed testdata/errorsentinel_err.go
+var errorsentinelErrSrc string
+
+//go:embed testdata/concurrency.go
+var concurrencySrc string
+
+//go:embed testdata/concurrency_nolock.go
+var concurrencyNoLockSrc string
+
+//go:embed testdata/concurrency_default.go
+var concurrencyDefaultSrc string
+
+//go:embed testdata/context.go
+var contextSrc string
+
+//go:embed testdata/context_nocancel.go
+var contextNoCancelSrc string
+
+//go:embed testdata/context_notimeout.go
+var contextNoTimeoutSrc string
+
+//go:embed testdata/collection.go
+var collectionSrc string
+
+//go:embed testdata/collection_noelem.go
+var collectionNoElemSrc string
+
+//go:embed testdata/collection_okfalse.go
+var collectionOkFalseSrc string
+
+//go:embed testdata/switch.go
+var switchSrc string
+
+//go:embed testdata/switch_removed.go
+var switchRemovedSrc string
+
+//go:embed testdata/callswap.go
+var callSwapSrc string
+
+//go:embed testdata/callswap_suffix.go
+var callSwapSuffixSrc string
+
+//go:embed testdata/generics.go
+var genericsSrc string
+
+//go:embed testdata/generics_zero.go
+var genericsZeroSrc string
+
+//go:embed testdata/constant.go
+var constantSrc string
+
+//go:embed testdata/constant_unit.go
+var constantUnitSrc string
+
+//go:embed testdata/deferpanic.go
+var deferPanicSrc string
+
+//go:embed testdata/deferpanic_return.go
+var deferPanicReturnSrc string
+
+//go:embed testdata/pointer.go
+var pointerSrc string
+
+//go:embed testdata/pointer_alias.go
+var pointerAliasSrc string
+
 //go:embed testdata/return.go
 var returnSrc string
 
@@ -122,6 +205,12 @@ var stmtRemovalSrc string
 //go:embed testdata/stmtremoval_removed.go
 var stmtRemovalRemovedSrc string
 
+//go:embed testdata/duplicate.go
+var duplicateSrc string
+
+//go:embed testdata/duplicate_empty.go
+var duplicateEmptySrc string
+
 func TestNewOverlayMutator(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -318,6 +407,19 @@ func TestPrepareMutation(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "fails with mutation leaving the source unchanged",
+			mutant: mutation.Mutant{
+				ID:       "test-4",
+				Type:     "arithmetic_binary",
+				FilePath: filepath.Join(tempDir, "calc.go"),
+				Line:     4,
+				Column:   9,
+				Original: "+",
+				Mutated:  "+",
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -676,39 +778,6 @@ func TestMultipleMutationsOnSameFile(t *testing.T) {
 	}
 }
 
-func TestApplyMutationToNodeWithOverlay(t *testing.T) {
-	tests := []struct {
-		name         string
-		mutationType string
-		expectResult bool
-	}{
-		{"arithmetic_binary", "arithmetic_binary", false},
-		{"conditional_binary", "conditional_binary", false},
-		{"logical_binary", "logical_binary", false},
-		{"unknown_type", "unknown_type", false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mutator, err := NewOverlayMutator()
-			if err != nil {
-				t.Fatalf("failed to create mutator: %v", err)
-			}
-			defer mutator.Cleanup()
-
-			// Use nil node - method checks type anyway
-			result := mutator.applyMutationToNode(nil, func(_ ast.Node) {}, mutation.Mutant{
-				Type:    tt.mutationType,
-				Mutated: "+",
-			})
-
-			if result != tt.expectResult {
-				t.Errorf("expected %v, got %v", tt.expectResult, result)
-			}
-		})
-	}
-}
-
 // TestMutateAndApplyIntegration verifies that mutants generated by Mutate()
 // can be successfully applied by PrepareMutation(), and that the mutated file
 // matches the expected output exactly. This is the core integration test for
@@ -861,6 +930,142 @@ func TestMutateAndApplyIntegration(t *testing.T) {
 			mutated:    "<removed>",
 			want:       exprRemovalRemovedSrc,
 		},
+		{
+			name:       "returned error expression replaced with nil",
+			src:        errorpathsSrc,
+			mutantType: "error_nilify",
+			original:   "ErrNotFound",
+			mutated:    "nil",
+			want:       errorpathsNilSrc,
+		},
+		{
+			name:       "wrap verb replaced with %v",
+			src:        errorpathsSrc,
+			mutantType: "error_wrap_verb",
+			original:   `"load %s: %w"`,
+			mutated:    `"load %s: %v"`,
+			want:       errorpathsWrapVSrc,
+		},
+		{
+			name:       "errors.Is negated",
+			src:        errorpathsSrc,
+			mutantType: "error_is_negate",
+			original:   "errors.Is(err, ErrNotFound)",
+			mutated:    "!errors.Is(err, ErrNotFound)",
+			want:       errorpathsNotIsSrc,
+		},
+		{
+			name:       "error check early return removed",
+			src:        errorpathsSrc,
+			mutantType: "error_check_removal",
+			original:   "if err != nil {...}",
+			mutated:    "<removed>",
+			want:       errorpathsNoCheckSrc,
+		},
+		{
+			name:       "nil error replaced with sentinel and errors imported",
+			src:        errorsentinelSrc,
+			mutantType: "error_sentinel",
+			original:   "nil",
+			mutated:    `errors.New("gomu: injected error")`,
+			want:       errorsentinelErrSrc,
+		},
+		{
+			name:       "lock and deferred unlock removed together",
+			src:        concurrencySrc,
+			mutantType: "lock_pair_removal",
+			original:   "c.mu.Lock() ... defer c.mu.Unlock()",
+			mutated:    "<removed>",
+			want:       concurrencyNoLockSrc,
+		},
+		{
+			name:       "default case inserted into select",
+			src:        concurrencySrc,
+			mutantType: "select_default_insert",
+			original:   "select {...}",
+			mutated:    "select {...; default:}",
+			want:       concurrencyDefaultSrc,
+		},
+		{
+			name:       "deferred cancel replaced with blank assignment",
+			src:        contextSrc,
+			mutantType: "context_cancel_removal",
+			original:   "defer cancel()",
+			mutated:    "_ = cancel",
+			want:       contextNoCancelSrc,
+		},
+		{
+			name:       "context timeout maximized and math imported",
+			src:        contextSrc,
+			mutantType: "context_timeout",
+			original:   "timeout",
+			mutated:    "math.MaxInt64",
+			want:       contextNoTimeoutSrc,
+		},
+		{
+			name:       "slice literal element removed",
+			src:        collectionSrc,
+			mutantType: "composite_element_removal",
+			original:   `"for"`,
+			mutated:    "<removed>",
+			want:       collectionNoElemSrc,
+		},
+		{
+			name:       "map lookup ok forced to false",
+			src:        collectionSrc,
+			mutantType: "map_lookup_ok_false",
+			original:   "n, ok := index[word]",
+			mutated:    "n, ok := index[word], false",
+			want:       collectionOkFalseSrc,
+		},
+		{
+			name:       "switch case removed",
+			src:        switchSrc,
+			mutantType: "switch_case_removal",
+			original:   `case "put", "post":`,
+			mutated:    "<removed>",
+			want:       switchRemovedSrc,
+		},
+		{
+			name:       "standard library call swapped",
+			src:        callSwapSrc,
+			mutantType: "call_swap",
+			original:   "strings.HasPrefix",
+			mutated:    "strings.HasSuffix",
+			want:       callSwapSuffixSrc,
+		},
+		{
+			name:       "type parameter compared with zero value",
+			src:        genericsSrc,
+			mutantType: "type_param_zero_compare",
+			original:   "item == target",
+			mutated:    "item == *new(T)",
+			want:       genericsZeroSrc,
+		},
+		{
+			name:       "duration unit replaced",
+			src:        constantSrc,
+			mutantType: "duration_unit",
+			original:   "time.Second",
+			mutated:    "time.Millisecond",
+			want:       constantUnitSrc,
+		},
+		{
+			name:       "panic replaced with return",
+			src:        deferPanicSrc,
+			mutantType: "panic_to_return",
+			original:   `panic("empty name")`,
+			mutated:    "return",
+			want:       deferPanicReturnSrc,
+		},
+		{
+			name:       "pointer copy swapped with alias",
+			src:        pointerSrc,
+			mutantType: "pointer_copy_swap",
+			original:   "draft := *acct",
+			mutated:    "draft := acct",
+			want:       pointerAliasSrc,
+		},
 		{
 			name:       "statement removed",
 			src:        stmtRemovalSrc,
@@ -886,8 +1091,9 @@ func TestMutateAndApplyIntegration(t *testing.T) {
 				t.Fatalf("failed to create main.go: %v", err)
 			}
 
-			// Pass 1: generate mutants
-			engine, err := mutation.New()
+			// Pass 1: generate mutants, keeping duplicates so that each
+			// mutator's own mutant is found
+			engine, err := mutation.New(mutation.WithDuplicatePruning(false))
 			if err != nil {
 				t.Fatalf("failed to create mutation engine: %v", err)
 			}
@@ -933,10 +1139,77 @@ func TestMutateAndApplyIntegration(t *testing.T) {
 			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
 				t.Errorf("mutated file mismatch (-want +got):\n%s", diff)
 			}
+
+			wantDiff := mutation.UnifiedDiff(filepath.ToSlash(target.FilePath), []byte(tt.src), []byte(tt.want))
+			if ctx.Diff == "" || ctx.Diff != wantDiff {
+				t.Errorf("diff mismatch: got\n%s\nwant\n%s", ctx.Diff, wantDiff)
+			}
 		})
 	}
 }
 
+func TestMutateAndApplyPrunedDuplicates(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte("module test\n\ngo 1.21\n"), 0600); err != nil {
+		t.Fatalf("failed to create go.mod: %v", err)
+	}
+
+	srcPath := filepath.Join(tempDir, "main.go")
+	if err := os.WriteFile(srcPath, []byte(duplicateSrc), 0600); err != nil {
+		t.Fatalf("failed to create main.go: %v", err)
+	}
+
+	engine, err := mutation.New()
+	if err != nil {
+		t.Fatalf("failed to create mutation engine: %v", err)
+	}
+
+	mutants, err := engine.GenerateMutants(srcPath)
+	if err != nil {
+		t.Fatalf("failed to generate mutants: %v", err)
+	}
+
+	// return_zero_value and string_literal both replace "hello" with "".
+	if got := engine.DuplicateMutants(); got != 1 {
+		t.Errorf("DuplicateMutants() = %d, want 1", got)
+	}
+
+	overlay, err := NewOverlayMutator()
+	if err != nil {
+		t.Fatalf("failed to create overlay mutator: %v", err)
+	}
+	defer overlay.Cleanup()
+
+	seen := make(map[string]string)
+
+	for _, m := range mutants {
+		ctx, err := overlay.PrepareMutation(m)
+		if err != nil {
+			t.Fatalf("PrepareMutation(%s) failed: %v", m.Type, err)
+		}
+
+		got, err := os.ReadFile(ctx.MutatedPath)
+		if err != nil {
+			t.Fatalf("failed to read mutated file: %v", err)
+		}
+
+		overlay.CleanupMutation(ctx)
+
+		if prev, ok := seen[string(got)]; ok {
+			t.Errorf("%s mutant duplicates %s mutant", m.Type, prev)
+		}
+
+		seen[string(got)] = m.Type
+	}
+
+	if got := seen[duplicateEmptySrc]; got != "return_zero_value" {
+		t.Errorf("empty string return kept from %q, want the return_zero_value mutant", got)
+	}
+}
+
 // Helper function to create a temporary test project for overlay tests.
 func createOverlayTestProject(t *testing.T) string {
 	tempDir := t.TempDir()
@@ -0,0 +1,7 @@
+package main
+
+import "strings"
+
+func IsCommand(line string) bool {
+	return strings.HasPrefix(line, "/")
+}
@@ -0,0 +1,12 @@
+package main
+
+var keywords = []string{
+	"if",
+
+	"func",
+}
+
+func Lookup(index map[string]int, word string) (int, bool) {
+	n, ok := index[word]
+	return n, ok
+}
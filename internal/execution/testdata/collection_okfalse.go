@@ -0,0 +1,12 @@
+package main
+
+var keywords = []string{
+	"if",
+	"for",
+	"func",
+}
+
+func Lookup(index map[string]int, word string) (int, bool) {
+	n, ok := index[word], false
+	return n, ok
+}
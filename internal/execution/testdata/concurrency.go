@@ -0,0 +1,22 @@
+package main
+
+import "sync"
+
+type Counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (c *Counter) Inc() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n++
+}
+
+func Poll(ch chan int) int {
+	v := -1
+	select {
+	case v = <-ch:
+	}
+	return v
+}
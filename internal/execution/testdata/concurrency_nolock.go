@@ -0,0 +1,21 @@
+package main
+
+import "sync"
+
+type Counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (c *Counter) Inc() {
+
+	c.n++
+}
+
+func Poll(ch chan int) int {
+	v := -1
+	select {
+	case v = <-ch:
+	}
+	return v
+}
@@ -0,0 +1,13 @@
+package main
+
+import "time"
+
+const maxRetries = 3
+
+func RetryDelay(attempt int) time.Duration {
+	if attempt >= maxRetries {
+		return 0
+	}
+
+	return time.Duration(attempt) * time.Second
+}
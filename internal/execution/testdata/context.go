@@ -0,0 +1,17 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+func Fetch(ctx context.Context, ch chan int, timeout time.Duration) int {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	select {
+	case <-ctx.Done():
+		return 0
+	case v := <-ch:
+		return v
+	}
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+func Fetch(ctx context.Context, ch chan int, timeout time.Duration) int {
+	ctx, cancel := context.WithTimeout(ctx, math.MaxInt64)
+	defer cancel()
+	select {
+	case <-ctx.Done():
+		return 0
+	case v := <-ch:
+		return v
+	}
+}
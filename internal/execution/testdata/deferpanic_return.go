@@ -0,0 +1,7 @@
+package main
+
+func Validate(name string) {
+	if name == "" {
+		return
+	}
+}
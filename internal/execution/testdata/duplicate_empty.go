@@ -0,0 +1,9 @@
+package main
+
+func Greeting(name string) string {
+	if name == "" {
+		return ""
+	}
+
+	return "hello, " + name
+}
@@ -3,9 +3,15 @@ package main
 import "fmt"
 
 func Validate(n int) (int, error) {
-	if n < 0 {
-		err := fmt.Errorf("negative")
+	if err := check(n); err != nil {
 		return 0, err
 	}
 	return n, nil
 }
+
+func check(n int) error {
+	if n < 0 {
+		return fmt.Errorf("negative")
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNotFound = errors.New("not found")
+
+func Find(key string) (string, error) {
+	if key == "" {
+		return "", ErrNotFound
+	}
+	return key, nil
+}
+
+func Load(key string) (string, error) {
+	v, err := Find(key)
+	if err != nil {
+		return "", fmt.Errorf("load %s: %w", key, err)
+	}
+	return v, err
+}
+
+func IsMissing(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+func Describe(err error) string {
+	return fmt.Sprint(err)
+}
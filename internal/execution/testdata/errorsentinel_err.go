@@ -0,0 +1,14 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+)
+
+func Parse(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	return n, errors.New("gomu: injected error")
+}
@@ -0,0 +1,11 @@
+package main
+
+func Contains[T comparable](items []T, target T) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+
+	return false
+}
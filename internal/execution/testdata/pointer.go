@@ -0,0 +1,12 @@
+package main
+
+type Account struct {
+	Balance int
+}
+
+func Preview(acct *Account, amount int) int {
+	draft := *acct
+	draft.Balance -= amount
+
+	return draft.Balance
+}
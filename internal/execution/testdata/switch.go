@@ -0,0 +1,12 @@
+package main
+
+func Dispatch(op string) int {
+	switch op {
+	case "get":
+		return 1
+	case "put", "post":
+		return 2
+	default:
+		return 0
+	}
+}
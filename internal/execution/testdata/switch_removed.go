@@ -0,0 +1,11 @@
+package main
+
+func Dispatch(op string) int {
+	switch op {
+	case "get":
+		return 1
+
+	default:
+		return 0
+	}
+}
@@ -5,12 +5,22 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/sivchari/gomu/internal/mutation"
 )
 
+// DefaultFile is the history file written by mutation testing runs.
+const DefaultFile = ".gomu_history.json"
+
+// ErrMutantNotFound is returned when no mutant in the history matches an ID.
+var ErrMutantNotFound = errors.New("mutant not found in history")
+
 // Store manages mutation testing history for incremental analysis.
 type Store struct {
 	filepath string
@@ -99,6 +109,44 @@ func (s *Store) GetEntry(filePath string) (Entry, bool) {
 	return entry, exists
 }
 
+// FindResult returns the result of the mutant with the given ID and the path
+// of its file. id may also be the end of an ID after a path separator, such
+// as calc.go_3, if it matches a single mutant.
+func (s *Store) FindResult(id string) (string, mutation.Result, error) {
+	var (
+		matches []string
+		found   mutation.Result
+		file    string
+	)
+
+	want := filepath.ToSlash(id)
+
+	for _, filePath := range slices.Sorted(maps.Keys(s.entries)) {
+		for _, result := range s.entries[filePath].Results {
+			mutantID := filepath.ToSlash(result.Mutant.ID)
+
+			if mutantID == want {
+				return filePath, result, nil
+			}
+
+			if strings.HasSuffix(mutantID, "/"+want) {
+				matches = append(matches, result.Mutant.ID)
+				found = result
+				file = filePath
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", mutation.Result{}, fmt.Errorf("%w: %s", ErrMutantNotFound, id)
+	case 1:
+		return file, found, nil
+	default:
+		return "", mutation.Result{}, fmt.Errorf("mutant ID %s is ambiguous: %s", id, strings.Join(matches, ", "))
+	}
+}
+
 // UpdateFile updates the history entry for a file.
 func (s *Store) UpdateFile(filePath string, mutants []mutation.Mutant, results []mutation.Result) {
 	s.UpdateFileWithHashes(filePath, mutants, results, "", "")
@@ -1,6 +1,7 @@
 package history
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -413,3 +414,84 @@ func TestGetStats_EmptyStore(t *testing.T) {
 		t.Error("Expected LastUpdated to be set")
 	}
 }
+
+func TestFindResult(t *testing.T) {
+	store, err := New(filepath.Join(t.TempDir(), "history.json"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	result := func(id string) mutation.Result {
+		return mutation.Result{Mutant: mutation.Mutant{ID: id}, Status: mutation.StatusSurvived}
+	}
+
+	store.UpdateFile("/src/a/calc.go", nil, []mutation.Result{result("/src/a/calc.go_0"), result("/src/a/calc.go_1")})
+	store.UpdateFile("/src/b/calc.go", nil, []mutation.Result{result("/src/b/calc.go_0")})
+
+	tests := []struct {
+		name     string
+		id       string
+		wantFile string
+		wantID   string
+		wantErr  bool
+	}{
+		{
+			name:     "full ID",
+			id:       "/src/b/calc.go_0",
+			wantFile: "/src/b/calc.go",
+			wantID:   "/src/b/calc.go_0",
+		},
+		{
+			name:     "unique suffix",
+			id:       "calc.go_1",
+			wantFile: "/src/a/calc.go",
+			wantID:   "/src/a/calc.go_1",
+		},
+		{
+			name:     "suffix with directory",
+			id:       "b/calc.go_0",
+			wantFile: "/src/b/calc.go",
+			wantID:   "/src/b/calc.go_0",
+		},
+		{
+			name:    "ambiguous suffix",
+			id:      "calc.go_0",
+			wantErr: true,
+		},
+		{
+			name:    "suffix not at a path separator",
+			id:      "alc.go_1",
+			wantErr: true,
+		},
+		{
+			name:    "unknown ID",
+			id:      "other.go_0",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, got, err := store.FindResult(tt.id)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %s, got mutant %s", tt.id, got.Mutant.ID)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("FindResult(%s) error = %v", tt.id, err)
+			}
+
+			if file != tt.wantFile || got.Mutant.ID != tt.wantID {
+				t.Errorf("FindResult(%s) = %s, %s; want %s, %s", tt.id, file, got.Mutant.ID, tt.wantFile, tt.wantID)
+			}
+		})
+	}
+
+	if _, _, err := store.FindResult("other.go_0"); !errors.Is(err, ErrMutantNotFound) {
+		t.Errorf("Expected ErrMutantNotFound, got %v", err)
+	}
+}
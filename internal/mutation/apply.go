@@ -0,0 +1,427 @@
+package mutation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"unicode"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+var (
+	// errUnchanged is returned when an applied mutant leaves the source
+	// unchanged.
+	errUnchanged = errors.New("mutated source is identical to the original")
+	// errOutsideSpan is returned when an applied mutant changes the source
+	// outside the node it was generated from.
+	errOutsideSpan = errors.New("mutated source differs outside the mutated node")
+)
+
+// ApplyMutant applies mutant to file in place and reports whether the
+// mutation was applied. Imports required by the mutated code are added to the
+// file.
+//
+// Mutants generated by the engine carry a locator: the mutator that generated
+// them and the kind and offsets of the node it was generated from. Such
+// mutants are only dispatched to their mutator, first at that node and then
+// at the nodes inside it at the mutant's line and column, so that other nodes
+// starting at the same position are never mutated in its place. Mutants
+// without a locator are dispatched to every mutator at the first node found
+// at their line and column. Higher-order mutants are applied component by
+// component and are only reported as applied if all components were.
+func ApplyMutant(fset *token.FileSet, file *ast.File, mutant Mutant, mutators []Mutator) bool {
+	for _, m := range components(mutant) {
+		applied := applyMutant(fset, file, m, mutators)
+		if applied == nil {
+			return false
+		}
+
+		addRequiredImports(fset, file, applied, m)
+	}
+
+	return true
+}
+
+// MutateSource applies mutant to src, the content of filename, and returns the
+// formatted mutated source. As a self-check, it fails if the mutant leaves the
+// source unchanged or, for mutants with a locator, changes it outside the node
+// the mutant was generated from. Higher-order mutants are applied component by
+// component.
+func MutateSource(filename string, src []byte, mutant Mutant, mutators []Mutator) ([]byte, error) {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	original, err := formatFile(fset, file)
+	if err != nil {
+		return nil, err
+	}
+
+	index := locatedIndex(fset, file, mutant)
+	parts := components(mutant)
+	applied := make([]Mutator, len(parts))
+
+	for i, m := range parts {
+		if applied[i] = applyMutant(fset, file, m, mutators); applied[i] == nil {
+			return nil, fmt.Errorf("failed to find mutation target at %s:%d:%d", filename, m.Line, m.Column)
+		}
+	}
+
+	mutated, err := formatFile(fset, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkMutatedSpan(original, mutated, index); err != nil {
+		return nil, fmt.Errorf("invalid mutation %s at %s:%d:%d: %w", mutant.Type, filename, mutant.Line, mutant.Column, err)
+	}
+
+	added := false
+
+	for i, m := range parts {
+		if addRequiredImports(fset, file, applied[i], m) {
+			added = true
+		}
+	}
+
+	if added {
+		if mutated, err = formatFile(fset, file); err != nil {
+			return nil, err
+		}
+	}
+
+	return mutated, nil
+}
+
+// SetLocator records node, the node mutant is generated from, and mutator as
+// the locator of mutant.
+func SetLocator(mutant *Mutant, fset *token.FileSet, node ast.Node, mutator Mutator) {
+	mutant.Mutator = mutator.Name()
+	mutant.NodeKind = nodeKind(node)
+	mutant.Offset = fset.Position(node.Pos()).Offset
+	mutant.EndOffset = fset.Position(node.End()).Offset
+}
+
+// hasLocator reports whether mutant records the node it was generated from.
+func hasLocator(mutant Mutant) bool {
+	return mutant.NodeKind != ""
+}
+
+// isLocated reports whether node is the node mutant was generated from.
+func isLocated(fset *token.FileSet, node ast.Node, mutant Mutant) bool {
+	return nodeKind(node) == mutant.NodeKind &&
+		fset.Position(node.Pos()).Offset == mutant.Offset &&
+		fset.Position(node.End()).Offset == mutant.EndOffset
+}
+
+// nodeKind returns the Go type of node, e.g. *ast.BinaryExpr.
+func nodeKind(node ast.Node) string {
+	return fmt.Sprintf("%T", node)
+}
+
+// applyMutant applies mutant to file and returns the mutator that applied it,
+// or nil.
+func applyMutant(fset *token.FileSet, file *ast.File, mutant Mutant, mutators []Mutator) Mutator {
+	if mutant.Mutator != "" {
+		mutators = ownedBy(mutant.Mutator, mutators)
+		if len(mutators) == 0 {
+			return nil
+		}
+	}
+
+	if !hasLocator(mutant) {
+		return applyAt(fset, file, mutant, mutators, func(ast.Node, bool) bool { return true })
+	}
+
+	// The located node is tried before the nodes inside it, so that for
+	// a + b + c the outer expression is mutated, not a + b.
+	applied := applyAt(fset, file, mutant, mutators, func(node ast.Node, _ bool) bool {
+		return isLocated(fset, node, mutant)
+	})
+	if applied != nil {
+		return applied
+	}
+
+	return applyAt(fset, file, mutant, mutators, func(_ ast.Node, inside bool) bool {
+		return inside
+	})
+}
+
+// applyAt applies mutant at the first node in post-order that starts at the
+// mutant's line and column and satisfies match, and returns the mutator that
+// applied it, or nil. match is told whether the node is inside the node the
+// mutant was generated from.
+func applyAt(fset *token.FileSet, file *ast.File, mutant Mutant, mutators []Mutator, match func(node ast.Node, inside bool) bool) Mutator {
+	var (
+		applied Mutator
+		inside  bool
+	)
+
+	pre := func(c *astutil.Cursor) bool {
+		if c.Node() != nil && hasLocator(mutant) && isLocated(fset, c.Node(), mutant) {
+			inside = true
+		}
+
+		return applied == nil
+	}
+
+	post := func(c *astutil.Cursor) bool {
+		if applied != nil {
+			return false
+		}
+
+		node := c.Node()
+		if node == nil {
+			return true
+		}
+
+		pos := fset.Position(node.Pos())
+		if pos.Line == mutant.Line && pos.Column == mutant.Column && match(node, inside) {
+			applied = applyToNode(node, func(replacement ast.Node) {
+				switch {
+				case replacement != nil:
+					c.Replace(replacement)
+				case c.Index() >= 0:
+					c.Delete()
+				}
+			}, mutant, mutators)
+
+			if applied == nil {
+				if block, ok := c.Parent().(*ast.BlockStmt); ok && c.Index() >= 0 {
+					applied = applyInBlock(block, c.Index(), mutant, mutators)
+				}
+			}
+		}
+
+		if applied == nil && hasLocator(mutant) && isLocated(fset, node, mutant) {
+			inside = false
+		}
+
+		return applied == nil
+	}
+
+	astutil.Apply(file, pre, post)
+
+	return applied
+}
+
+// ownedBy returns the mutators named name.
+func ownedBy(name string, mutators []Mutator) []Mutator {
+	for _, m := range mutators {
+		if m.Name() == name {
+			return []Mutator{m}
+		}
+	}
+
+	return nil
+}
+
+// addRequiredImports adds the imports the applied mutant requires to file and
+// reports whether any were added.
+func addRequiredImports(fset *token.FileSet, file *ast.File, applied Mutator, mutant Mutant) bool {
+	ir, ok := applied.(ImportRequirer)
+	if !ok {
+		return false
+	}
+
+	added := false
+
+	for _, path := range ir.RequiredImports(mutant) {
+		if astutil.AddImport(fset, file, path) {
+			added = true
+		}
+	}
+
+	return added
+}
+
+// applyToNode applies the mutation to a specific AST node and returns the
+// mutator that applied it, or nil. Mutators that need to replace the node
+// through its parent are tried first.
+func applyToNode(node ast.Node, replaceFunc func(ast.Node), mutant Mutant, mutators []Mutator) Mutator {
+	for _, m := range mutators {
+		if ca, ok := m.(CursorApplier); ok {
+			if ca.ApplyWithCursor(node, replaceFunc, mutant) {
+				return m
+			}
+		}
+	}
+
+	for _, m := range mutators {
+		if m.Apply(node, mutant) {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// applyInBlock applies the mutation to the index-th statement of block and
+// returns the mutator that applied it, or nil.
+func applyInBlock(block *ast.BlockStmt, index int, mutant Mutant, mutators []Mutator) Mutator {
+	for _, m := range mutators {
+		if ba, ok := m.(BlockApplier); ok {
+			if ba.ApplyInBlock(block, index, mutant) {
+				return m
+			}
+		}
+	}
+
+	return nil
+}
+
+// locatedIndex returns the index of the node mutant was generated from in a
+// pre-order walk of the declarations of file, or -1. Import declarations are
+// skipped because formatting may sort and deduplicate them.
+func locatedIndex(fset *token.FileSet, file *ast.File, mutant Mutant) int {
+	if !hasLocator(mutant) {
+		return -1
+	}
+
+	index := -1
+
+	walkDecls(file, func(node ast.Node, i int) bool {
+		if isLocated(fset, node, mutant) {
+			index = i
+
+			return false
+		}
+
+		return true
+	})
+
+	return index
+}
+
+// walkDecls calls fn for every node of the non-import declarations of file in
+// pre-order, along with the node's index, until fn returns false.
+func walkDecls(file *ast.File, fn func(node ast.Node, index int) bool) {
+	index := 0
+	done := false
+
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			continue
+		}
+
+		ast.Inspect(decl, func(node ast.Node) bool {
+			if done || node == nil {
+				return false
+			}
+
+			if !fn(node, index) {
+				done = true
+
+				return false
+			}
+
+			index++
+
+			return true
+		})
+
+		if done {
+			return
+		}
+	}
+}
+
+// checkMutatedSpan checks that mutated differs from original, both formatted
+// from the same file, and, if index is not negative, that it only differs
+// inside the node at index in original. Whitespace is ignored outside the
+// node, because the printer realigns neighbouring lines.
+func checkMutatedSpan(original, mutated []byte, index int) error {
+	if bytes.Equal(original, mutated) {
+		return errUnchanged
+	}
+
+	if index < 0 {
+		return nil
+	}
+
+	// The formatted original is parsed again to find the node's span in it.
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "", original, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("failed to parse formatted source: %w", err)
+	}
+
+	start, end := -1, -1
+
+	// The printer drops the semicolons of a for clause whose init and post
+	// statements are both gone, so changes to them may reach across the
+	// whole loop header.
+	headers := make(map[ast.Node]*ast.ForStmt)
+
+	walkDecls(file, func(node ast.Node, i int) bool {
+		if loop, ok := node.(*ast.ForStmt); ok {
+			if loop.Init != nil {
+				headers[loop.Init] = loop
+			}
+
+			if loop.Post != nil {
+				headers[loop.Post] = loop
+			}
+		}
+
+		if i != index {
+			return true
+		}
+
+		start = fset.Position(node.Pos()).Offset
+		end = fset.Position(node.End()).Offset
+
+		if loop, ok := headers[node]; ok {
+			start = fset.Position(loop.Pos()).Offset
+			end = fset.Position(loop.Body.Lbrace).Offset
+		}
+
+		return false
+	})
+
+	if start < 0 {
+		return errOutsideSpan
+	}
+
+	// Separators next to the node may be dropped along with it, e.g. the
+	// semicolon after a removed if statement initializer.
+	prefix := bytes.TrimRight(removeSpace(original[:start]), ",;")
+	suffix := bytes.TrimLeft(removeSpace(original[end:]), ",;")
+	got := removeSpace(mutated)
+
+	if !bytes.HasPrefix(got, prefix) || !bytes.HasSuffix(got, suffix) || len(prefix)+len(suffix) > len(got) {
+		return errOutsideSpan
+	}
+
+	return nil
+}
+
+// removeSpace returns a copy of b without white space.
+func removeSpace(b []byte) []byte {
+	return bytes.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+
+		return r
+	}, b)
+}
+
+// formatFile formats file with gofmt style.
+func formatFile(fset *token.FileSet, file *ast.File) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, fmt.Errorf("failed to format file: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
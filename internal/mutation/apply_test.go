@@ -0,0 +1,386 @@
+package mutation
+
+import (
+	"bytes"
+	"errors"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestApplyMutant(t *testing.T) {
+	t.Parallel()
+
+	const src = `package calc
+
+func Add(a, b int) int {
+	return a + b
+}
+`
+
+	tests := []struct {
+		name    string
+		mutant  Mutant
+		applied bool
+		want    string
+	}{
+		{
+			name:    "arithmetic operator replaced",
+			mutant:  Mutant{Type: arithmeticBinaryType, Line: 4, Column: 9, Original: "+", Mutated: "-"},
+			applied: true,
+			want:    "return a - b",
+		},
+		{
+			name:    "no node at position",
+			mutant:  Mutant{Type: arithmeticBinaryType, Line: 2, Column: 1, Original: "+", Mutated: "-"},
+			applied: false,
+			want:    "return a + b",
+		},
+		{
+			name:    "unknown mutation type",
+			mutant:  Mutant{Type: "unknown_type", Line: 4, Column: 9, Original: "+", Mutated: "-"},
+			applied: false,
+			want:    "return a + b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+
+			file, err := parser.ParseFile(fset, "calc.go", src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("failed to parse source: %v", err)
+			}
+
+			if got := ApplyMutant(fset, file, tt.mutant, getAllMutators()); got != tt.applied {
+				t.Errorf("ApplyMutant() = %v, want %v", got, tt.applied)
+			}
+
+			var buf bytes.Buffer
+			if err := format.Node(&buf, fset, file); err != nil {
+				t.Fatalf("failed to format file: %v", err)
+			}
+
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("expected output to contain %q, got:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestApplyToNode_NilNode(t *testing.T) {
+	t.Parallel()
+
+	mutant := Mutant{Type: arithmeticBinaryType, Mutated: "+"}
+
+	if applyToNode(nil, func(_ ast.Node) {}, mutant, getAllMutators()) != nil {
+		t.Error("expected nil node not to be mutated")
+	}
+}
+
+func TestApplyMutant_Locator(t *testing.T) {
+	t.Parallel()
+
+	// a + b and a + b + c start at the same position.
+	const src = `package calc
+
+func Sum(a, b, c int) int {
+	return a + b + c
+}
+`
+
+	tests := []struct {
+		name    string
+		locate  bool
+		mutator string
+		applied bool
+		want    string
+	}{
+		{
+			name:    "located node is mutated",
+			locate:  true,
+			applied: true,
+			want:    "return a + b - c",
+		},
+		{
+			name:    "first node at the position is mutated without a locator",
+			applied: true,
+			want:    "return a - b + c",
+		},
+		{
+			name:    "unknown owning mutator",
+			locate:  true,
+			mutator: "unknown",
+			applied: false,
+			want:    "return a + b + c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+
+			file, err := parser.ParseFile(fset, "calc.go", src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("failed to parse source: %v", err)
+			}
+
+			var outer *ast.BinaryExpr
+
+			ast.Inspect(file, func(n ast.Node) bool {
+				if expr, ok := n.(*ast.BinaryExpr); ok && outer == nil {
+					outer = expr
+				}
+
+				return true
+			})
+
+			mutant := Mutant{Type: arithmeticBinaryType, Line: 4, Column: 9, Original: "+", Mutated: "-"}
+
+			if tt.locate {
+				SetLocator(&mutant, fset, outer, &ArithmeticMutator{})
+			}
+
+			if tt.mutator != "" {
+				mutant.Mutator = tt.mutator
+			}
+
+			if got := ApplyMutant(fset, file, mutant, getAllMutators()); got != tt.applied {
+				t.Errorf("ApplyMutant() = %v, want %v", got, tt.applied)
+			}
+
+			var buf bytes.Buffer
+			if err := format.Node(&buf, fset, file); err != nil {
+				t.Fatalf("failed to format file: %v", err)
+			}
+
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("expected output to contain %q, got:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestMutateSource(t *testing.T) {
+	t.Parallel()
+
+	const src = `package calc
+
+func Add(a, b int) int {
+	return a + b
+}
+
+func Zero() int {
+	return 0
+}
+`
+
+	tests := []struct {
+		name    string
+		mutant  Mutant
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "mutated source",
+			mutant: Mutant{Type: arithmeticBinaryType, Line: 4, Column: 9, Original: "+", Mutated: "-"},
+			want:   "return a - b",
+		},
+		{
+			name:    "unchanged source",
+			mutant:  Mutant{Type: returnZeroValueType, Line: 8, Column: 2, Original: "0", Mutated: "0"},
+			wantErr: errUnchanged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := MutateSource("calc.go", []byte(src), tt.mutant, getAllMutators())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MutateSource() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !strings.Contains(string(got), tt.want) {
+				t.Errorf("expected output to contain %q, got:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMutateSource_TargetNotFound(t *testing.T) {
+	t.Parallel()
+
+	mutant := Mutant{Type: arithmeticBinaryType, Line: 2, Column: 1, Original: "+", Mutated: "-"}
+
+	if _, err := MutateSource("calc.go", []byte("package calc\n"), mutant, getAllMutators()); err == nil {
+		t.Error("expected an error for a missing mutation target")
+	}
+}
+
+func TestMutateSource_ForClauseStatementRemoved(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{
+			name:   "post statement of a loop without init",
+			header: "for ; i < n; i++ {",
+			want:   "for i < n {",
+		},
+		{
+			name:   "post statement of a loop with init",
+			header: "for i = 0; i < n; i++ {",
+			want:   "for i = 0; i < n; {",
+		},
+		{
+			name:   "init statement of a loop without post",
+			header: "for i++; i < n; {",
+			want:   "for i < n {",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Formatting a file with grouped imports reparses the printed
+			// source, which drops the separators of the emptied clause.
+			src := "package calc\n\nimport (\n\t\"fmt\"\n)\n\nfunc Count(n int) (i int) {\n\t" + tt.header + "\n\t\tfmt.Println(n)\n\t}\n\n\treturn i\n}\n"
+
+			fset := token.NewFileSet()
+
+			file, err := parser.ParseFile(fset, "calc.go", src, 0)
+			if err != nil {
+				t.Fatalf("failed to parse source: %v", err)
+			}
+
+			var loop *ast.ForStmt
+
+			ast.Inspect(file, func(n ast.Node) bool {
+				if f, ok := n.(*ast.ForStmt); ok {
+					loop = f
+				}
+
+				return true
+			})
+
+			stmt := loop.Post
+			if stmt == nil {
+				stmt = loop.Init
+			}
+
+			mutator := &StatementRemovalMutator{}
+
+			mutants := mutator.Mutate(stmt, fset)
+			if len(mutants) != 1 {
+				t.Fatalf("Mutate() returned %d mutants, want 1", len(mutants))
+			}
+
+			SetLocator(&mutants[0], fset, stmt, mutator)
+
+			got, err := MutateSource("calc.go", []byte(src), mutants[0], []Mutator{mutator})
+			if err != nil {
+				t.Fatalf("MutateSource() error = %v", err)
+			}
+
+			if !strings.Contains(string(got), tt.want) {
+				t.Errorf("expected mutated source to contain %q, got:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckMutatedSpan(t *testing.T) {
+	t.Parallel()
+
+	const original = `package calc
+
+func Add(a, b int) int {
+	return a + b
+}
+
+func Sub(a, b int) int {
+	if a < b {
+		return 0
+	}
+
+	return a - b
+}
+`
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "calc.go", original, 0)
+	if err != nil {
+		t.Fatalf("failed to parse source: %v", err)
+	}
+
+	// The located node is the body of Sub.
+	var body *ast.BlockStmt
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if fn, ok := n.(*ast.FuncDecl); ok && fn.Name.Name == "Sub" {
+			body = fn.Body
+		}
+
+		return true
+	})
+
+	mutant := Mutant{}
+	SetLocator(&mutant, fset, body, &StatementRemovalMutator{})
+
+	index := locatedIndex(fset, file, mutant)
+
+	tests := []struct {
+		name    string
+		mutated string
+		wantErr error
+	}{
+		{
+			name:    "change inside the node",
+			mutated: strings.Replace(original, "return a - b", "return a + b\n", 1),
+		},
+		{
+			name:    "statement removed with its separator",
+			mutated: strings.Replace(original, "\tif a < b {\n\t\treturn 0\n\t}\n\n", "", 1),
+		},
+		{
+			name:    "whitespace realigned outside the node",
+			mutated: strings.Replace(strings.Replace(original, "return a - b", "return b", 1), "a + b", "a  +  b", 1),
+		},
+		{
+			name:    "change outside the node",
+			mutated: strings.Replace(original, "return a + b", "return a * b", 1),
+			wantErr: errOutsideSpan,
+		},
+		{
+			name:    "no change",
+			mutated: original,
+			wantErr: errUnchanged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkMutatedSpan([]byte(original), []byte(tt.mutated), index)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkMutatedSpan() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -16,7 +16,8 @@ const (
 // effect of the assignment is properly covered by tests.
 //
 // Short variable declarations (`:=`) are not targeted because removing them
-// leaves later references undefined, which never compiles.
+// leaves later references undefined, which never compiles. Field assignments
+// (`x.f = v`) are removed by the pointer_semantics mutator instead.
 type AssignmentRemovalMutator struct {
 }
 
@@ -29,13 +30,13 @@ func (m *AssignmentRemovalMutator) Name() string {
 func (m *AssignmentRemovalMutator) CanMutate(node ast.Node) bool {
 	stmt, ok := node.(*ast.AssignStmt)
 
-	return ok && stmt.Tok != token.DEFINE
+	return ok && stmt.Tok != token.DEFINE && !isFieldAssignment(stmt)
 }
 
 // Mutate generates mutants for the given node.
 func (m *AssignmentRemovalMutator) Mutate(node ast.Node, fset *token.FileSet) []Mutant {
 	stmt, ok := node.(*ast.AssignStmt)
-	if !ok || stmt.Tok == token.DEFINE {
+	if !ok || stmt.Tok == token.DEFINE || isFieldAssignment(stmt) {
 		return nil
 	}
 
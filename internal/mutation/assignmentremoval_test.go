@@ -59,6 +59,8 @@ func TestAssignmentRemovalMutator_CanMutate(t *testing.T) {
 		{name: "compound assign", code: "a += b", expected: true},
 		{name: "multi assign", code: "a, b = b, a", expected: true},
 		{name: "short var decl", code: "a := b", expected: false},
+		{name: "field assign", code: "a.b = c", expected: false},
+		{name: "compound field assign", code: "a.b += c", expected: true},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,511 @@
+package mutation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"strings"
+)
+
+const (
+	callSwapMutatorName     = "call_swap"
+	callSwapType            = "call_swap"
+	callResultType          = "call_result"
+	callLessInversionType   = "call_less_inversion"
+	callSwapSelectorDivider = "."
+)
+
+// CallSwap describes how calls to one function or method are mutated. Exactly
+// one of With, Result and InvertLess must be set.
+type CallSwap struct {
+	// Func identifies the callee: "strings.HasPrefix" for package-level
+	// functions (import path, then name), "time.Time.Before" for methods
+	// (import path, receiver type name, method name) and "min" for builtins.
+	Func string `json:"func"`
+	// With is the name of a sibling function or method with the same
+	// signature that replaces the callee, e.g. "HasSuffix".
+	With string `json:"with,omitempty"`
+	// Result is a Go expression replacing the whole call, e.g. "true".
+	Result string `json:"result,omitempty"`
+	// InvertLess swaps the first two parameters of the function literal
+	// passed as the last argument, inverting a less or compare function.
+	InvertLess bool `json:"invertLess,omitempty"`
+}
+
+// defaultCallSwaps is the built-in call swap table.
+var defaultCallSwaps = concatCallSwaps(
+	swapPair("strings.HasPrefix", "HasSuffix"),
+	swapPair("strings.Index", "LastIndex"),
+	swapPair("strings.IndexAny", "LastIndexAny"),
+	swapPair("strings.IndexByte", "LastIndexByte"),
+	swapPair("strings.TrimLeft", "TrimRight"),
+	swapPair("strings.TrimPrefix", "TrimSuffix"),
+	swapPair("strings.ToLower", "ToUpper"),
+	swapPair("bytes.HasPrefix", "HasSuffix"),
+	swapPair("bytes.Index", "LastIndex"),
+	swapPair("bytes.IndexAny", "LastIndexAny"),
+	swapPair("bytes.IndexByte", "LastIndexByte"),
+	swapPair("bytes.TrimLeft", "TrimRight"),
+	swapPair("bytes.TrimPrefix", "TrimSuffix"),
+	swapPair("bytes.ToLower", "ToUpper"),
+	[]CallSwap{
+		{Func: "bytes.Equal", Result: "true"},
+		{Func: "strings.EqualFold", Result: "true"},
+	},
+	swapPair("math.Floor", "Ceil"),
+	swapPair("math.Max", "Min"),
+	swapPair("min", "max"),
+	[]CallSwap{
+		{Func: "sort.Slice", InvertLess: true},
+		{Func: "sort.SliceStable", InvertLess: true},
+		{Func: "slices.SortFunc", InvertLess: true},
+		{Func: "slices.SortStableFunc", InvertLess: true},
+	},
+	swapPair("time.Time.Before", "After"),
+)
+
+// DefaultCallSwaps returns a copy of the built-in call swap table.
+func DefaultCallSwaps() []CallSwap {
+	return append([]CallSwap(nil), defaultCallSwaps...)
+}
+
+// LoadCallSwaps reads additional call swaps from a JSON file containing an
+// array of CallSwap objects.
+func LoadCallSwaps(path string) ([]CallSwap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read call swaps file: %w", err)
+	}
+
+	var swaps []CallSwap
+	if err := json.Unmarshal(data, &swaps); err != nil {
+		return nil, fmt.Errorf("failed to parse call swaps file %s: %w", path, err)
+	}
+
+	for _, swap := range swaps {
+		if err := swap.validate(); err != nil {
+			return nil, fmt.Errorf("invalid call swap in %s: %w", path, err)
+		}
+	}
+
+	return swaps, nil
+}
+
+// validate checks that the swap names a callee and exactly one mutation.
+func (s CallSwap) validate() error {
+	if s.Func == "" {
+		return errors.New("func is required")
+	}
+
+	set := 0
+
+	for _, ok := range []bool{s.With != "", s.Result != "", s.InvertLess} {
+		if ok {
+			set++
+		}
+	}
+
+	if set != 1 {
+		return fmt.Errorf("%s: exactly one of with, result and invertLess must be set", s.Func)
+	}
+
+	if s.Result != "" {
+		if _, err := parser.ParseExpr(s.Result); err != nil {
+			return fmt.Errorf("%s: invalid result expression %q: %w", s.Func, s.Result, err)
+		}
+	}
+
+	return nil
+}
+
+// CallSwapMutator replaces calls with semantically close siblings according to
+// a data-driven table (see DefaultCallSwaps):
+//   - the callee is replaced with a sibling, e.g. strings.HasPrefix with
+//     strings.HasSuffix (call_swap)
+//   - the call is replaced with a fixed result, e.g. bytes.Equal(a, b) with
+//     true (call_result)
+//   - the less function passed to sort.Slice is inverted
+//     (call_less_inversion)
+//
+// Callees are resolved through type information, so renamed imports and
+// shadowing identifiers are handled; without it, package functions are
+// matched by package name.
+type CallSwapMutator struct {
+	info *types.Info
+	// swaps indexes the swap table by callee; nil means the default table.
+	swaps map[string][]CallSwap
+}
+
+// setCallSwaps replaces the swap table with the defaults plus extra.
+func (m *CallSwapMutator) setCallSwaps(extra []CallSwap) {
+	m.swaps = make(map[string][]CallSwap)
+
+	for _, swap := range append(DefaultCallSwaps(), extra...) {
+		m.swaps[swap.Func] = append(m.swaps[swap.Func], swap)
+	}
+}
+
+// Name returns the name of the mutator.
+func (m *CallSwapMutator) Name() string {
+	return callSwapMutatorName
+}
+
+// Prepare records the type information of the file about to be mutated.
+func (m *CallSwapMutator) Prepare(_ *ast.File, info *types.Info) {
+	m.info = info
+}
+
+// CanMutate returns true if the node is a call listed in the swap table.
+func (m *CallSwapMutator) CanMutate(node ast.Node) bool {
+	call, ok := node.(*ast.CallExpr)
+
+	return ok && len(m.lookup(call)) > 0
+}
+
+// Mutate generates mutants for the given node.
+func (m *CallSwapMutator) Mutate(node ast.Node, fset *token.FileSet) []Mutant {
+	call, ok := node.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+
+	var mutants []Mutant
+
+	for _, swap := range m.lookup(call) {
+		var mutant *Mutant
+
+		switch {
+		case swap.With != "":
+			mutant = swapCallee(call, swap.With, fset)
+		case swap.Result != "":
+			mutant = replaceCallResult(call, swap.Result, fset)
+		case swap.InvertLess:
+			mutant = invertLess(call, fset)
+		}
+
+		if mutant != nil {
+			mutants = append(mutants, *mutant)
+		}
+	}
+
+	return mutants
+}
+
+// swapCallee creates a mutant calling with instead of the callee. It is
+// positioned at the callee's name.
+func swapCallee(call *ast.CallExpr, with string, fset *token.FileSet) *Mutant {
+	ident := calleeIdent(call)
+	if ident == nil || ident.Name == with {
+		return nil
+	}
+
+	pos := fset.Position(ident.Pos())
+	original := exprToString(call.Fun)
+	mutated := strings.TrimSuffix(original, ident.Name) + with
+
+	return &Mutant{
+		Line:        pos.Line,
+		Column:      pos.Column,
+		Type:        callSwapType,
+		Original:    original,
+		Mutated:     mutated,
+		Description: fmt.Sprintf("Replace %s with %s", original, mutated),
+	}
+}
+
+// replaceCallResult creates a mutant replacing the whole call with result.
+func replaceCallResult(call *ast.CallExpr, result string, fset *token.FileSet) *Mutant {
+	pos := fset.Position(call.Pos())
+	original := exprToString(call)
+
+	return &Mutant{
+		Line:        pos.Line,
+		Column:      pos.Column,
+		Type:        callResultType,
+		Original:    original,
+		Mutated:     result,
+		Description: fmt.Sprintf("Replace %s with %s", original, result),
+	}
+}
+
+// invertLess creates a mutant swapping the first two parameters of the
+// function literal passed as the last argument of call.
+func invertLess(call *ast.CallExpr, fset *token.FileSet) *Mutant {
+	if len(call.Args) == 0 {
+		return nil
+	}
+
+	lit, ok := call.Args[len(call.Args)-1].(*ast.FuncLit)
+	if !ok {
+		return nil
+	}
+
+	original := exprToString(lit.Type)
+
+	inverted, ok := swappedParams(lit.Type)
+	if !ok {
+		return nil
+	}
+
+	pos := fset.Position(lit.Pos())
+
+	return &Mutant{
+		Line:        pos.Line,
+		Column:      pos.Column,
+		Type:        callLessInversionType,
+		Original:    original,
+		Mutated:     exprToString(inverted),
+		Description: fmt.Sprintf("Invert the comparison function of %s", exprToString(call.Fun)),
+	}
+}
+
+// Apply applies the mutation to the given AST node.
+func (m *CallSwapMutator) Apply(node ast.Node, mutant Mutant) bool {
+	switch mutant.Type {
+	case callSwapType:
+		ident, ok := node.(*ast.Ident)
+		if !ok || ident.Name != lastSelectorName(mutant.Original) {
+			return false
+		}
+
+		ident.Name = lastSelectorName(mutant.Mutated)
+
+		return true
+	case callLessInversionType:
+		lit, ok := node.(*ast.FuncLit)
+		if !ok || exprToString(lit.Type) != mutant.Original {
+			return false
+		}
+
+		inverted, ok := swappedParams(lit.Type)
+		if !ok {
+			return false
+		}
+
+		lit.Type = inverted
+
+		return true
+	default:
+		return false
+	}
+}
+
+// ApplyWithCursor replaces a call with its configured result.
+func (m *CallSwapMutator) ApplyWithCursor(node ast.Node, replaceFunc func(ast.Node), mutant Mutant) bool {
+	if mutant.Type != callResultType {
+		return false
+	}
+
+	call, ok := node.(*ast.CallExpr)
+	if !ok || exprToString(call) != mutant.Original {
+		return false
+	}
+
+	result, err := parser.ParseExpr(mutant.Mutated)
+	if err != nil {
+		return false
+	}
+
+	clearPositions(result)
+
+	// The call may be an operand of a larger expression.
+	if _, ok := result.(*ast.BinaryExpr); ok {
+		result = &ast.ParenExpr{X: result}
+	}
+
+	replaceFunc(result)
+
+	return true
+}
+
+// clearPositions resets the positions of a parsed expression, which refer to
+// the parsed snippet rather than the file being mutated.
+func clearPositions(expr ast.Expr) {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch e := n.(type) {
+		case *ast.Ident:
+			e.NamePos = token.NoPos
+		case *ast.BasicLit:
+			e.ValuePos = token.NoPos
+		case *ast.BinaryExpr:
+			e.OpPos = token.NoPos
+		case *ast.UnaryExpr:
+			e.OpPos = token.NoPos
+		case *ast.StarExpr:
+			e.Star = token.NoPos
+		case *ast.ParenExpr:
+			e.Lparen, e.Rparen = token.NoPos, token.NoPos
+		case *ast.CallExpr:
+			e.Lparen, e.Rparen = token.NoPos, token.NoPos
+		case *ast.IndexExpr:
+			e.Lbrack, e.Rbrack = token.NoPos, token.NoPos
+		case *ast.CompositeLit:
+			e.Lbrace, e.Rbrace = token.NoPos, token.NoPos
+		}
+
+		return true
+	})
+}
+
+// lookup returns the swaps configured for the callee of call.
+func (m *CallSwapMutator) lookup(call *ast.CallExpr) []CallSwap {
+	if m.swaps == nil {
+		m.setCallSwaps(nil)
+	}
+
+	name := m.calleeName(call)
+	if name == "" {
+		return nil
+	}
+
+	return m.swaps[name]
+}
+
+// calleeName returns the qualified name of the function or method called by
+// call, in the format of CallSwap.Func.
+func (m *CallSwapMutator) calleeName(call *ast.CallExpr) string {
+	ident := calleeIdent(call)
+	if ident == nil {
+		return ""
+	}
+
+	if m.info == nil {
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok {
+				return pkg.Name + callSwapSelectorDivider + sel.Sel.Name
+			}
+
+			return ""
+		}
+
+		return ident.Name
+	}
+
+	switch obj := m.info.Uses[ident].(type) {
+	case *types.Builtin:
+		return obj.Name()
+	case *types.Func:
+		return qualifiedFuncName(obj)
+	default:
+		return ""
+	}
+}
+
+// calleeIdent returns the identifier naming the callee of call.
+func calleeIdent(call *ast.CallExpr) *ast.Ident {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return fun
+	case *ast.SelectorExpr:
+		return fun.Sel
+	default:
+		return nil
+	}
+}
+
+// qualifiedFuncName returns "path.Name" for functions and
+// "path.Type.Name" for methods.
+func qualifiedFuncName(fn *types.Func) string {
+	if fn.Pkg() == nil {
+		return fn.Name()
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return fn.Pkg().Path() + callSwapSelectorDivider + fn.Name()
+	}
+
+	recv := sig.Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+
+	named, ok := recv.(*types.Named)
+	if !ok {
+		return ""
+	}
+
+	return fn.Pkg().Path() + callSwapSelectorDivider + named.Obj().Name() + callSwapSelectorDivider + fn.Name()
+}
+
+// swappedParams returns a copy of typ with its first two parameter names
+// swapped, provided both have the same type.
+func swappedParams(typ *ast.FuncType) (*ast.FuncType, bool) {
+	if typ.Params == nil {
+		return nil, false
+	}
+
+	var (
+		names      []*ast.Ident
+		paramTypes []string
+	)
+
+	for _, field := range typ.Params.List {
+		for _, name := range field.Names {
+			names = append(names, name)
+			paramTypes = append(paramTypes, exprToString(field.Type))
+		}
+	}
+
+	if len(names) < 2 || paramTypes[0] != paramTypes[1] || names[0].Name == names[1].Name {
+		return nil, false
+	}
+
+	params := &ast.FieldList{Opening: typ.Params.Opening, Closing: typ.Params.Closing}
+
+	for _, field := range typ.Params.List {
+		copied := *field
+		copied.Names = make([]*ast.Ident, len(field.Names))
+
+		for i, name := range field.Names {
+			swapped := *name
+
+			switch name {
+			case names[0]:
+				swapped.Name = names[1].Name
+			case names[1]:
+				swapped.Name = names[0].Name
+			}
+
+			copied.Names[i] = &swapped
+		}
+
+		params.List = append(params.List, &copied)
+	}
+
+	inverted := *typ
+	inverted.Params = params
+
+	return &inverted, true
+}
+
+// lastSelectorName returns the name after the last dot of a selector.
+func lastSelectorName(selector string) string {
+	return selector[strings.LastIndex(selector, callSwapSelectorDivider)+1:]
+}
+
+// swapPair returns swaps replacing fn with sibling and the sibling with fn.
+// fn is qualified; sibling is the bare name of a function next to it.
+func swapPair(fn, sibling string) []CallSwap {
+	prefix := strings.TrimSuffix(fn, lastSelectorName(fn))
+
+	return []CallSwap{
+		{Func: fn, With: sibling},
+		{Func: prefix + sibling, With: lastSelectorName(fn)},
+	}
+}
+
+// concatCallSwaps concatenates swap tables.
+func concatCallSwaps(tables ...[]CallSwap) []CallSwap {
+	var swaps []CallSwap
+	for _, table := range tables {
+		swaps = append(swaps, table...)
+	}
+
+	return swaps
+}
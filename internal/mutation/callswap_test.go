@@ -0,0 +1,243 @@
+package mutation
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCallSwapMutator_Name(t *testing.T) {
+	t.Parallel()
+
+	mutator := &CallSwapMutator{}
+
+	if mutator.Name() != callSwapMutatorName {
+		t.Errorf("Name() = %q, want %q", mutator.Name(), callSwapMutatorName)
+	}
+}
+
+func TestCallSwapMutator_TypeAware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		src   string
+		extra []CallSwap
+		want  []string
+	}{
+		{
+			name: "strings functions through a renamed import",
+			src: `package main
+import str "strings"
+func f(s string) (bool, int) { return str.HasPrefix(s, "a"), str.Index(s, "b") }`,
+			want: []string{
+				"call_swap: str.HasPrefix -> str.HasSuffix",
+				"call_swap: str.Index -> str.LastIndex",
+			},
+		},
+		{
+			name: "method with a listed name on another type is skipped",
+			src: `package main
+type prefixer struct{}
+func (prefixer) HasPrefix(s, p string) bool { return false }
+func f(strings prefixer) bool { return strings.HasPrefix("a", "b") }`,
+			want: nil,
+		},
+		{
+			name: "builtins and math",
+			src: `package main
+import "math"
+func f(a, b int, x float64) (int, float64) { return min(a, b), math.Floor(x) }`,
+			want: []string{
+				"call_swap: min -> max",
+				"call_swap: math.Floor -> math.Ceil",
+			},
+		},
+		{
+			name: "call replaced with result",
+			src: `package main
+import "bytes"
+func f(a, b []byte) bool { return bytes.Equal(a, b) }`,
+			want: []string{"call_result: bytes.Equal(a, b) -> true"},
+		},
+		{
+			name: "sort less function inverted",
+			src: `package main
+import "sort"
+func f(s []int) { sort.Slice(s, func(i, j int) bool { return s[i] < s[j] }) }`,
+			want: []string{"call_less_inversion: func(i, j int) bool -> func(j, i int) bool"},
+		},
+		{
+			name: "time method",
+			src: `package main
+import "time"
+func f(a, b time.Time) bool { return a.Before(b) }`,
+			want: []string{"call_swap: a.Before -> a.After"},
+		},
+		{
+			name: "configured swap",
+			src: `package main
+import "strings"
+func f(s string) bool { return strings.Contains(s, "x") }`,
+			extra: []CallSwap{{Func: "strings.Contains", Result: "false"}},
+			want:  []string{`call_result: strings.Contains(s, "x") -> false`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mutator := &CallSwapMutator{}
+			mutator.setCallSwaps(tt.extra)
+
+			got := mutateTypeChecked(t, mutator, tt.src)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mutants mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCallSwapMutator_ApplyMutant(t *testing.T) {
+	t.Parallel()
+
+	const src = `package main
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+func Normalize(names []string, a, b []byte) bool {
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return strings.HasPrefix(names[0], "x") && bytes.Equal(a, b)
+}
+`
+
+	tests := []struct {
+		name   string
+		mutant Mutant
+		want   string
+	}{
+		{
+			name: "callee swapped",
+			mutant: Mutant{
+				Type: callSwapType, Line: 11, Column: 17,
+				Original: "strings.HasPrefix", Mutated: "strings.HasSuffix",
+			},
+			want: `strings.HasSuffix(names[0], "x")`,
+		},
+		{
+			name: "call replaced with result",
+			mutant: Mutant{
+				Type: callResultType, Line: 11, Column: 45,
+				Original: "bytes.Equal(a, b)", Mutated: "true",
+			},
+			want: `"x") && true`,
+		},
+		{
+			name: "less function inverted",
+			mutant: Mutant{
+				Type: callLessInversionType, Line: 10, Column: 20,
+				Original: "func(i, j int) bool", Mutated: "func(j, i int) bool",
+			},
+			want: "func(j, i int) bool { return names[i] < names[j] }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+
+			file, err := parser.ParseFile(fset, "normalize.go", src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("failed to parse source: %v", err)
+			}
+
+			if !ApplyMutant(fset, file, tt.mutant, []Mutator{&CallSwapMutator{}}) {
+				t.Fatal("ApplyMutant() = false, want true")
+			}
+
+			var buf bytes.Buffer
+			if err := format.Node(&buf, fset, file); err != nil {
+				t.Fatalf("failed to format file: %v", err)
+			}
+
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("expected output to contain %q, got:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestLoadCallSwaps(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    []CallSwap
+		wantErr bool
+	}{
+		{
+			name:    "valid swaps",
+			content: `[{"func": "strings.Contains", "result": "false"}, {"func": "example.com/x.Open", "with": "Create"}]`,
+			want: []CallSwap{
+				{Func: "strings.Contains", Result: "false"},
+				{Func: "example.com/x.Open", With: "Create"},
+			},
+		},
+		{
+			name:    "missing func",
+			content: `[{"with": "Create"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "more than one mutation",
+			content: `[{"func": "strings.Contains", "with": "HasPrefix", "result": "true"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid result expression",
+			content: `[{"func": "strings.Contains", "result": "true &&"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed json",
+			content: `{`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "swaps.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("failed to write swaps file: %v", err)
+			}
+
+			got, err := LoadCallSwaps(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadCallSwaps() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("LoadCallSwaps() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,446 @@
+package mutation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+const (
+	collectionMutatorName   = "collection"
+	sliceBoundType          = "slice_bound"
+	indexOffsetType         = "index_offset"
+	lenDecrementType        = "len_decrement"
+	appendRemovalType       = "append_removal"
+	elementRemovalType      = "composite_element_removal"
+	mapLookupOkFalseType    = "map_lookup_ok_false"
+	collectionRemoved       = "<removed>"
+	falseIdentName          = "false"
+	collectionOffsetLiteral = "1"
+)
+
+// CollectionMutator mutates slicing, indexing and collection literals:
+//   - slice bounds are shifted inwards: s[i:j] becomes s[i+1:j] and
+//     s[i:j-1] (slice_bound)
+//   - indexes of slices, arrays and strings are shifted by one (index_offset)
+//   - len(x) becomes len(x)-1 (len_decrement)
+//   - append(s, v) becomes s (append_removal)
+//   - elements of slice, array and map literals are removed
+//     (composite_element_removal)
+//   - the ok of `v, ok := m[k]` is forced to false (map_lookup_ok_false)
+//
+// Index offsets need type information to tell indexing from map lookups and
+// generic instantiations; the other mutations fall back to syntax.
+type CollectionMutator struct {
+	info *types.Info
+	// operands holds len calls that are operands of unary or binary
+	// expressions and need parentheses once decremented.
+	operands map[*ast.CallExpr]bool
+}
+
+// Name returns the name of the mutator.
+func (m *CollectionMutator) Name() string {
+	return collectionMutatorName
+}
+
+// Prepare records the type information of the file about to be mutated.
+func (m *CollectionMutator) Prepare(file *ast.File, info *types.Info) {
+	m.info = info
+	m.operands = make(map[*ast.CallExpr]bool)
+
+	if file == nil {
+		return
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.BinaryExpr:
+			m.recordOperand(n.X)
+			m.recordOperand(n.Y)
+		case *ast.UnaryExpr:
+			m.recordOperand(n.X)
+		}
+
+		return true
+	})
+}
+
+// recordOperand records expr if it is a call used as an operand.
+func (m *CollectionMutator) recordOperand(expr ast.Expr) {
+	if call, ok := expr.(*ast.CallExpr); ok {
+		m.operands[call] = true
+	}
+}
+
+// CanMutate returns true if the node can be mutated by this mutator.
+func (m *CollectionMutator) CanMutate(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.SliceExpr:
+		return len(sliceBoundVariants(n)) > 0
+	case *ast.IndexExpr:
+		return m.isIndexable(n.X)
+	case *ast.CallExpr:
+		return m.isBuiltinCall(n, "len") || m.isAppendWithValues(n)
+	case *ast.CompositeLit:
+		return len(n.Elts) > 0 && m.isCollectionLit(n)
+	case *ast.AssignStmt:
+		return isMapLookupOk(n)
+	default:
+		return false
+	}
+}
+
+// Mutate generates mutants for the given node.
+func (m *CollectionMutator) Mutate(node ast.Node, fset *token.FileSet) []Mutant {
+	switch n := node.(type) {
+	case *ast.SliceExpr:
+		return exprMutants(n, sliceBoundType, sliceBoundVariants(n), fset)
+	case *ast.IndexExpr:
+		if !m.isIndexable(n.X) {
+			return nil
+		}
+
+		return exprMutants(n, indexOffsetType, indexOffsetVariants(n), fset)
+	case *ast.CallExpr:
+		return m.mutateCall(n, fset)
+	case *ast.CompositeLit:
+		if !m.isCollectionLit(n) {
+			return nil
+		}
+
+		return mutateElements(n, fset)
+	case *ast.AssignStmt:
+		if !isMapLookupOk(n) {
+			return nil
+		}
+
+		pos := fset.Position(n.Pos())
+
+		return []Mutant{{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        mapLookupOkFalseType,
+			Original:    stmtToString(n),
+			Mutated:     stmtToString(mapLookupOkFalse(n)),
+			Description: "Force the ok result of a map lookup to false",
+		}}
+	default:
+		return nil
+	}
+}
+
+// mutateCall generates len decrement and append removal mutants.
+func (m *CollectionMutator) mutateCall(call *ast.CallExpr, fset *token.FileSet) []Mutant {
+	pos := fset.Position(call.Pos())
+	original := exprToString(call)
+
+	var mutated, mutationType string
+
+	switch {
+	case m.isBuiltinCall(call, "len"):
+		mutationType = lenDecrementType
+		mutated = exprToString(lenDecrement(call, m.operands[call]))
+	case m.isAppendWithValues(call):
+		mutationType = appendRemovalType
+		mutated = exprToString(call.Args[0])
+	default:
+		return nil
+	}
+
+	return []Mutant{{
+		Line:        pos.Line,
+		Column:      pos.Column,
+		Type:        mutationType,
+		Original:    original,
+		Mutated:     mutated,
+		Description: fmt.Sprintf("Replace %s with %s", original, mutated),
+	}}
+}
+
+// mutateElements generates a mutant removing each element of lit. Mutants
+// are positioned at the removed element.
+func mutateElements(lit *ast.CompositeLit, fset *token.FileSet) []Mutant {
+	mutants := make([]Mutant, 0, len(lit.Elts))
+
+	for _, elt := range lit.Elts {
+		pos := fset.Position(elt.Pos())
+		original := exprToString(elt)
+
+		mutants = append(mutants, Mutant{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        elementRemovalType,
+			Original:    original,
+			Mutated:     collectionRemoved,
+			Description: fmt.Sprintf("Remove element %s from composite literal", original),
+		})
+	}
+
+	return mutants
+}
+
+// exprMutants generates one mutant per variant of expr.
+func exprMutants(expr ast.Expr, mutationType string, variants []ast.Expr, fset *token.FileSet) []Mutant {
+	pos := fset.Position(expr.Pos())
+	original := exprToString(expr)
+	mutants := make([]Mutant, 0, len(variants))
+
+	for _, variant := range variants {
+		mutated := exprToString(variant)
+
+		mutants = append(mutants, Mutant{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        mutationType,
+			Original:    original,
+			Mutated:     mutated,
+			Description: fmt.Sprintf("Replace %s with %s", original, mutated),
+		})
+	}
+
+	return mutants
+}
+
+// Apply applies the mutation to the given AST node.
+func (m *CollectionMutator) Apply(node ast.Node, mutant Mutant) bool {
+	switch mutant.Type {
+	case sliceBoundType:
+		expr, ok := node.(*ast.SliceExpr)
+		if !ok || exprToString(expr) != mutant.Original {
+			return false
+		}
+
+		variant, ok := findVariant(sliceBoundVariants(expr), mutant.Mutated).(*ast.SliceExpr)
+		if !ok {
+			return false
+		}
+
+		*expr = *variant
+
+		return true
+	case indexOffsetType:
+		expr, ok := node.(*ast.IndexExpr)
+		if !ok || exprToString(expr) != mutant.Original {
+			return false
+		}
+
+		variant, ok := findVariant(indexOffsetVariants(expr), mutant.Mutated).(*ast.IndexExpr)
+		if !ok {
+			return false
+		}
+
+		*expr = *variant
+
+		return true
+	case mapLookupOkFalseType:
+		stmt, ok := node.(*ast.AssignStmt)
+		if !ok || !isMapLookupOk(stmt) || stmtToString(stmt) != mutant.Original {
+			return false
+		}
+
+		*stmt = *mapLookupOkFalse(stmt)
+
+		return true
+	default:
+		return false
+	}
+}
+
+// ApplyWithCursor replaces len and append calls and removes literal elements.
+func (m *CollectionMutator) ApplyWithCursor(node ast.Node, replaceFunc func(ast.Node), mutant Mutant) bool {
+	switch mutant.Type {
+	case lenDecrementType, appendRemovalType:
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 || exprToString(call) != mutant.Original {
+			return false
+		}
+
+		if mutant.Type == appendRemovalType {
+			replaceFunc(call.Args[0])
+
+			return true
+		}
+
+		replaceFunc(lenDecrement(call, strings.HasPrefix(mutant.Mutated, "(")))
+
+		return true
+	case elementRemovalType:
+		expr, ok := node.(ast.Expr)
+		if !ok || exprToString(expr) != mutant.Original {
+			return false
+		}
+
+		replaceFunc(nil)
+
+		return true
+	default:
+		return false
+	}
+}
+
+// isIndexable reports whether expr is a slice, array, pointer to array or
+// string, so that indexing it takes an integer index. This requires type
+// information.
+func (m *CollectionMutator) isIndexable(expr ast.Expr) bool {
+	if m.info == nil {
+		return false
+	}
+
+	tv, ok := m.info.Types[expr]
+	if !ok || tv.Type == nil || !tv.IsValue() {
+		return false
+	}
+
+	t := tv.Type.Underlying()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem().Underlying()
+	}
+
+	switch t := t.(type) {
+	case *types.Slice, *types.Array:
+		return true
+	case *types.Basic:
+		return t.Info()&types.IsString != 0
+	default:
+		return false
+	}
+}
+
+// isCollectionLit reports whether lit is a slice, array or map literal.
+func (m *CollectionMutator) isCollectionLit(lit *ast.CompositeLit) bool {
+	if m.info != nil {
+		if tv, ok := m.info.Types[lit]; ok && tv.Type != nil {
+			switch tv.Type.Underlying().(type) {
+			case *types.Slice, *types.Array, *types.Map:
+				return true
+			default:
+				return false
+			}
+		}
+	}
+
+	switch lit.Type.(type) {
+	case *ast.ArrayType, *ast.MapType:
+		return true
+	default:
+		return false
+	}
+}
+
+// isBuiltinCall reports whether call calls the predeclared function name.
+func (m *CollectionMutator) isBuiltinCall(call *ast.CallExpr, name string) bool {
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok || ident.Name != name || len(call.Args) == 0 {
+		return false
+	}
+
+	if m.info == nil {
+		return true
+	}
+
+	_, ok = m.info.Uses[ident].(*types.Builtin)
+
+	return ok
+}
+
+// isAppendWithValues reports whether call appends at least one value.
+func (m *CollectionMutator) isAppendWithValues(call *ast.CallExpr) bool {
+	return m.isBuiltinCall(call, "append") && len(call.Args) > 1
+}
+
+// isMapLookupOk reports whether stmt is a comma-ok map lookup such as
+// `v, ok := m[k]`. Comma-ok index expressions are only valid on maps.
+func isMapLookupOk(stmt *ast.AssignStmt) bool {
+	if len(stmt.Lhs) != 2 || len(stmt.Rhs) != 1 {
+		return false
+	}
+
+	if ident, ok := stmt.Lhs[1].(*ast.Ident); ok && ident.Name == "_" {
+		return false
+	}
+
+	_, ok := stmt.Rhs[0].(*ast.IndexExpr)
+
+	return ok
+}
+
+// mapLookupOkFalse returns stmt with the lookup turned into a single-value
+// index expression and false assigned to ok: `v, ok := m[k], false`.
+func mapLookupOkFalse(stmt *ast.AssignStmt) *ast.AssignStmt {
+	mutated := *stmt
+	mutated.Rhs = []ast.Expr{stmt.Rhs[0], ast.NewIdent(falseIdentName)}
+
+	return &mutated
+}
+
+// sliceBoundVariants returns expr with its low bound incremented and with
+// its high bound decremented. Full slice expressions are left alone.
+func sliceBoundVariants(expr *ast.SliceExpr) []ast.Expr {
+	if expr.Slice3 {
+		return nil
+	}
+
+	var variants []ast.Expr
+
+	if expr.Low != nil {
+		variant := *expr
+		variant.Low = offsetExpr(expr.Low, token.ADD)
+		variants = append(variants, &variant)
+	}
+
+	if expr.High != nil {
+		variant := *expr
+		variant.High = offsetExpr(expr.High, token.SUB)
+		variants = append(variants, &variant)
+	}
+
+	return variants
+}
+
+// indexOffsetVariants returns expr with its index incremented and
+// decremented.
+func indexOffsetVariants(expr *ast.IndexExpr) []ast.Expr {
+	variants := make([]ast.Expr, 0, 2)
+
+	for _, op := range []token.Token{token.ADD, token.SUB} {
+		variant := *expr
+		variant.Index = offsetExpr(expr.Index, op)
+		variants = append(variants, &variant)
+	}
+
+	return variants
+}
+
+// findVariant returns the variant rendered as mutated.
+func findVariant(variants []ast.Expr, mutated string) ast.Expr {
+	for _, variant := range variants {
+		if exprToString(variant) == mutated {
+			return variant
+		}
+	}
+
+	return nil
+}
+
+// offsetExpr returns `expr op 1`. Integer operators all bind at least as
+// tightly as + and -, so no parentheses are needed.
+func offsetExpr(expr ast.Expr, op token.Token) ast.Expr {
+	return &ast.BinaryExpr{
+		X:  expr,
+		Op: op,
+		Y:  &ast.BasicLit{Kind: token.INT, Value: collectionOffsetLiteral},
+	}
+}
+
+// lenDecrement returns `len(x) - 1`, parenthesized when it replaces an
+// operand.
+func lenDecrement(call *ast.CallExpr, parenthesize bool) ast.Expr {
+	expr := offsetExpr(call, token.SUB)
+	if parenthesize {
+		return &ast.ParenExpr{X: expr}
+	}
+
+	return expr
+}
@@ -0,0 +1,214 @@
+package mutation
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCollectionMutator_Name(t *testing.T) {
+	t.Parallel()
+
+	mutator := &CollectionMutator{}
+
+	if mutator.Name() != collectionMutatorName {
+		t.Errorf("Name() = %q, want %q", mutator.Name(), collectionMutatorName)
+	}
+}
+
+func TestCollectionMutator_TypeAware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "slice bounds",
+			src: `package main
+func f(s []int, i, j int) ([]int, []int, []int) { return s[i:j], s[:j], s[i:] }`,
+			want: []string{
+				"slice_bound: s[i:j] -> s[i+1 : j]",
+				"slice_bound: s[i:j] -> s[i : j-1]",
+				"slice_bound: s[:j] -> s[:j-1]",
+				"slice_bound: s[i:] -> s[i+1:]",
+			},
+		},
+		{
+			name: "full slice expressions are skipped",
+			src: `package main
+func f(s []int) []int { return s[:] }`,
+			want: nil,
+		},
+		{
+			name: "index of slice and string",
+			src: `package main
+func f(s []int, str string, i int) (int, byte) { return s[i], str[i] }`,
+			want: []string{
+				"index_offset: s[i] -> s[i+1]",
+				"index_offset: s[i] -> s[i-1]",
+				"index_offset: str[i] -> str[i+1]",
+				"index_offset: str[i] -> str[i-1]",
+			},
+		},
+		{
+			name: "map index and generic instantiation are skipped",
+			src: `package main
+func id[T any](v T) T { return v }
+func f(m map[int]int) int { g := id[int]; return g(m[1]) }`,
+			want: nil,
+		},
+		{
+			name: "len as operand is parenthesized",
+			src: `package main
+func f(s []int) (int, int) { return len(s), 2 * len(s) }`,
+			want: []string{
+				"len_decrement: len(s) -> len(s) - 1",
+				"len_decrement: len(s) -> (len(s) - 1)",
+			},
+		},
+		{
+			name: "append",
+			src: `package main
+func f(s []int, v int) []int { s = append(s); return append(s, v) }`,
+			want: []string{"append_removal: append(s, v) -> s"},
+		},
+		{
+			name: "shadowed len is skipped",
+			src: `package main
+func len(s []int) int { return 0 }
+func f(s []int) int { return len(s) }`,
+			want: nil,
+		},
+		{
+			name: "slice and map literal elements",
+			src: `package main
+type point struct{ x, y int }
+func f() ([]int, map[string]int, point) {
+	return []int{1, 2}, map[string]int{"a": 1}, point{1, 2}
+}`,
+			want: []string{
+				"composite_element_removal: 1 -> <removed>",
+				"composite_element_removal: 2 -> <removed>",
+				`composite_element_removal: "a": 1 -> <removed>`,
+			},
+		},
+		{
+			name: "map lookup ok",
+			src: `package main
+func f(m map[string]int) bool {
+	_, ok := m["k"]
+	v, _ := m["k"]
+	return ok && v > 0
+}`,
+			want: []string{`map_lookup_ok_false: _, ok := m["k"] -> _, ok := m["k"], false`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := mutateTypeChecked(t, &CollectionMutator{}, tt.src)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mutants mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCollectionMutator_ApplyMutant(t *testing.T) {
+	t.Parallel()
+
+	const src = `package main
+
+func Parse(s string, words []string, index map[string]int) (string, []string, bool) {
+	head := s[1:len(s)]
+	words = append(words, head)
+	_, ok := index[head]
+	return head, []string{"a", "b"}, ok
+}
+`
+
+	tests := []struct {
+		name   string
+		mutant Mutant
+		want   []string
+	}{
+		{
+			name: "slice low bound incremented",
+			mutant: Mutant{
+				Type: sliceBoundType, Line: 4, Column: 10,
+				Original: "s[1:len(s)]", Mutated: "s[1+1 : len(s)]",
+			},
+			want: []string{"s[1+1 : len(s)]"},
+		},
+		{
+			name: "len decremented",
+			mutant: Mutant{
+				Type: lenDecrementType, Line: 4, Column: 14,
+				Original: "len(s)", Mutated: "len(s) - 1",
+			},
+			want: []string{"s[1 : len(s)-1]"},
+		},
+		{
+			name: "append removed",
+			mutant: Mutant{
+				Type: appendRemovalType, Line: 5, Column: 10,
+				Original: "append(words, head)", Mutated: "words",
+			},
+			want: []string{"words = words\n"},
+		},
+		{
+			name: "map lookup ok forced to false",
+			mutant: Mutant{
+				Type: mapLookupOkFalseType, Line: 6, Column: 2,
+				Original: "_, ok := index[head]", Mutated: "_, ok := index[head], false",
+			},
+			want: []string{"_, ok := index[head], false"},
+		},
+		{
+			name: "literal element removed",
+			mutant: Mutant{
+				Type: elementRemovalType, Line: 7, Column: 24,
+				Original: `"a"`, Mutated: collectionRemoved,
+			},
+			want: []string{`[]string{"b"}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+
+			file, err := parser.ParseFile(fset, "parse.go", src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("failed to parse source: %v", err)
+			}
+
+			if !ApplyMutant(fset, file, tt.mutant, []Mutator{&CollectionMutator{}}) {
+				t.Fatal("ApplyMutant() = false, want true")
+			}
+
+			var buf bytes.Buffer
+			if err := format.Node(&buf, fset, file); err != nil {
+				t.Fatalf("failed to format file: %v", err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,523 @@
+package mutation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+const (
+	concurrencyMutatorName   = "concurrency"
+	lockPairRemovalType      = "lock_pair_removal"
+	rlockToLockType          = "rlock_to_lock"
+	waitGroupAddRemovalType  = "waitgroup_add_removal"
+	waitGroupDoneRemovalType = "waitgroup_done_removal"
+	closeRemovalType         = "close_removal"
+	chanBufferSizeType       = "chan_buffer_size"
+	selectCaseRemovalType    = "select_case_removal"
+	selectDefaultInsertType  = "select_default_insert"
+	selectDefaultRemovalType = "select_default_removal"
+
+	syncPackagePath    = "sync"
+	concurrencyRemoved = "<removed>"
+)
+
+// ConcurrencyMutator mutates synchronization and channel code:
+//   - a Lock/RLock call and its matching (possibly deferred) Unlock/RUnlock
+//     in the same block are removed together (lock_pair_removal)
+//   - RLock/RUnlock pairs are turned into Lock/Unlock (rlock_to_lock)
+//   - WaitGroup Add and Done calls are removed (waitgroup_add_removal,
+//     waitgroup_done_removal)
+//   - close(ch) is removed (close_removal)
+//   - buffered channels become unbuffered and unbuffered channels get a
+//     buffer of one (chan_buffer_size)
+//   - select cases are dropped, and a default case is inserted or removed
+//     (select_case_removal, select_default_insert, select_default_removal)
+//
+// Surviving mutants are most telling when tests run with -race.
+//
+// With type information, only methods of the sync package and the builtin
+// close and make are considered; without it, calls are matched by name.
+type ConcurrencyMutator struct {
+	info *types.Info
+}
+
+// Name returns the name of the mutator.
+func (m *ConcurrencyMutator) Name() string {
+	return concurrencyMutatorName
+}
+
+// Prepare records the type information of the file about to be mutated.
+func (m *ConcurrencyMutator) Prepare(_ *ast.File, info *types.Info) {
+	m.info = info
+}
+
+// CanMutate returns true if the node is concurrency code this mutator handles.
+func (m *ConcurrencyMutator) CanMutate(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.BlockStmt:
+		for i := range n.List {
+			if _, ok := m.findUnlock(n.List, i); ok {
+				return true
+			}
+		}
+
+		return false
+	case *ast.ExprStmt:
+		return m.removalType(n) != ""
+	case *ast.DeferStmt:
+		return m.removalType(n) != ""
+	case *ast.CallExpr:
+		return m.isChanMake(n)
+	case *ast.SelectStmt:
+		return true
+	default:
+		return false
+	}
+}
+
+// Mutate generates mutants for the given node.
+func (m *ConcurrencyMutator) Mutate(node ast.Node, fset *token.FileSet) []Mutant {
+	switch n := node.(type) {
+	case *ast.BlockStmt:
+		return m.mutateLocks(n, fset)
+	case *ast.ExprStmt:
+		return m.mutateRemoval(n, fset)
+	case *ast.DeferStmt:
+		return m.mutateRemoval(n, fset)
+	case *ast.CallExpr:
+		if !m.isChanMake(n) {
+			return nil
+		}
+
+		pos := fset.Position(n.Pos())
+		original := exprToString(n)
+		mutated := exprToString(&ast.CallExpr{Fun: n.Fun, Args: chanBufferArgs(n.Args)})
+
+		return []Mutant{{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        chanBufferSizeType,
+			Original:    original,
+			Mutated:     mutated,
+			Description: fmt.Sprintf("Replace %s with %s", original, mutated),
+		}}
+	case *ast.SelectStmt:
+		return mutateSelect(n, fset)
+	default:
+		return nil
+	}
+}
+
+// mutateRemoval generates a mutant removing a WaitGroup or close statement.
+func (m *ConcurrencyMutator) mutateRemoval(stmt ast.Stmt, fset *token.FileSet) []Mutant {
+	mutationType := m.removalType(stmt)
+	if mutationType == "" {
+		return nil
+	}
+
+	pos := fset.Position(stmt.Pos())
+	original := stmtToString(stmt)
+
+	return []Mutant{{
+		Line:        pos.Line,
+		Column:      pos.Column,
+		Type:        mutationType,
+		Original:    original,
+		Mutated:     concurrencyRemoved,
+		Description: fmt.Sprintf("Remove %s", original),
+	}}
+}
+
+// mutateLocks generates lock pair mutants for every Lock/Unlock pair whose
+// calls are statements of block.
+func (m *ConcurrencyMutator) mutateLocks(block *ast.BlockStmt, fset *token.FileSet) []Mutant {
+	var mutants []Mutant
+
+	for i, stmt := range block.List {
+		j, ok := m.findUnlock(block.List, i)
+		if !ok {
+			continue
+		}
+
+		pos := fset.Position(stmt.Pos())
+		original := lockPairString(stmt, block.List[j])
+
+		mutants = append(mutants, Mutant{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        lockPairRemovalType,
+			Original:    original,
+			Mutated:     concurrencyRemoved,
+			Description: fmt.Sprintf("Remove lock pair %s", original),
+		})
+
+		if _, method, _ := lockCall(stmt); method == "RLock" {
+			mutants = append(mutants, Mutant{
+				Line:        pos.Line,
+				Column:      pos.Column,
+				Type:        rlockToLockType,
+				Original:    original,
+				Mutated:     rlockToLockString(original),
+				Description: "Replace RLock/RUnlock with Lock/Unlock",
+			})
+		}
+	}
+
+	return mutants
+}
+
+// mutateSelect generates mutants that drop cases of stmt or insert or remove
+// its default case.
+func mutateSelect(stmt *ast.SelectStmt, fset *token.FileSet) []Mutant {
+	var (
+		mutants    []Mutant
+		hasDefault bool
+	)
+
+	for _, s := range stmt.Body.List {
+		clause, ok := s.(*ast.CommClause)
+		if !ok {
+			continue
+		}
+
+		pos := fset.Position(clause.Pos())
+		original := commClauseString(clause)
+
+		switch {
+		case clause.Comm == nil:
+			hasDefault = true
+
+			mutants = append(mutants, Mutant{
+				Line:        pos.Line,
+				Column:      pos.Column,
+				Type:        selectDefaultRemovalType,
+				Original:    original,
+				Mutated:     concurrencyRemoved,
+				Description: "Remove default case from select",
+			})
+		case len(stmt.Body.List) > 1:
+			// Dropping the only case would leave a select that blocks forever.
+			mutants = append(mutants, Mutant{
+				Line:        pos.Line,
+				Column:      pos.Column,
+				Type:        selectCaseRemovalType,
+				Original:    original,
+				Mutated:     concurrencyRemoved,
+				Description: fmt.Sprintf("Remove select %s", original),
+			})
+		}
+	}
+
+	if !hasDefault && len(stmt.Body.List) > 0 {
+		pos := fset.Position(stmt.Pos())
+
+		mutants = append(mutants, Mutant{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        selectDefaultInsertType,
+			Original:    "select {...}",
+			Mutated:     "select {...; default:}",
+			Description: "Insert empty default case into select",
+		})
+	}
+
+	return mutants
+}
+
+// Apply applies the mutation to the given AST node.
+func (m *ConcurrencyMutator) Apply(node ast.Node, mutant Mutant) bool {
+	switch mutant.Type {
+	case chanBufferSizeType:
+		call, ok := node.(*ast.CallExpr)
+		if !ok || exprToString(call) != mutant.Original {
+			return false
+		}
+
+		call.Args = chanBufferArgs(call.Args)
+
+		return true
+	case selectDefaultInsertType:
+		stmt, ok := node.(*ast.SelectStmt)
+		if !ok || hasDefaultClause(stmt) {
+			return false
+		}
+
+		stmt.Body.List = append(stmt.Body.List, &ast.CommClause{})
+
+		return true
+	default:
+		return false
+	}
+}
+
+// ApplyWithCursor removes WaitGroup and close statements.
+func (m *ConcurrencyMutator) ApplyWithCursor(node ast.Node, replaceFunc func(ast.Node), mutant Mutant) bool {
+	switch mutant.Type {
+	case waitGroupAddRemovalType, waitGroupDoneRemovalType, closeRemovalType:
+		stmt, ok := node.(ast.Stmt)
+		if !ok || stmtToString(stmt) != mutant.Original {
+			return false
+		}
+
+		replaceFunc(&ast.EmptyStmt{})
+
+		return true
+	default:
+		return false
+	}
+}
+
+// ApplyInBlock applies lock pair and select case mutations, which rewrite
+// more than the statement at the mutant's position.
+func (m *ConcurrencyMutator) ApplyInBlock(block *ast.BlockStmt, index int, mutant Mutant) bool {
+	switch mutant.Type {
+	case lockPairRemovalType, rlockToLockType:
+		// The block comes from a fresh parse, so match syntactically.
+		j, ok := findUnlock(block.List, index, func(*ast.SelectorExpr) bool { return true })
+		if !ok || lockPairString(block.List[index], block.List[j]) != mutant.Original {
+			return false
+		}
+
+		if mutant.Type == rlockToLockType {
+			renameLockCall(block.List[index], "Lock")
+			renameLockCall(block.List[j], "Unlock")
+
+			return true
+		}
+
+		block.List[index] = &ast.EmptyStmt{}
+		block.List[j] = &ast.EmptyStmt{}
+
+		return true
+	case selectCaseRemovalType, selectDefaultRemovalType:
+		clause, ok := block.List[index].(*ast.CommClause)
+		if !ok || commClauseString(clause) != mutant.Original {
+			return false
+		}
+
+		block.List = append(block.List[:index], block.List[index+1:]...)
+
+		return true
+	default:
+		return false
+	}
+}
+
+// findUnlock returns the index of the statement releasing the lock acquired
+// by list[i], if list[i] is a Lock or RLock call of the sync package.
+func (m *ConcurrencyMutator) findUnlock(list []ast.Stmt, i int) (int, bool) {
+	return findUnlock(list, i, m.isSyncMethod)
+}
+
+// removalType returns the mutation type removing stmt, or "" if stmt is not a
+// WaitGroup Add/Done or close call.
+func (m *ConcurrencyMutator) removalType(stmt ast.Stmt) string {
+	call := stmtCall(stmt)
+	if call == nil {
+		return ""
+	}
+
+	if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == "close" && len(call.Args) == 1 {
+		if m.isBuiltin(ident) {
+			return closeRemovalType
+		}
+
+		return ""
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !m.isSyncMethod(sel) || !m.isWaitGroup(sel.X) {
+		return ""
+	}
+
+	switch sel.Sel.Name {
+	case "Add":
+		// A deferred Add is unusual enough that removing it is not modelled.
+		if _, ok := stmt.(*ast.ExprStmt); ok {
+			return waitGroupAddRemovalType
+		}
+	case "Done":
+		return waitGroupDoneRemovalType
+	}
+
+	return ""
+}
+
+// isChanMake reports whether call is make(chan T) or make(chan T, n).
+func (m *ConcurrencyMutator) isChanMake(call *ast.CallExpr) bool {
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok || ident.Name != "make" || len(call.Args) == 0 || len(call.Args) > 2 || !m.isBuiltin(ident) {
+		return false
+	}
+
+	_, ok = call.Args[0].(*ast.ChanType)
+
+	return ok
+}
+
+// isBuiltin reports whether ident refers to a predeclared function rather
+// than a local declaration shadowing it.
+func (m *ConcurrencyMutator) isBuiltin(ident *ast.Ident) bool {
+	if m.info == nil {
+		return true
+	}
+
+	_, ok := m.info.Uses[ident].(*types.Builtin)
+
+	return ok
+}
+
+// isSyncMethod reports whether sel selects a method of the sync package,
+// including methods promoted from an embedded sync.Mutex.
+func (m *ConcurrencyMutator) isSyncMethod(sel *ast.SelectorExpr) bool {
+	if m.info == nil {
+		return true
+	}
+
+	fn, ok := m.info.Uses[sel.Sel].(*types.Func)
+
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == syncPackagePath
+}
+
+// isWaitGroup reports whether expr is a sync.WaitGroup or a pointer to one.
+// Without type information any receiver is accepted.
+func (m *ConcurrencyMutator) isWaitGroup(expr ast.Expr) bool {
+	if m.info == nil {
+		return true
+	}
+
+	tv, ok := m.info.Types[expr]
+	if !ok || tv.Type == nil {
+		return false
+	}
+
+	t := tv.Type
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == syncPackagePath && obj.Name() == "WaitGroup"
+}
+
+// findUnlock returns the index of the first statement after list[i] that
+// releases the lock list[i] acquires: `x.Unlock()` or `defer x.Unlock()` for
+// `x.Lock()`, and the RUnlock equivalents for `x.RLock()`.
+func findUnlock(list []ast.Stmt, i int, isSync func(*ast.SelectorExpr) bool) (int, bool) {
+	if _, ok := list[i].(*ast.ExprStmt); !ok {
+		return 0, false
+	}
+
+	recv, method, sel := lockCall(list[i])
+	if sel == nil || !isSync(sel) {
+		return 0, false
+	}
+
+	var unlock string
+
+	switch method {
+	case "Lock":
+		unlock = "Unlock"
+	case "RLock":
+		unlock = "RUnlock"
+	default:
+		return 0, false
+	}
+
+	for j := i + 1; j < len(list); j++ {
+		r, method, sel := lockCall(list[j])
+		if sel != nil && r == recv && method == unlock && isSync(sel) {
+			return j, true
+		}
+	}
+
+	return 0, false
+}
+
+// lockCall returns the receiver, method name and selector of a statement of
+// the form `x.M()` or `defer x.M()`.
+func lockCall(stmt ast.Stmt) (string, string, *ast.SelectorExpr) {
+	call := stmtCall(stmt)
+	if call == nil || len(call.Args) != 0 {
+		return "", "", nil
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", nil
+	}
+
+	return exprToString(sel.X), sel.Sel.Name, sel
+}
+
+// renameLockCall changes the method called by a lock statement.
+func renameLockCall(stmt ast.Stmt, name string) {
+	if _, _, sel := lockCall(stmt); sel != nil {
+		sel.Sel = ast.NewIdent(name)
+	}
+}
+
+// stmtCall returns the call of an expression or defer statement.
+func stmtCall(stmt ast.Stmt) *ast.CallExpr {
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
+		call, _ := s.X.(*ast.CallExpr)
+
+		return call
+	case *ast.DeferStmt:
+		return s.Call
+	default:
+		return nil
+	}
+}
+
+// chanBufferArgs returns the make arguments with the buffer size changed:
+// buffered channels become unbuffered, unbuffered ones get a buffer of one.
+func chanBufferArgs(args []ast.Expr) []ast.Expr {
+	if len(args) == 2 {
+		if lit, ok := args[1].(*ast.BasicLit); !ok || lit.Value != "0" {
+			return args[:1]
+		}
+	}
+
+	return []ast.Expr{args[0], &ast.BasicLit{Kind: token.INT, Value: "1"}}
+}
+
+// hasDefaultClause reports whether stmt has a default case.
+func hasDefaultClause(stmt *ast.SelectStmt) bool {
+	for _, s := range stmt.Body.List {
+		if clause, ok := s.(*ast.CommClause); ok && clause.Comm == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lockPairString renders a lock pair for reports.
+func lockPairString(lock, unlock ast.Stmt) string {
+	return stmtToString(lock) + " ... " + stmtToString(unlock)
+}
+
+// rlockToLockString renders a read lock pair as the equivalent write lock pair.
+func rlockToLockString(pair string) string {
+	return strings.NewReplacer(".RLock()", ".Lock()", ".RUnlock()", ".Unlock()").Replace(pair)
+}
+
+// commClauseString renders the header of a select case for reports.
+func commClauseString(clause *ast.CommClause) string {
+	if clause.Comm == nil {
+		return "default:"
+	}
+
+	return "case " + stmtToString(clause.Comm) + ":"
+}
@@ -0,0 +1,329 @@
+package mutation
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConcurrencyMutator_Name(t *testing.T) {
+	t.Parallel()
+
+	mutator := &ConcurrencyMutator{}
+
+	if mutator.Name() != concurrencyMutatorName {
+		t.Errorf("Name() = %q, want %q", mutator.Name(), concurrencyMutatorName)
+	}
+}
+
+func TestConcurrencyMutator_TypeAware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "mutex with deferred unlock",
+			src: `package main
+import "sync"
+type C struct { mu sync.Mutex; n int }
+func (c *C) Inc() { c.mu.Lock(); defer c.mu.Unlock(); c.n++ }`,
+			want: []string{"lock_pair_removal: c.mu.Lock() ... defer c.mu.Unlock() -> <removed>"},
+		},
+		{
+			name: "read lock",
+			src: `package main
+import "sync"
+type C struct { mu sync.RWMutex; n int }
+func (c *C) Get() int { c.mu.RLock(); n := c.n; c.mu.RUnlock(); return n }`,
+			want: []string{
+				"lock_pair_removal: c.mu.RLock() ... c.mu.RUnlock() -> <removed>",
+				"rlock_to_lock: c.mu.RLock() ... c.mu.RUnlock() -> c.mu.Lock() ... c.mu.Unlock()",
+			},
+		},
+		{
+			name: "embedded mutex",
+			src: `package main
+import "sync"
+type C struct { sync.Mutex; n int }
+func (c *C) Inc() { c.Lock(); c.n++; c.Unlock() }`,
+			want: []string{"lock_pair_removal: c.Lock() ... c.Unlock() -> <removed>"},
+		},
+		{
+			name: "lock method outside sync is skipped",
+			src: `package main
+type L struct{}
+func (L) Lock() {}
+func (L) Unlock() {}
+func f(l L) { l.Lock(); defer l.Unlock() }`,
+			want: nil,
+		},
+		{
+			name: "unlock of another mutex is not a pair",
+			src: `package main
+import "sync"
+func f(a, b *sync.Mutex) { a.Lock(); b.Unlock() }`,
+			want: nil,
+		},
+		{
+			name: "wait group add and done",
+			src: `package main
+import "sync"
+func f(work func()) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() { defer wg.Done(); work() }()
+	wg.Wait()
+}`,
+			want: []string{
+				"waitgroup_add_removal: wg.Add(1) -> <removed>",
+				"waitgroup_done_removal: defer wg.Done() -> <removed>",
+			},
+		},
+		{
+			name: "add on a non wait group is skipped",
+			src: `package main
+import "sync/atomic"
+func f(n *atomic.Int64) { n.Add(1) }`,
+			want: nil,
+		},
+		{
+			name: "close builtin",
+			src: `package main
+func f(ch chan int) { defer close(ch); ch <- 1 }`,
+			want: []string{"close_removal: defer close(ch) -> <removed>"},
+		},
+		{
+			name: "shadowed close is skipped",
+			src: `package main
+func close(int) {}
+func f() { close(1) }`,
+			want: nil,
+		},
+		{
+			name: "channel buffer sizes",
+			src: `package main
+func f(n int) (chan int, chan int, chan int, []int) {
+	return make(chan int), make(chan int, n), make(chan int, 0), make([]int, n)
+}`,
+			want: []string{
+				"chan_buffer_size: make(chan int) -> make(chan int, 1)",
+				"chan_buffer_size: make(chan int, n) -> make(chan int)",
+				"chan_buffer_size: make(chan int, 0) -> make(chan int, 1)",
+			},
+		},
+		{
+			name: "select without default",
+			src: `package main
+func f(a, b chan int) int {
+	select {
+	case v := <-a:
+		return v
+	case b <- 1:
+		return 0
+	}
+}`,
+			want: []string{
+				"select_case_removal: case v := <-a: -> <removed>",
+				"select_case_removal: case b <- 1: -> <removed>",
+				"select_default_insert: select {...} -> select {...; default:}",
+			},
+		},
+		{
+			name: "select with single case and default",
+			src: `package main
+func f(a chan int) int {
+	select {
+	case v := <-a:
+		return v
+	default:
+		return 0
+	}
+}`,
+			want: []string{
+				"select_case_removal: case v := <-a: -> <removed>",
+				"select_default_removal: default: -> <removed>",
+			},
+		},
+		{
+			name: "select with single case keeps it",
+			src: `package main
+func f(a chan int) { select { case <-a: } }`,
+			want: []string{"select_default_insert: select {...} -> select {...; default:}"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := mutateTypeChecked(t, &ConcurrencyMutator{}, tt.src)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mutants mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConcurrencyMutator_ApplyMutant(t *testing.T) {
+	t.Parallel()
+
+	const src = `package main
+
+import "sync"
+
+type Cache struct {
+	mu   sync.RWMutex
+	data map[string]int
+}
+
+func (c *Cache) Get(key string) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.data[key]
+}
+
+func Wait(ch chan int, done chan struct{}) int {
+	select {
+	case v := <-ch:
+		return v
+	case <-done:
+		return 0
+	}
+}
+
+func Produce() chan int {
+	ch := make(chan int, 4)
+	close(ch)
+	return ch
+}
+`
+
+	tests := []struct {
+		name    string
+		mutant  Mutant
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "lock pair removed",
+			mutant: Mutant{
+				Type: lockPairRemovalType, Line: 11, Column: 2,
+				Original: "c.mu.RLock() ... defer c.mu.RUnlock()",
+			},
+			notWant: []string{"RLock", "RUnlock"},
+		},
+		{
+			name: "read lock replaced with write lock",
+			mutant: Mutant{
+				Type: rlockToLockType, Line: 11, Column: 2,
+				Original: "c.mu.RLock() ... defer c.mu.RUnlock()",
+			},
+			want:    []string{"c.mu.Lock()", "defer c.mu.Unlock()"},
+			notWant: []string{"RLock", "RUnlock"},
+		},
+		{
+			name: "select case removed",
+			mutant: Mutant{
+				Type: selectCaseRemovalType, Line: 20, Column: 2,
+				Original: "case <-done:",
+			},
+			want:    []string{"case v := <-ch:"},
+			notWant: []string{"<-done"},
+		},
+		{
+			name: "default case inserted",
+			mutant: Mutant{
+				Type: selectDefaultInsertType, Line: 17, Column: 2,
+				Original: "select {...}",
+			},
+			want: []string{"default:"},
+		},
+		{
+			name: "buffer size changed",
+			mutant: Mutant{
+				Type: chanBufferSizeType, Line: 26, Column: 8,
+				Original: "make(chan int, 4)",
+			},
+			want: []string{"ch := make(chan int)\n"},
+		},
+		{
+			name: "close removed",
+			mutant: Mutant{
+				Type: closeRemovalType, Line: 27, Column: 2,
+				Original: "close(ch)",
+			},
+			notWant: []string{"close(ch)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+
+			file, err := parser.ParseFile(fset, "cache.go", src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("failed to parse source: %v", err)
+			}
+
+			if !ApplyMutant(fset, file, tt.mutant, []Mutator{&ConcurrencyMutator{}}) {
+				t.Fatal("ApplyMutant() = false, want true")
+			}
+
+			var buf bytes.Buffer
+			if err := format.Node(&buf, fset, file); err != nil {
+				t.Fatalf("failed to format file: %v", err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, buf.String())
+				}
+			}
+
+			for _, notWant := range tt.notWant {
+				if strings.Contains(buf.String(), notWant) {
+					t.Errorf("expected output not to contain %q, got:\n%s", notWant, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestConcurrencyMutator_ApplyInBlock_Mismatch(t *testing.T) {
+	t.Parallel()
+
+	const src = `package main
+
+func f(ch chan int) {
+	select {
+	case <-ch:
+	default:
+	}
+}
+`
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "f.go", src, 0)
+	if err != nil {
+		t.Fatalf("failed to parse source: %v", err)
+	}
+
+	// The clause at the position differs from the recorded original.
+	mutant := Mutant{Type: selectCaseRemovalType, Line: 5, Column: 2, Original: "case <-other:"}
+
+	if ApplyMutant(fset, file, mutant, []Mutator{&ConcurrencyMutator{}}) {
+		t.Error("ApplyMutant() = true, want false for a mismatched original")
+	}
+}
@@ -0,0 +1,402 @@
+package mutation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+const (
+	constantMutatorName = "constant"
+	constantValueType   = "constant_value"
+	durationUnitType    = "duration_unit"
+
+	timePackagePath   = "time"
+	constantZero      = "0"
+	constantFloatZero = "0.0"
+)
+
+// durationUnits maps each time.Duration unit constant to the unit it is
+// replaced with.
+var durationUnits = map[string]string{
+	"Hour":        "Minute",
+	"Minute":      "Second",
+	"Second":      "Millisecond",
+	"Millisecond": "Microsecond",
+	"Microsecond": "Nanosecond",
+	"Nanosecond":  "Microsecond",
+}
+
+// ConstantMutator mutates numeric constants wherever they appear in
+// expressions:
+//   - integer and float literals and named numeric constants are negated
+//     and replaced with zero; floats and named constants are also
+//     incremented (constant_value)
+//   - time.Duration units are replaced with the next smaller unit, e.g.
+//     time.Second becomes time.Millisecond (duration_unit)
+//
+// Incrementing and decrementing integer literals is left to the boundary
+// value mutator, and replacing returned literals with zero to the return
+// mutator. Array lengths, constants of iota blocks and shift counts are
+// never mutated, since their mutants rarely compile. Named constants and
+// unsigned operands are recognised with type information.
+type ConstantMutator struct {
+	info *types.Info
+	// skipped holds the nodes of array lengths, iota blocks, shift counts
+	// and the selector names of qualified identifiers.
+	skipped map[ast.Node]bool
+	// operands holds the expressions that are operands of unary or binary
+	// expressions and need parentheses once incremented.
+	operands map[ast.Expr]bool
+	// negated holds the operands of unary minus.
+	negated map[ast.Expr]bool
+	// returned holds the results of return statements.
+	returned map[ast.Expr]bool
+}
+
+// Name returns the name of the mutator.
+func (m *ConstantMutator) Name() string {
+	return constantMutatorName
+}
+
+// Prepare records the type information of the file about to be mutated and
+// the positions in which constants are not mutated.
+func (m *ConstantMutator) Prepare(file *ast.File, info *types.Info) {
+	m.info = info
+	m.skipped = make(map[ast.Node]bool)
+	m.operands = make(map[ast.Expr]bool)
+	m.negated = make(map[ast.Expr]bool)
+	m.returned = make(map[ast.Expr]bool)
+
+	if file == nil {
+		return
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.ArrayType:
+			m.skip(n.Len)
+		case *ast.GenDecl:
+			if n.Tok == token.CONST && usesIota(n) {
+				m.skip(n)
+			}
+		case *ast.BinaryExpr:
+			if n.Op == token.SHL || n.Op == token.SHR {
+				m.skip(n.Y)
+			}
+
+			m.operands[n.X] = true
+			m.operands[n.Y] = true
+		case *ast.AssignStmt:
+			if n.Tok == token.SHL_ASSIGN || n.Tok == token.SHR_ASSIGN {
+				for _, rhs := range n.Rhs {
+					m.skip(rhs)
+				}
+			}
+		case *ast.UnaryExpr:
+			m.operands[n.X] = true
+			m.negated[n.X] = n.Op == token.SUB
+		case *ast.SelectorExpr:
+			m.skipped[n.Sel] = true
+		case *ast.ReturnStmt:
+			for _, result := range n.Results {
+				m.returned[result] = true
+			}
+		}
+
+		return true
+	})
+}
+
+// skip records every node of the subtree rooted at node as not mutated.
+func (m *ConstantMutator) skip(node ast.Node) {
+	if node == nil {
+		return
+	}
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		if n != nil {
+			m.skipped[n] = true
+		}
+
+		return true
+	})
+}
+
+// CanMutate returns true if the node can be mutated by this mutator.
+func (m *ConstantMutator) CanMutate(node ast.Node) bool {
+	expr, ok := node.(ast.Expr)
+	if !ok || m.skipped[node] {
+		return false
+	}
+
+	return m.isDurationUnit(expr) || len(m.constantVariants(expr)) > 0
+}
+
+// Mutate generates mutants for the given node.
+func (m *ConstantMutator) Mutate(node ast.Node, fset *token.FileSet) []Mutant {
+	expr, ok := node.(ast.Expr)
+	if !ok || m.skipped[node] {
+		return nil
+	}
+
+	if m.isDurationUnit(expr) {
+		sel, ok := expr.(*ast.SelectorExpr)
+		if !ok {
+			return nil
+		}
+
+		pos := fset.Position(sel.Pos())
+		original := exprToString(sel)
+		mutated := exprToString(sel.X) + "." + durationUnits[sel.Sel.Name]
+
+		return []Mutant{{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        durationUnitType,
+			Original:    original,
+			Mutated:     mutated,
+			Description: fmt.Sprintf("Replace %s with %s", original, mutated),
+		}}
+	}
+
+	return exprMutants(expr, constantValueType, m.constantVariants(expr), fset)
+}
+
+// Apply replaces a duration unit.
+func (m *ConstantMutator) Apply(node ast.Node, mutant Mutant) bool {
+	if mutant.Type != durationUnitType {
+		return false
+	}
+
+	sel, ok := node.(*ast.SelectorExpr)
+	if !ok || exprToString(sel) != mutant.Original {
+		return false
+	}
+
+	unit, ok := durationUnits[sel.Sel.Name]
+	if !ok || !strings.HasSuffix(mutant.Mutated, "."+unit) {
+		return false
+	}
+
+	sel.Sel = ast.NewIdent(unit)
+
+	return true
+}
+
+// ApplyWithCursor replaces a constant with one of its variants. The
+// variants are rebuilt without type information.
+func (m *ConstantMutator) ApplyWithCursor(node ast.Node, replaceFunc func(ast.Node), mutant Mutant) bool {
+	if mutant.Type != constantValueType {
+		return false
+	}
+
+	expr, ok := node.(ast.Expr)
+	if !ok || exprToString(expr) != mutant.Original {
+		return false
+	}
+
+	variants := []ast.Expr{
+		&ast.UnaryExpr{Op: token.SUB, X: expr},
+		zeroConstant(false),
+		zeroConstant(true),
+	}
+
+	if increment := incrementConstant(expr, strings.HasPrefix(mutant.Mutated, "(")); increment != nil {
+		variants = append(variants, increment)
+	}
+
+	variant := findVariant(variants, mutant.Mutated)
+	if variant == nil {
+		return false
+	}
+
+	replaceFunc(variant)
+
+	return true
+}
+
+// constantVariants returns the replacements of a numeric literal or named
+// constant, or nil if expr is neither.
+func (m *ConstantMutator) constantVariants(expr ast.Expr) []ast.Expr {
+	isFloat, ok := m.constantKind(expr)
+	if !ok {
+		return nil
+	}
+
+	var variants []ast.Expr
+
+	if increment := incrementConstant(expr, m.operands[expr]); increment != nil {
+		variants = append(variants, increment)
+	}
+
+	if !m.negated[expr] && !m.isUnsigned(expr) {
+		variants = append(variants, &ast.UnaryExpr{Op: token.SUB, X: expr})
+	}
+
+	// Returned literals are replaced with zero by the return mutator.
+	if _, isLit := expr.(*ast.BasicLit); !isLit || !m.returned[expr] {
+		variants = append(variants, zeroConstant(isFloat))
+	}
+
+	return variants
+}
+
+// constantKind reports whether expr is a non-zero numeric literal or a
+// named numeric constant, and whether it is a float.
+func (m *ConstantMutator) constantKind(expr ast.Expr) (isFloat, ok bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.INT:
+			value, ok := parseIntLit(e.Value)
+
+			return false, ok && value != 0
+		case token.FLOAT:
+			value, err := strconv.ParseFloat(e.Value, 64)
+
+			return true, err == nil && value != 0
+		default:
+			return false, false
+		}
+	case *ast.Ident, *ast.SelectorExpr:
+		return m.numericConst(e)
+	default:
+		return false, false
+	}
+}
+
+// incrementConstant returns expr incremented by one, parenthesized when it
+// replaces an operand. Integer literals are incremented by the boundary
+// value mutator, so nil is returned for them.
+func incrementConstant(expr ast.Expr, parenthesize bool) ast.Expr {
+	if lit, ok := expr.(*ast.BasicLit); ok {
+		if lit.Kind != token.FLOAT {
+			return nil
+		}
+
+		value, err := strconv.ParseFloat(lit.Value, 64)
+		if err != nil {
+			return nil
+		}
+
+		return &ast.BasicLit{Kind: token.FLOAT, Value: formatFloatLit(value + 1)}
+	}
+
+	increment := offsetExpr(expr, token.ADD)
+	if parenthesize {
+		return &ast.ParenExpr{X: increment}
+	}
+
+	return increment
+}
+
+// zeroConstant returns the zero literal, as a float literal for floats so
+// that the type of inferred variables does not change.
+func zeroConstant(isFloat bool) ast.Expr {
+	if isFloat {
+		return &ast.BasicLit{Kind: token.FLOAT, Value: constantFloatZero}
+	}
+
+	return &ast.BasicLit{Kind: token.INT, Value: constantZero}
+}
+
+// numericConst reports whether expr names an integer or float constant of
+// a predeclared type, and whether it is a float. Constants of defined types,
+// such as enums and durations, are not mutated.
+func (m *ConstantMutator) numericConst(expr ast.Expr) (isFloat, ok bool) {
+	if m.info == nil {
+		return false, false
+	}
+
+	var ident *ast.Ident
+
+	switch e := expr.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return false, false
+	}
+
+	c, ok := m.info.Uses[ident].(*types.Const)
+	if !ok || c.Pkg() == nil {
+		return false, false
+	}
+
+	basic, ok := c.Type().(*types.Basic)
+	if !ok || basic.Info()&(types.IsInteger|types.IsFloat) == 0 {
+		return false, false
+	}
+
+	return basic.Info()&types.IsFloat != 0, true
+}
+
+// isUnsigned reports whether expr has an unsigned integer type, which a
+// negated constant would overflow.
+func (m *ConstantMutator) isUnsigned(expr ast.Expr) bool {
+	if m.info == nil {
+		return false
+	}
+
+	tv, ok := m.info.Types[expr]
+	if !ok || tv.Type == nil {
+		return false
+	}
+
+	basic, ok := tv.Type.Underlying().(*types.Basic)
+
+	return ok && basic.Info()&types.IsUnsigned != 0
+}
+
+// isDurationUnit reports whether expr is one of the time.Duration unit
+// constants.
+func (m *ConstantMutator) isDurationUnit(expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	if _, ok := durationUnits[sel.Sel.Name]; !ok {
+		return false
+	}
+
+	if m.info != nil {
+		if c, ok := m.info.Uses[sel.Sel].(*types.Const); ok {
+			return c.Pkg() != nil && c.Pkg().Path() == timePackagePath
+		}
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+
+	return ok && ident.Name == timePackagePath
+}
+
+// usesIota reports whether a const declaration refers to iota.
+func usesIota(decl *ast.GenDecl) bool {
+	found := false
+
+	ast.Inspect(decl, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == "iota" {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// formatFloatLit formats a float so that it remains a float literal.
+func formatFloatLit(value float64) string {
+	s := strconv.FormatFloat(value, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+
+	return s
+}
@@ -0,0 +1,217 @@
+package mutation
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConstantMutator_Name(t *testing.T) {
+	t.Parallel()
+
+	mutator := &ConstantMutator{}
+
+	if mutator.Name() != constantMutatorName {
+		t.Errorf("Name() = %q, want %q", mutator.Name(), constantMutatorName)
+	}
+}
+
+func TestConstantMutator_TypeAware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "integer and float literals",
+			src: `package main
+func f(n int) (int, float64) {
+	retries := 3
+	ratio := 0.5 * float64(n)
+	return retries + n + 0, ratio
+}`,
+			want: []string{
+				"constant_value: 3 -> -3",
+				"constant_value: 3 -> 0",
+				"constant_value: 0.5 -> 1.5",
+				"constant_value: 0.5 -> -0.5",
+				"constant_value: 0.5 -> 0.0",
+			},
+		},
+		{
+			name: "returned and negated literals",
+			src: `package main
+func f() (int, int) { return 5, -2 }`,
+			want: []string{
+				"constant_value: 5 -> -5",
+				"constant_value: 2 -> 0",
+			},
+		},
+		{
+			name: "named constants",
+			src: `package main
+import "math"
+const maxRetries = 3
+func f(n int) (int, int, float64) { return maxRetries, 2 * maxRetries, math.Pi }`,
+			want: []string{
+				"constant_value: 3 -> -3",
+				"constant_value: 3 -> 0",
+				"constant_value: maxRetries -> maxRetries + 1",
+				"constant_value: maxRetries -> -maxRetries",
+				"constant_value: maxRetries -> 0",
+				"constant_value: 2 -> -2",
+				"constant_value: 2 -> 0",
+				"constant_value: maxRetries -> (maxRetries + 1)",
+				"constant_value: maxRetries -> -maxRetries",
+				"constant_value: maxRetries -> 0",
+				"constant_value: math.Pi -> math.Pi + 1",
+				"constant_value: math.Pi -> -math.Pi",
+				"constant_value: math.Pi -> 0.0",
+			},
+		},
+		{
+			name: "unsigned operands are not negated",
+			src: `package main
+func f() uint8 { var size uint8 = 16; return size }`,
+			want: []string{"constant_value: 16 -> 0"},
+		},
+		{
+			name: "array lengths, iota blocks and shift counts are skipped",
+			src: `package main
+type state int
+const (
+	idle state = iota + 1
+	running
+)
+const size = 4
+func f(x int) ([size]int, [2]int, int, state) {
+	x <<= 2
+	return [size]int{}, [2]int{}, x >> 1, running
+}`,
+			want: []string{
+				"constant_value: 4 -> -4",
+				"constant_value: 4 -> 0",
+			},
+		},
+		{
+			name: "duration units",
+			src: `package main
+import "time"
+func f() time.Duration { return 5 * time.Second + time.Hour }`,
+			want: []string{
+				"constant_value: 5 -> -5",
+				"constant_value: 5 -> 0",
+				"duration_unit: time.Second -> time.Millisecond",
+				"duration_unit: time.Hour -> time.Minute",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := mutateTypeChecked(t, &ConstantMutator{}, tt.src)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mutants mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConstantMutator_ApplyMutant(t *testing.T) {
+	t.Parallel()
+
+	const src = `package main
+
+import "time"
+
+const maxRetries = 3
+
+func Backoff(attempt int) (time.Duration, bool) {
+	delay := 1.5 * float64(attempt)
+	return time.Duration(delay) * time.Second, attempt < 2*maxRetries
+}
+`
+
+	tests := []struct {
+		name   string
+		mutant Mutant
+		want   string
+	}{
+		{
+			name: "float literal incremented",
+			mutant: Mutant{
+				Type: constantValueType, Line: 8, Column: 11,
+				Original: "1.5", Mutated: "2.5",
+			},
+			want: "delay := 2.5 * float64(attempt)",
+		},
+		{
+			name: "named constant operand incremented",
+			mutant: Mutant{
+				Type: constantValueType, Line: 9, Column: 57,
+				Original: "maxRetries", Mutated: "(maxRetries + 1)",
+			},
+			want: "attempt < 2*(maxRetries+1)",
+		},
+		{
+			name: "named constant negated",
+			mutant: Mutant{
+				Type: constantValueType, Line: 9, Column: 57,
+				Original: "maxRetries", Mutated: "-maxRetries",
+			},
+			want: "attempt < 2*-maxRetries",
+		},
+		{
+			name: "literal replaced with zero",
+			mutant: Mutant{
+				Type: constantValueType, Line: 5, Column: 20,
+				Original: "3", Mutated: "0",
+			},
+			want: "const maxRetries = 0",
+		},
+		{
+			name: "duration unit replaced",
+			mutant: Mutant{
+				Type: durationUnitType, Line: 9, Column: 32,
+				Original: "time.Second", Mutated: "time.Millisecond",
+			},
+			want: "time.Duration(delay) * time.Millisecond",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+
+			file, err := parser.ParseFile(fset, "backoff.go", src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("failed to parse source: %v", err)
+			}
+
+			if !ApplyMutant(fset, file, tt.mutant, []Mutator{&ConstantMutator{}}) {
+				t.Fatal("ApplyMutant() = false, want true")
+			}
+
+			var buf bytes.Buffer
+			if err := format.Node(&buf, fset, file); err != nil {
+				t.Fatalf("failed to format file: %v", err)
+			}
+
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("expected output to contain %q, got:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
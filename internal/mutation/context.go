@@ -0,0 +1,346 @@
+package mutation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+const (
+	contextMutatorName       = "context"
+	contextBackgroundType    = "context_background"
+	contextCancelRemovalType = "context_cancel_removal"
+	contextDoneRemovalType   = "context_done_removal"
+	contextTimeoutType       = "context_timeout"
+
+	contextPackagePath = "context"
+	ctxIdentName       = "ctx"
+	cancelIdentName    = "cancel"
+
+	contextBackgroundExpr = "context.Background()"
+	contextTimeoutZero    = "0"
+	// contextTimeoutMax is the largest time.Duration, i.e. no deadline in
+	// practice.
+	contextTimeoutMax = "math.MaxInt64"
+)
+
+// ContextMutator mutates cancellation and deadline handling:
+//   - context.Context arguments are replaced with context.Background()
+//     (context_background)
+//   - `defer cancel()` is replaced with `_ = cancel` so that the context is
+//     never released (context_cancel_removal)
+//   - `case <-ctx.Done():` clauses are dropped from select statements
+//     (context_done_removal)
+//   - context.WithTimeout durations are replaced with zero and with the
+//     largest duration (context_timeout)
+//
+// Contexts and cancel functions are identified by their types; without type
+// information it falls back to identifiers named ctx and cancel.
+type ContextMutator struct {
+	info *types.Info
+}
+
+// Name returns the name of the mutator.
+func (m *ContextMutator) Name() string {
+	return contextMutatorName
+}
+
+// Prepare records the type information of the file about to be mutated.
+func (m *ContextMutator) Prepare(_ *ast.File, info *types.Info) {
+	m.info = info
+}
+
+// CanMutate returns true if the node handles contexts in a way this mutator
+// changes.
+func (m *ContextMutator) CanMutate(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.CallExpr:
+		return m.isTimeoutCall(n) || slices.ContainsFunc(n.Args, m.isPropagatedContext)
+	case *ast.DeferStmt:
+		return m.isDeferredCancel(n)
+	case *ast.SelectStmt:
+		for _, s := range n.Body.List {
+			if clause, ok := s.(*ast.CommClause); ok && m.isDoneClause(clause) {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return false
+	}
+}
+
+// Mutate generates mutants for the given node.
+func (m *ContextMutator) Mutate(node ast.Node, fset *token.FileSet) []Mutant {
+	switch n := node.(type) {
+	case *ast.CallExpr:
+		return m.mutateCall(n, fset)
+	case *ast.DeferStmt:
+		if !m.isDeferredCancel(n) {
+			return nil
+		}
+
+		pos := fset.Position(n.Pos())
+
+		return []Mutant{{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        contextCancelRemovalType,
+			Original:    stmtToString(n),
+			Mutated:     "_ = " + exprToString(n.Call.Fun),
+			Description: "Remove deferred context cancellation",
+		}}
+	case *ast.SelectStmt:
+		return m.mutateSelect(n, fset)
+	default:
+		return nil
+	}
+}
+
+// mutateCall generates mutants for the context arguments of call and for the
+// duration of a context.WithTimeout call.
+func (m *ContextMutator) mutateCall(call *ast.CallExpr, fset *token.FileSet) []Mutant {
+	var mutants []Mutant
+
+	for _, arg := range call.Args {
+		if !m.isPropagatedContext(arg) {
+			continue
+		}
+
+		pos := fset.Position(arg.Pos())
+		original := exprToString(arg)
+
+		mutants = append(mutants, Mutant{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        contextBackgroundType,
+			Original:    original,
+			Mutated:     contextBackgroundExpr,
+			Description: fmt.Sprintf("Replace %s with %s", original, contextBackgroundExpr),
+		})
+	}
+
+	if m.isTimeoutCall(call) {
+		duration := call.Args[1]
+		pos := fset.Position(duration.Pos())
+		original := exprToString(duration)
+
+		for _, mutated := range []string{contextTimeoutZero, contextTimeoutMax} {
+			if original == mutated {
+				continue
+			}
+
+			mutants = append(mutants, Mutant{
+				Line:        pos.Line,
+				Column:      pos.Column,
+				Type:        contextTimeoutType,
+				Original:    original,
+				Mutated:     mutated,
+				Description: fmt.Sprintf("Replace context timeout %s with %s", original, mutated),
+			})
+		}
+	}
+
+	return mutants
+}
+
+// mutateSelect generates mutants dropping the ctx.Done() cases of stmt.
+func (m *ContextMutator) mutateSelect(stmt *ast.SelectStmt, fset *token.FileSet) []Mutant {
+	var mutants []Mutant
+
+	for _, s := range stmt.Body.List {
+		clause, ok := s.(*ast.CommClause)
+		if !ok || !m.isDoneClause(clause) {
+			continue
+		}
+
+		pos := fset.Position(clause.Pos())
+		original := commClauseString(clause)
+
+		mutants = append(mutants, Mutant{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        contextDoneRemovalType,
+			Original:    original,
+			Mutated:     concurrencyRemoved,
+			Description: fmt.Sprintf("Remove select %s", original),
+		})
+	}
+
+	return mutants
+}
+
+// Apply applies the mutation to the given AST node. All context mutations
+// replace nodes, so the plain Apply path always reports failure.
+func (m *ContextMutator) Apply(_ ast.Node, _ Mutant) bool {
+	return false
+}
+
+// ApplyWithCursor replaces context arguments, deferred cancellations and
+// timeout durations.
+func (m *ContextMutator) ApplyWithCursor(node ast.Node, replaceFunc func(ast.Node), mutant Mutant) bool {
+	switch mutant.Type {
+	case contextBackgroundType, contextTimeoutType:
+		expr, ok := node.(ast.Expr)
+		if !ok || exprToString(expr) != mutant.Original {
+			return false
+		}
+
+		replaceFunc(contextReplacement(mutant.Mutated))
+
+		return true
+	case contextCancelRemovalType:
+		stmt, ok := node.(*ast.DeferStmt)
+		if !ok || stmtToString(stmt) != mutant.Original {
+			return false
+		}
+
+		replaceFunc(&ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("_")},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{stmt.Call.Fun},
+		})
+
+		return true
+	default:
+		return false
+	}
+}
+
+// ApplyInBlock removes a ctx.Done() case from the body of a select statement.
+func (m *ContextMutator) ApplyInBlock(block *ast.BlockStmt, index int, mutant Mutant) bool {
+	if mutant.Type != contextDoneRemovalType {
+		return false
+	}
+
+	clause, ok := block.List[index].(*ast.CommClause)
+	if !ok || commClauseString(clause) != mutant.Original {
+		return false
+	}
+
+	block.List = append(block.List[:index], block.List[index+1:]...)
+
+	return true
+}
+
+// RequiredImports returns the packages referenced by the mutated code.
+func (m *ContextMutator) RequiredImports(mutant Mutant) []string {
+	switch {
+	case mutant.Type == contextBackgroundType:
+		return []string{contextPackagePath}
+	case mutant.Type == contextTimeoutType && mutant.Mutated == contextTimeoutMax:
+		return []string{"math"}
+	default:
+		return nil
+	}
+}
+
+// isContext reports whether expr is a context.Context value.
+func (m *ContextMutator) isContext(expr ast.Expr) bool {
+	if m.info != nil {
+		tv, ok := m.info.Types[expr]
+
+		return ok && isContextNamed(tv.Type, "Context")
+	}
+
+	ident, ok := expr.(*ast.Ident)
+
+	return ok && ident.Name == ctxIdentName
+}
+
+// isPropagatedContext reports whether expr is a context passed on to a call,
+// other than a fresh root context.
+func (m *ContextMutator) isPropagatedContext(expr ast.Expr) bool {
+	return m.isContext(expr) && !isRootContext(expr)
+}
+
+// isTimeoutCall reports whether call is context.WithTimeout(parent, d).
+func (m *ContextMutator) isTimeoutCall(call *ast.CallExpr) bool {
+	return len(call.Args) == 2 && isPackageFuncCall(m.info, call, contextPackagePath, "WithTimeout")
+}
+
+// isDeferredCancel reports whether stmt is `defer cancel()` for a
+// context.CancelFunc.
+func (m *ContextMutator) isDeferredCancel(stmt *ast.DeferStmt) bool {
+	if len(stmt.Call.Args) != 0 {
+		return false
+	}
+
+	ident, ok := stmt.Call.Fun.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	if m.info != nil {
+		tv, ok := m.info.Types[ident]
+
+		return ok && isContextNamed(tv.Type, "CancelFunc")
+	}
+
+	return ident.Name == cancelIdentName
+}
+
+// isDoneClause reports whether clause is `case <-ctx.Done():`.
+func (m *ContextMutator) isDoneClause(clause *ast.CommClause) bool {
+	stmt, ok := clause.Comm.(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+
+	recv, ok := stmt.X.(*ast.UnaryExpr)
+	if !ok || recv.Op != token.ARROW {
+		return false
+	}
+
+	call, ok := recv.X.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return false
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+
+	return ok && sel.Sel.Name == "Done" && m.isContext(sel.X)
+}
+
+// isContextNamed reports whether t is the named type name of the context
+// package.
+func isContextNamed(t types.Type, name string) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == contextPackagePath && obj.Name() == name
+}
+
+// isRootContext reports whether expr already is context.Background() or
+// context.TODO().
+func isRootContext(expr ast.Expr) bool {
+	s := exprToString(expr)
+
+	return s == contextBackgroundExpr || s == "context.TODO()"
+}
+
+// contextReplacement builds the expression replacing a context argument or
+// timeout duration.
+func contextReplacement(mutated string) ast.Expr {
+	switch mutated {
+	case contextTimeoutZero:
+		return &ast.BasicLit{Kind: token.INT, Value: contextTimeoutZero}
+	default:
+		pkg, name, _ := strings.Cut(strings.TrimSuffix(mutated, "()"), ".")
+
+		var expr ast.Expr = &ast.SelectorExpr{X: ast.NewIdent(pkg), Sel: ast.NewIdent(name)}
+		if strings.HasSuffix(mutated, "()") {
+			expr = &ast.CallExpr{Fun: expr}
+		}
+
+		return expr
+	}
+}
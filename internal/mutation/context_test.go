@@ -0,0 +1,215 @@
+package mutation
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestContextMutator_Name(t *testing.T) {
+	t.Parallel()
+
+	mutator := &ContextMutator{}
+
+	if mutator.Name() != contextMutatorName {
+		t.Errorf("Name() = %q, want %q", mutator.Name(), contextMutatorName)
+	}
+}
+
+func TestContextMutator_TypeAware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "propagated context argument",
+			src: `package main
+import "context"
+func get(ctx context.Context, key string) string { return key }
+func f(parent context.Context) string { return get(parent, "k") }`,
+			want: []string{"context_background: parent -> context.Background()"},
+		},
+		{
+			name: "root contexts are not replaced",
+			src: `package main
+import "context"
+func get(ctx context.Context) {}
+func f() { get(context.Background()); get(context.TODO()) }`,
+			want: nil,
+		},
+		{
+			name: "non-context value named ctx is skipped",
+			src: `package main
+func get(ctx string) {}
+func f(ctx string) { get(ctx) }`,
+			want: nil,
+		},
+		{
+			name: "timeout and deferred cancel",
+			src: `package main
+import (
+	"context"
+	"time"
+)
+func f(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return ctx.Err()
+}`,
+			want: []string{
+				"context_background: ctx -> context.Background()",
+				"context_timeout: 5 * time.Second -> 0",
+				"context_timeout: 5 * time.Second -> math.MaxInt64",
+				"context_cancel_removal: defer cancel() -> _ = cancel",
+			},
+		},
+		{
+			name: "deferred call that is not a cancel func is skipped",
+			src: `package main
+func f(cancel func()) { defer cancel() }`,
+			want: nil,
+		},
+		{
+			name: "done case in select",
+			src: `package main
+import "context"
+func f(ctx context.Context, ch chan int) int {
+	select {
+	case <-ctx.Done():
+		return 0
+	case v := <-ch:
+		return v
+	}
+}`,
+			want: []string{"context_done_removal: case <-ctx.Done(): -> <removed>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := mutateTypeChecked(t, &ContextMutator{}, tt.src)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mutants mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestContextMutator_ApplyMutant(t *testing.T) {
+	t.Parallel()
+
+	const src = `package main
+
+import (
+	"context"
+	"time"
+)
+
+func Fetch(ctx context.Context, ch chan int) int {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	select {
+	case <-ctx.Done():
+		return 0
+	case v := <-ch:
+		return v
+	}
+}
+`
+
+	tests := []struct {
+		name    string
+		mutant  Mutant
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "context replaced with background",
+			mutant: Mutant{
+				Type: contextBackgroundType, Line: 9, Column: 37,
+				Original: "ctx", Mutated: contextBackgroundExpr,
+			},
+			want: []string{"context.WithTimeout(context.Background(), time.Second)"},
+		},
+		{
+			name: "timeout replaced with maximum",
+			mutant: Mutant{
+				Type: contextTimeoutType, Line: 9, Column: 42,
+				Original: "time.Second", Mutated: contextTimeoutMax,
+			},
+			want: []string{"context.WithTimeout(ctx, math.MaxInt64)", "\"math\""},
+		},
+		{
+			name: "timeout replaced with zero",
+			mutant: Mutant{
+				Type: contextTimeoutType, Line: 9, Column: 42,
+				Original: "time.Second", Mutated: contextTimeoutZero,
+			},
+			want:    []string{"context.WithTimeout(ctx, 0)"},
+			notWant: []string{"\"math\""},
+		},
+		{
+			name: "deferred cancel removed",
+			mutant: Mutant{
+				Type: contextCancelRemovalType, Line: 10, Column: 2,
+				Original: "defer cancel()", Mutated: "_ = cancel",
+			},
+			want:    []string{"_ = cancel"},
+			notWant: []string{"defer cancel()"},
+		},
+		{
+			name: "done case removed",
+			mutant: Mutant{
+				Type: contextDoneRemovalType, Line: 12, Column: 2,
+				Original: "case <-ctx.Done():", Mutated: concurrencyRemoved,
+			},
+			want:    []string{"case v := <-ch:"},
+			notWant: []string{"ctx.Done()"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+
+			file, err := parser.ParseFile(fset, "fetch.go", src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("failed to parse source: %v", err)
+			}
+
+			if !ApplyMutant(fset, file, tt.mutant, []Mutator{&ContextMutator{}}) {
+				t.Fatal("ApplyMutant() = false, want true")
+			}
+
+			var buf bytes.Buffer
+			if err := format.Node(&buf, fset, file); err != nil {
+				t.Fatalf("failed to format file: %v", err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, buf.String())
+				}
+			}
+
+			for _, notWant := range tt.notWant {
+				if strings.Contains(buf.String(), notWant) {
+					t.Errorf("expected output not to contain %q, got:\n%s", notWant, buf.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package mutation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+const (
+	deferPanicMutatorName = "defer_panic"
+	deferRemovalType      = "defer_removal"
+	deferImmediateType    = "defer_immediate"
+	recoverRemovalType    = "recover_removal"
+	panicRemovalType      = "panic_removal"
+	panicToReturnType     = "panic_to_return"
+
+	deferPanicRemoved = "<removed>"
+	panicReturn       = "return"
+)
+
+// DeferPanicMutator mutates Go specific control flow:
+//   - deferred calls are removed (defer_removal)
+//   - deferred calls are executed immediately instead: `defer f.Close()`
+//     becomes `f.Close()` (defer_immediate)
+//   - deferred functions calling recover() are removed, so that panics are
+//     no longer recovered (recover_removal)
+//   - panic(x) statements are removed (panic_removal) and, in functions
+//     whose results can be returned bare, replaced with `return`
+//     (panic_to_return)
+//
+// Defer statements and panic calls are not mutated by the statement_removal
+// and expression_removal mutators, so that these mutations can be tuned on
+// their own. The builtins are recognised through type information, falling
+// back to their names.
+type DeferPanicMutator struct {
+	info *types.Info
+	// bareReturns holds the panic statements of functions without results
+	// or with named results.
+	bareReturns map[*ast.ExprStmt]bool
+}
+
+// Name returns the name of the mutator.
+func (m *DeferPanicMutator) Name() string {
+	return deferPanicMutatorName
+}
+
+// Prepare records the type information of the file about to be mutated and
+// the panic statements that may be replaced with a bare return.
+func (m *DeferPanicMutator) Prepare(file *ast.File, info *types.Info) {
+	m.info = info
+	m.bareReturns = make(map[*ast.ExprStmt]bool)
+
+	if file == nil {
+		return
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncDecl:
+			m.recordBareReturns(n.Type, n.Body)
+		case *ast.FuncLit:
+			m.recordBareReturns(n.Type, n.Body)
+		}
+
+		return true
+	})
+}
+
+// recordBareReturns records the panic statements of a function body, not
+// descending into nested function literals, if the function allows a bare
+// return.
+func (m *DeferPanicMutator) recordBareReturns(typ *ast.FuncType, body *ast.BlockStmt) {
+	if body == nil || !allowsBareReturn(typ) {
+		return
+	}
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ExprStmt:
+			if m.isBuiltinCall(n.X, "panic") {
+				m.bareReturns[n] = true
+			}
+		}
+
+		return true
+	})
+}
+
+// CanMutate returns true if the node can be mutated by this mutator.
+func (m *DeferPanicMutator) CanMutate(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.DeferStmt:
+		return true
+	case *ast.ExprStmt:
+		return m.isBuiltinCall(n.X, "panic")
+	default:
+		return false
+	}
+}
+
+// Mutate generates mutants for the given node.
+func (m *DeferPanicMutator) Mutate(node ast.Node, fset *token.FileSet) []Mutant {
+	switch n := node.(type) {
+	case *ast.DeferStmt:
+		pos := fset.Position(n.Pos())
+		original := stmtToString(n)
+
+		if m.isRecoverHandler(n) {
+			return []Mutant{{
+				Line:        pos.Line,
+				Column:      pos.Column,
+				Type:        recoverRemovalType,
+				Original:    original,
+				Mutated:     deferPanicRemoved,
+				Description: "Remove deferred recover handler",
+			}}
+		}
+
+		immediate := exprToString(n.Call)
+
+		return []Mutant{
+			{
+				Line:        pos.Line,
+				Column:      pos.Column,
+				Type:        deferRemovalType,
+				Original:    original,
+				Mutated:     deferPanicRemoved,
+				Description: fmt.Sprintf("Remove %s", original),
+			},
+			{
+				Line:        pos.Line,
+				Column:      pos.Column,
+				Type:        deferImmediateType,
+				Original:    original,
+				Mutated:     immediate,
+				Description: fmt.Sprintf("Call %s immediately instead of deferring it", immediate),
+			},
+		}
+	case *ast.ExprStmt:
+		if !m.isBuiltinCall(n.X, "panic") {
+			return nil
+		}
+
+		pos := fset.Position(n.Pos())
+		original := exprToString(n.X)
+
+		mutants := []Mutant{{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        panicRemovalType,
+			Original:    original,
+			Mutated:     deferPanicRemoved,
+			Description: fmt.Sprintf("Remove %s", original),
+		}}
+
+		if m.bareReturns[n] {
+			mutants = append(mutants, Mutant{
+				Line:        pos.Line,
+				Column:      pos.Column,
+				Type:        panicToReturnType,
+				Original:    original,
+				Mutated:     panicReturn,
+				Description: fmt.Sprintf("Replace %s with return", original),
+			})
+		}
+
+		return mutants
+	default:
+		return nil
+	}
+}
+
+// Apply applies the mutation to the given AST node.
+// Defer and panic mutations replace whole statements via the cursor, so the
+// plain Apply path always reports failure.
+func (m *DeferPanicMutator) Apply(_ ast.Node, _ Mutant) bool {
+	return false
+}
+
+// ApplyWithCursor replaces the defer or panic statement.
+func (m *DeferPanicMutator) ApplyWithCursor(node ast.Node, replaceFunc func(ast.Node), mutant Mutant) bool {
+	switch mutant.Type {
+	case deferRemovalType, deferImmediateType, recoverRemovalType:
+		stmt, ok := node.(*ast.DeferStmt)
+		if !ok || stmtToString(stmt) != mutant.Original {
+			return false
+		}
+
+		if mutant.Type == deferImmediateType {
+			replaceFunc(&ast.ExprStmt{X: stmt.Call})
+
+			return true
+		}
+
+		replaceFunc(&ast.EmptyStmt{})
+
+		return true
+	case panicRemovalType, panicToReturnType:
+		stmt, ok := node.(*ast.ExprStmt)
+		if !ok || exprToString(stmt.X) != mutant.Original {
+			return false
+		}
+
+		if mutant.Type == panicToReturnType {
+			replaceFunc(&ast.ReturnStmt{Return: stmt.Pos()})
+
+			return true
+		}
+
+		replaceFunc(&ast.EmptyStmt{})
+
+		return true
+	default:
+		return false
+	}
+}
+
+// isRecoverHandler reports whether a deferred function literal calls
+// recover().
+func (m *DeferPanicMutator) isRecoverHandler(stmt *ast.DeferStmt) bool {
+	lit, ok := stmt.Call.Fun.(*ast.FuncLit)
+	if !ok {
+		return false
+	}
+
+	found := false
+
+	ast.Inspect(lit.Body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if m.isBuiltinCall(n, "recover") {
+				found = true
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// isBuiltinCall reports whether expr calls the builtin function name.
+func (m *DeferPanicMutator) isBuiltinCall(expr ast.Expr, name string) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok || ident.Name != name {
+		return false
+	}
+
+	if m.info == nil {
+		return true
+	}
+
+	_, ok = m.info.Uses[ident].(*types.Builtin)
+
+	return ok
+}
+
+// allowsBareReturn reports whether a function has no results or only named
+// results, so that a bare return statement is valid in it.
+func allowsBareReturn(typ *ast.FuncType) bool {
+	if typ.Results == nil {
+		return true
+	}
+
+	for _, field := range typ.Results.List {
+		if len(field.Names) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,216 @@
+package mutation
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDeferPanicMutator_Name(t *testing.T) {
+	t.Parallel()
+
+	mutator := &DeferPanicMutator{}
+
+	if mutator.Name() != deferPanicMutatorName {
+		t.Errorf("Name() = %q, want %q", mutator.Name(), deferPanicMutatorName)
+	}
+}
+
+func TestDeferPanicMutator_TypeAware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "deferred cleanup",
+			src: `package main
+import "os"
+func f(name string) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return nil
+}`,
+			want: []string{
+				"defer_removal: defer file.Close() -> <removed>",
+				"defer_immediate: defer file.Close() -> file.Close()",
+			},
+		},
+		{
+			name: "recover handler",
+			src: `package main
+func f(run func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = nil
+		}
+	}()
+	run()
+	return nil
+}`,
+			want: []string{"recover_removal: defer func() {\n\tif r := recover(); r != nil {\n\t\terr = nil\n\t}\n}() -> <removed>"},
+		},
+		{
+			name: "panic with and without bare return",
+			src: `package main
+func check(ok bool) {
+	if !ok {
+		panic("not ok")
+	}
+}
+func must(v int, err error) int {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}`,
+			want: []string{
+				`panic_removal: panic("not ok") -> <removed>`,
+				`panic_to_return: panic("not ok") -> return`,
+				"panic_removal: panic(err) -> <removed>",
+			},
+		},
+		{
+			name: "panic inside a function literal",
+			src: `package main
+func f() int {
+	g := func() { panic("x") }
+	g()
+	return 0
+}`,
+			want: []string{
+				`panic_removal: panic("x") -> <removed>`,
+				`panic_to_return: panic("x") -> return`,
+			},
+		},
+		{
+			name: "shadowed panic is skipped",
+			src: `package main
+func panic(v any) {}
+func f() { panic(1) }`,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := mutateTypeChecked(t, &DeferPanicMutator{}, tt.src)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mutants mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeferPanicMutator_ApplyMutant(t *testing.T) {
+	t.Parallel()
+
+	const src = `package main
+
+import "sync"
+
+func Handle(mu *sync.Mutex, fn func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	defer func() {
+		if recover() != nil {
+			println("recovered")
+		}
+	}()
+	if fn == nil {
+		panic("nil handler")
+	}
+	fn()
+}
+`
+
+	tests := []struct {
+		name    string
+		mutant  Mutant
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "deferred call removed",
+			mutant: Mutant{
+				Type: deferRemovalType, Line: 7, Column: 2,
+				Original: "defer mu.Unlock()", Mutated: deferPanicRemoved,
+			},
+			notWant: []string{"mu.Unlock()"},
+		},
+		{
+			name: "deferred call executed immediately",
+			mutant: Mutant{
+				Type: deferImmediateType, Line: 7, Column: 2,
+				Original: "defer mu.Unlock()", Mutated: "mu.Unlock()",
+			},
+			want:    []string{"mu.Lock()\n\tmu.Unlock()\n"},
+			notWant: []string{"defer mu.Unlock()"},
+		},
+		{
+			name: "recover handler removed",
+			mutant: Mutant{
+				Type: recoverRemovalType, Line: 8, Column: 2,
+				Original: "defer func() {\n\tif recover() != nil {\n\t\tprintln(\"recovered\")\n\t}\n}()",
+				Mutated:  deferPanicRemoved,
+			},
+			notWant: []string{"recover()"},
+		},
+		{
+			name: "panic replaced with return",
+			mutant: Mutant{
+				Type: panicToReturnType, Line: 14, Column: 3,
+				Original: `panic("nil handler")`, Mutated: panicReturn,
+			},
+			want:    []string{"if fn == nil {\n\t\treturn\n\t}"},
+			notWant: []string{"panic("},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+
+			file, err := parser.ParseFile(fset, "handle.go", src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("failed to parse source: %v", err)
+			}
+
+			if !ApplyMutant(fset, file, tt.mutant, []Mutator{&DeferPanicMutator{}}) {
+				t.Fatal("ApplyMutant() = false, want true")
+			}
+
+			var buf bytes.Buffer
+			if err := format.Node(&buf, fset, file); err != nil {
+				t.Fatalf("failed to format file: %v", err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, buf.String())
+				}
+			}
+
+			for _, notWant := range tt.notWant {
+				if strings.Contains(buf.String(), notWant) {
+					t.Errorf("expected output not to contain %q, got:\n%s", notWant, buf.String())
+				}
+			}
+		})
+	}
+}
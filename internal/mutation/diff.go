@@ -0,0 +1,228 @@
+package mutation
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// diffContext is the number of unchanged lines shown around changes.
+	diffContext = 3
+	// maxDiffCells bounds the line comparison table; larger changes are
+	// shown as a single replacement.
+	maxDiffCells = 1 << 22
+)
+
+// diffOp is a line of an edit script: ' ' keeps, '-' deletes and '+' inserts
+// a line.
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// UnifiedDiff returns the unified diff from original to mutated, both the
+// content of path, with a few lines of context around each change. It
+// returns "" if they are equal.
+func UnifiedDiff(path string, original, mutated []byte) string {
+	ops := diffLines(splitLines(string(original)), splitLines(string(mutated)))
+
+	var buf strings.Builder
+
+	oldLine, newLine := 1, 1
+
+	for start := 0; start < len(ops); {
+		// Find the next change and the run of changes that follows it,
+		// merging changes separated by less than twice the context.
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+
+		if first == len(ops) {
+			break
+		}
+
+		last := first
+
+		for i := first; i < len(ops) && i-last <= 2*diffContext; i++ {
+			if ops[i].kind != ' ' {
+				last = i
+			}
+		}
+
+		from := max(first-diffContext, start)
+		to := min(last+diffContext+1, len(ops))
+
+		// Lines before the hunk are unchanged.
+		oldLine += from - start
+		newLine += from - start
+
+		if buf.Len() == 0 {
+			writeFileHeader(&buf, path)
+		}
+
+		writeHunk(&buf, ops[from:to], oldLine, newLine)
+
+		for _, op := range ops[from:to] {
+			if op.kind != '+' {
+				oldLine++
+			}
+
+			if op.kind != '-' {
+				newLine++
+			}
+		}
+
+		start = to
+	}
+
+	return buf.String()
+}
+
+// writeFileHeader writes the file header of the diff of path. Relative paths
+// get the a/ and b/ prefixes of git diffs.
+func writeFileHeader(buf *strings.Builder, path string) {
+	if strings.HasPrefix(path, "/") {
+		fmt.Fprintf(buf, "--- %s\n+++ %s\n", path, path)
+
+		return
+	}
+
+	fmt.Fprintf(buf, "--- a/%s\n+++ b/%s\n", path, path)
+}
+
+// writeHunk writes the hunk of ops starting at oldLine in the original and
+// newLine in the mutated source.
+func writeHunk(buf *strings.Builder, ops []diffOp, oldLine, newLine int) {
+	oldCount, newCount := 0, 0
+
+	for _, op := range ops {
+		if op.kind != '+' {
+			oldCount++
+		}
+
+		if op.kind != '-' {
+			newCount++
+		}
+	}
+
+	fmt.Fprintf(buf, "@@ -%s +%s @@\n", hunkRange(oldLine, oldCount), hunkRange(newLine, newCount))
+
+	for _, op := range ops {
+		buf.WriteByte(op.kind)
+		buf.WriteString(op.line)
+		buf.WriteByte('\n')
+	}
+}
+
+// hunkRange formats the range of count lines starting at line. Empty ranges
+// refer to the line before them.
+func hunkRange(line, count int) string {
+	if count == 0 {
+		line--
+	}
+
+	if count == 1 {
+		return fmt.Sprintf("%d", line)
+	}
+
+	return fmt.Sprintf("%d,%d", line, count)
+}
+
+// diffLines returns an edit script from a to b.
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b)-prefix-suffix)
+
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+
+	ops = append(ops, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+
+	return ops
+}
+
+// diffMiddle returns an edit script from a to b based on their longest common
+// subsequence of lines.
+func diffMiddle(a, b []string) []diffOp {
+	ops := make([]diffOp, 0, len(a)+len(b))
+
+	if len(a)*len(b) > maxDiffCells {
+		for _, line := range a {
+			ops = append(ops, diffOp{'-', line})
+		}
+
+		for _, line := range b {
+			ops = append(ops, diffOp{'+', line})
+		}
+
+		return ops
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+
+	return ops
+}
+
+// splitLines splits s into lines without their line terminators.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,148 @@
+package mutation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	lines := func(n int) []string {
+		l := make([]string, n)
+		for i := range l {
+			l[i] = string(rune('a' + i))
+		}
+
+		return l
+	}
+
+	source := func(l []string) []byte {
+		return []byte(strings.Join(l, "\n") + "\n")
+	}
+
+	replace := func(l []string, i int, line string) []string {
+		c := append([]string(nil), l...)
+		c[i] = line
+
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		original []string
+		mutated  []string
+		want     string
+	}{
+		{
+			name:     "identical",
+			original: lines(5),
+			mutated:  lines(5),
+			want:     "",
+		},
+		{
+			name:     "line replaced",
+			original: lines(10),
+			mutated:  replace(lines(10), 5, "F"),
+			want: `--- a/x.go
++++ b/x.go
+@@ -3,7 +3,7 @@
+ c
+ d
+ e
+-f
++F
+ g
+ h
+ i
+`,
+		},
+		{
+			name:     "line removed at start",
+			original: lines(5),
+			mutated:  lines(5)[1:],
+			want: `--- a/x.go
++++ b/x.go
+@@ -1,4 +1,3 @@
+-a
+ b
+ c
+ d
+`,
+		},
+		{
+			name:     "line inserted into empty file",
+			original: nil,
+			mutated:  []string{"a"},
+			want: `--- a/x.go
++++ b/x.go
+@@ -0,0 +1 @@
++a
+`,
+		},
+		{
+			name:     "distant changes in separate hunks",
+			original: lines(20),
+			mutated:  replace(replace(lines(20), 1, "B"), 18, "S"),
+			want: `--- a/x.go
++++ b/x.go
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -16,5 +16,5 @@
+ p
+ q
+ r
+-s
++S
+ t
+`,
+		},
+		{
+			name:     "close changes in one hunk",
+			original: lines(10),
+			mutated:  replace(replace(lines(10), 2, "C"), 7, "H"),
+			want: `--- a/x.go
++++ b/x.go
+@@ -1,10 +1,10 @@
+ a
+ b
+-c
++C
+ d
+ e
+ f
+ g
+-h
++H
+ i
+ j
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var original, mutated []byte
+			if tt.original != nil {
+				original = source(tt.original)
+			}
+
+			if tt.mutated != nil {
+				mutated = source(tt.mutated)
+			}
+
+			if diff := cmp.Diff(tt.want, UnifiedDiff("x.go", original, mutated)); diff != "" {
+				t.Errorf("UnifiedDiff() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -3,9 +3,11 @@
 package mutation
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 
 	"github.com/sivchari/gomu/internal/analysis"
 )
@@ -14,6 +16,89 @@ import (
 type Engine struct {
 	analyzer *analysis.Analyzer
 	mutators []Mutator
+	// filtered counts mutants discarded by type checking before execution.
+	filtered int
+	// duplicates counts mutants pruned because an earlier mutant produces
+	// the same mutated source; keepDuplicates disables the pruning.
+	duplicates     int
+	keepDuplicates bool
+	// subsume enables pruning mutants subsumed by other mutants at the same
+	// site; subsumed counts the pruned ones.
+	subsume  bool
+	subsumed int
+	// order is the number of first-order mutants combined into each
+	// higher-order mutant; orderLimit caps the higher-order mutants per file.
+	order      int
+	orderLimit int
+	// callSwaps extends the default call swap table.
+	callSwaps []CallSwap
+	// extra are the mutators of this engine only, added with WithMutators.
+	extra []Mutator
+	// typeErrors lists the packages whose type errors prevented checking
+	// their mutants exactly, once per package directory.
+	typeErrors     []PackageTypeErrors
+	typeErrorsSeen map[string]bool
+}
+
+// PackageTypeErrors lists the type errors of a package. Its mutants are only
+// filtered by heuristics, since type checking them would report the errors of
+// the original package.
+type PackageTypeErrors struct {
+	Dir    string
+	Errors []string
+}
+
+// Option is a functional option for configuring an Engine.
+type Option func(*Engine)
+
+// WithAnalyzer sets the analyzer used to parse and type check files, so that
+// loaded packages can be shared with the caller.
+func WithAnalyzer(analyzer *analysis.Analyzer) Option {
+	return func(e *Engine) {
+		e.analyzer = analyzer
+	}
+}
+
+// WithCallSwaps adds call swaps to the default table used by the call_swap
+// mutator.
+func WithCallSwaps(swaps []CallSwap) Option {
+	return func(e *Engine) {
+		e.callSwaps = swaps
+	}
+}
+
+// WithSubsumption enables pruning relational operator replacements that are
+// subsumed by other replacements at the same site.
+func WithSubsumption(enabled bool) Option {
+	return func(e *Engine) {
+		e.subsume = enabled
+	}
+}
+
+// WithDuplicatePruning enables or disables pruning mutants whose mutated
+// source is identical to that of an earlier mutant. It is enabled by default.
+func WithDuplicatePruning(enabled bool) Option {
+	return func(e *Engine) {
+		e.keepDuplicates = !enabled
+	}
+}
+
+// WithHigherOrder enables higher-order mutants: besides the first-order
+// mutants, up to limit mutants per file are sampled that each combine order
+// first-order mutants of the same function. Orders below 2 disable them.
+func WithHigherOrder(order, limit int) Option {
+	return func(e *Engine) {
+		e.order = order
+		e.orderLimit = limit
+	}
+}
+
+// WithMutators adds mutators to this engine only, after the built-in and
+// registered ones. Unlike Register, they do not affect other engines.
+func WithMutators(mutators ...Mutator) Option {
+	return func(e *Engine) {
+		e.extra = append(e.extra, mutators...)
+	}
 }
 
 // Mutant represents a single mutation.
@@ -26,8 +111,18 @@ type Mutant struct {
 	Original    string `json:"original"`
 	Mutated     string `json:"mutated"`
 	Description string `json:"description"`
-	Context     string `json:"context,omitempty"`  // Source code context around the mutation
 	Function    string `json:"function,omitempty"` // Function name containing the mutation
+	Diff        string `json:"diff,omitempty"`     // Unified diff of the mutated file, set when the mutant is executed
+
+	// Locator of the node the mutant was generated from, used to apply it
+	// unambiguously.
+	Mutator   string `json:"mutator,omitempty"`   // Name of the mutator that generated the mutant
+	NodeKind  string `json:"nodeKind,omitempty"`  // Go type of the node, e.g. *ast.BinaryExpr
+	Offset    int    `json:"offset,omitempty"`    // Byte offset of the start of the node
+	EndOffset int    `json:"endOffset,omitempty"` // Byte offset of the end of the node
+
+	// Components are the first-order mutants a higher-order mutant combines.
+	Components []Mutant `json:"components,omitempty"`
 }
 
 // Result represents the result of testing a mutant.
@@ -65,6 +160,9 @@ const (
 	StatusError Status = "ERROR" // Build or runtime error
 	// StatusNotViable indicates that a mutant causes compilation failure.
 	StatusNotViable Status = "NOT_VIABLE" // Mutant causes compilation failure
+	// StatusEquivalent indicates that a mutant compiles to the same object
+	// code as the original, so no test can kill it.
+	StatusEquivalent Status = "EQUIVALENT" // Mutant is equivalent to the original
 )
 
 // Mutator interface for different types of mutations.
@@ -77,24 +175,70 @@ type Mutator interface {
 
 // CursorApplier is an optional interface that mutators can implement
 // to support mutations requiring parent node context (e.g., node replacement).
+// Calling replaceFunc with nil deletes the node from the list containing it,
+// such as the elements of a composite literal.
 type CursorApplier interface {
 	ApplyWithCursor(node ast.Node, replaceFunc func(ast.Node), mutant Mutant) bool
 }
 
-// New creates a new mutation engine.
-func New() (*Engine, error) {
-	analyzer, err := analysis.New()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create analyzer: %w", err)
-	}
+// BlockApplier is an optional interface for mutators whose mutations span
+// several statements of the enclosing block, such as removing a Lock/Unlock
+// pair. ApplyInBlock is called with the block and the index of the statement
+// at the mutant's position when neither ApplyWithCursor nor Apply succeeded.
+type BlockApplier interface {
+	ApplyInBlock(block *ast.BlockStmt, index int, mutant Mutant) bool
+}
+
+// TypeAwareMutator is an optional interface for mutators that need type
+// information. Prepare is called with each file before it is walked; info is
+// nil when type checking failed, in which case mutators should fall back to
+// syntax-only heuristics.
+type TypeAwareMutator interface {
+	Prepare(file *ast.File, info *types.Info)
+}
+
+// ImportRequirer is an optional interface for mutators whose mutated code
+// references packages that the file may not import yet.
+type ImportRequirer interface {
+	RequiredImports(mutant Mutant) []string
+}
 
+// New creates a new mutation engine with optional configuration.
+func New(opts ...Option) (*Engine, error) {
 	engine := &Engine{
-		analyzer: analyzer,
 		mutators: make([]Mutator, 0),
 	}
 
-	// Register all mutators from generated registry
-	engine.mutators = getAllMutators()
+	for _, opt := range opts {
+		opt(engine)
+	}
+
+	if engine.analyzer == nil {
+		analyzer, err := analysis.New()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create analyzer: %w", err)
+		}
+
+		engine.analyzer = analyzer
+	}
+
+	// Register all mutators from generated registry, followed by the ones
+	// registered by plugins and the ones of this engine
+	engine.mutators = Mutators()
+
+	for _, mutator := range engine.extra {
+		if err := checkName(mutator, engine.mutators); err != nil {
+			return nil, fmt.Errorf("failed to add mutator: %w", err)
+		}
+
+		engine.mutators = append(engine.mutators, mutator)
+	}
+
+	for _, mutator := range engine.mutators {
+		if cs, ok := mutator.(*CallSwapMutator); ok {
+			cs.setCallSwaps(engine.callSwaps)
+		}
+	}
 
 	return engine, nil
 }
@@ -107,12 +251,37 @@ func (e *Engine) GenerateMutants(filePath string) ([]Mutant, error) {
 	}
 
 	// Create type checker if type info is available
-	var typeChecker *TypeChecker
+	var (
+		typeChecker *TypeChecker
+		viability   *ViabilityChecker
+	)
+
 	if fileInfo.TypeInfo != nil {
 		typeChecker = NewTypeChecker(fileInfo.TypeInfo)
+
+		// With the full package available, check the remaining mutants
+		// exactly once they have been pruned.
+		if fileInfo.Package != nil {
+			viability, err = NewViabilityChecker(e.analyzer.GetFileSet(), fileInfo.Package, filePath, e.mutators)
+			if errors.Is(err, errTypeErrors) {
+				e.recordTypeErrors(fileInfo.Package)
+			}
+		}
+	}
+
+	for _, mutator := range e.mutators {
+		if tam, ok := mutator.(TypeAwareMutator); ok {
+			tam.Prepare(fileInfo.FileAST, fileInfo.TypeInfo)
+		}
 	}
 
-	var allMutants []Mutant
+	var (
+		allMutants []Mutant
+		function   *ast.FuncDecl
+		// functions maps the offsets of mutated nodes to the declarations of
+		// the functions containing them.
+		functions = make(map[int]*ast.FuncDecl)
+	)
 
 	// Walk the AST and apply mutators
 	ast.Inspect(fileInfo.FileAST, func(node ast.Node) bool {
@@ -120,6 +289,10 @@ func (e *Engine) GenerateMutants(filePath string) ([]Mutant, error) {
 			return false
 		}
 
+		if decl, ok := node.(*ast.FuncDecl); ok {
+			function = decl
+		}
+
 		// Import paths are string literals but must never be mutated; skip
 		// the whole import spec subtree.
 		if _, ok := node.(*ast.ImportSpec); ok {
@@ -133,11 +306,19 @@ func (e *Engine) GenerateMutants(filePath string) ([]Mutant, error) {
 				// Filter mutants based on type information
 				for i := range mutants {
 					mutants[i].FilePath = filePath
+					mutants[i].Function = enclosingFunction(function, node)
 					mutants[i].ID = fmt.Sprintf("%s_%d", filePath, len(allMutants)+i)
+					SetLocator(&mutants[i], e.analyzer.GetFileSet(), node, mutator)
+
+					if mutants[i].Function != "" {
+						functions[mutants[i].Offset] = function
+					}
 
 					// Only add mutant if it passes type check
 					if typeChecker == nil || typeChecker.IsValidMutation(node, mutants[i]) {
 						allMutants = append(allMutants, mutants[i])
+					} else {
+						e.filtered++
 					}
 				}
 			}
@@ -146,9 +327,74 @@ func (e *Engine) GenerateMutants(filePath string) ([]Mutant, error) {
 		return true
 	})
 
+	if e.subsume {
+		allMutants = e.pruneSubsumed(allMutants)
+	}
+
+	if !e.keepDuplicates {
+		allMutants = e.pruneDuplicates(filePath, allMutants)
+	}
+
+	if viability != nil {
+		allMutants = e.filterViable(viability, allMutants)
+	}
+
+	renumber(filePath, allMutants)
+
+	if e.order > 1 {
+		allMutants = append(allMutants, e.combine(allMutants, functions, viability)...)
+		renumber(filePath, allMutants)
+	}
+
 	return allMutants, nil
 }
 
+// FilteredMutants returns the number of mutants discarded by type checking
+// across all GenerateMutants calls.
+func (e *Engine) FilteredMutants() int {
+	return e.filtered
+}
+
+// TypeErrors returns the packages whose type errors prevented checking their
+// mutants exactly, across all GenerateMutants calls.
+func (e *Engine) TypeErrors() []PackageTypeErrors {
+	return e.typeErrors
+}
+
+// recordTypeErrors records the type errors of pkg, once per package.
+func (e *Engine) recordTypeErrors(pkg *analysis.LoadedPackage) {
+	if e.typeErrorsSeen[pkg.Dir] {
+		return
+	}
+
+	if e.typeErrorsSeen == nil {
+		e.typeErrorsSeen = make(map[string]bool)
+	}
+
+	e.typeErrorsSeen[pkg.Dir] = true
+
+	messages := make([]string, len(pkg.Errors))
+	for i, err := range pkg.Errors {
+		messages[i] = err.Error()
+	}
+
+	e.typeErrors = append(e.typeErrors, PackageTypeErrors{Dir: pkg.Dir, Errors: messages})
+}
+
+// DuplicateMutants returns the number of mutants pruned because they produce
+// the same mutated source as another mutant, across all GenerateMutants
+// calls.
+func (e *Engine) DuplicateMutants() int {
+	return e.duplicates
+}
+
+// SubsumedMutants returns the number of mutants pruned because they are
+// subsumed by other mutants at the same site, across all GenerateMutants
+// calls.
+func (e *Engine) SubsumedMutants() int {
+	return e.subsumed
+}
+
 // GetFileSet returns the file set used by the engine.
 func (e *Engine) GetFileSet() *token.FileSet {
 	return e.analyzer.GetFileSet()
@@ -74,8 +74,8 @@ func TestNew(t *testing.T) {
 		t.Fatal("Expected engine to be non-nil")
 	}
 
-	if len(engine.mutators) != 17 {
-		t.Errorf("Expected 17 mutators, got %d", len(engine.mutators))
+	if len(engine.mutators) != 26 {
+		t.Errorf("Expected 26 mutators, got %d", len(engine.mutators))
 	}
 
 	// Check mutator types
@@ -85,7 +85,7 @@ func TestNew(t *testing.T) {
 		mutatorNames[mutator.Name()] = true
 	}
 
-	expectedMutators := []string{"arithmetic", "assignment_removal", "boundary_value", "branch", "break_continue", "conditional", "empty_block", "error_handling", "expression_removal", "invert_negatives", "logical", "loop_condition", "remove_self_assignments", "return", "statement_removal", "string_literal"}
+	expectedMutators := []string{"arithmetic", "assignment_removal", "boundary_value", "branch", "break_continue", "call_swap", "collection", "concurrency", "conditional", "constant", "context", "defer_panic", "empty_block", "error_handling", "expression_removal", "generics", "invert_negatives", "logical", "loop_condition", "pointer_semantics", "remove_self_assignments", "return", "statement_removal", "string_literal", "switch"}
 
 	for _, expected := range expectedMutators {
 		if !mutatorNames[expected] {
@@ -101,8 +101,8 @@ func TestNew_CustomMutators(t *testing.T) {
 		t.Fatalf("Failed to create mutation engine: %v", err)
 	}
 
-	if len(engine.mutators) != 17 {
-		t.Errorf("Expected 17 mutators (all types enabled by default), got %d", len(engine.mutators))
+	if len(engine.mutators) != 26 {
+		t.Errorf("Expected 26 mutators (all types enabled by default), got %d", len(engine.mutators))
 	}
 }
 
@@ -114,8 +114,8 @@ func TestNew_InvalidMutator(t *testing.T) {
 	}
 
 	// Should ignore invalid mutator
-	if len(engine.mutators) != 17 {
-		t.Errorf("Expected 17 mutators (all types enabled by default), got %d", len(engine.mutators))
+	if len(engine.mutators) != 26 {
+		t.Errorf("Expected 26 mutators (all types enabled by default), got %d", len(engine.mutators))
 	}
 }
 
@@ -380,6 +380,93 @@ func Check() bool {
 	}
 }
 
+func TestGenerateMutants_InModuleImportFiltering(t *testing.T) {
+	// Types from in-module imports are only known when the package is loaded
+	// like the go command does; ordered comparisons of structs must be filtered.
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":         "module example.com/m\n\ngo 1.21\n",
+		"other/other.go": "package other\n\ntype Point struct{ X, Y int }\n",
+		"same.go": `package m
+
+import "example.com/m/other"
+
+func Same(a, b other.Point) bool {
+	return a == b
+}
+`,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create mutation engine: %v", err)
+	}
+
+	mutants, err := engine.GenerateMutants(filepath.Join(tmpDir, "same.go"))
+	if err != nil {
+		t.Fatalf("Failed to generate mutants: %v", err)
+	}
+
+	for _, m := range mutants {
+		if m.Type == conditionalBinaryType && m.Mutated != "!=" {
+			t.Errorf("Should not generate comparison mutation %s for struct type", m.Mutated)
+		}
+	}
+
+	if engine.FilteredMutants() == 0 {
+		t.Error("Expected ordered comparison mutants to be counted as filtered")
+	}
+}
+
+func TestGenerateMutants_TypeErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":    "module example.com/m\n\ngo 1.21\n",
+		"a.go":      "package m\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n",
+		"b.go":      "package m\n\nfunc Sub(a, b int) int {\n\treturn a - b\n}\n",
+		"broken.go": "package m\n\nvar broken int = \"text\"\n",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create mutation engine: %v", err)
+	}
+
+	for _, name := range []string{"a.go", "b.go"} {
+		if _, err := engine.GenerateMutants(filepath.Join(tmpDir, name)); err != nil {
+			t.Fatalf("Failed to generate mutants for %s: %v", name, err)
+		}
+	}
+
+	typeErrors := engine.TypeErrors()
+	if len(typeErrors) != 1 {
+		t.Fatalf("TypeErrors() returned %d packages, want 1", len(typeErrors))
+	}
+
+	if len(typeErrors[0].Errors) == 0 || !strings.Contains(typeErrors[0].Errors[0], "broken.go") {
+		t.Errorf("Expected the type error of broken.go, got %v", typeErrors[0].Errors)
+	}
+}
+
 func TestGenerateMutants_RealEngineFile(t *testing.T) {
 	// Test on the real engine.go file to verify type filtering works
 	engine, err := New()
@@ -4,17 +4,45 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
 )
 
 const (
 	errorHandlingMutatorName = "error_handling"
 	errorNilifyType          = "error_nilify"
+	errorSentinelType        = "error_sentinel"
+	errorWrapVerbType        = "error_wrap_verb"
+	errorIsNegateType        = "error_is_negate"
+	errorCheckRemovalType    = "error_check_removal"
 	errIdentName             = "err"
 	nilIdentName             = "nil"
+
+	// errorSentinelExpr is the non-nil error injected in place of nil.
+	errorSentinelExpr    = `errors.New("gomu: injected error")`
+	errorSentinelMessage = "gomu: injected error"
+	errorCheckRemoved    = "<removed>"
 )
 
-// ErrorHandlingMutator mutates error return values by replacing err with nil.
+// ErrorHandlingMutator mutates error paths.
+//
+// With type information, any expression of type error is considered, not
+// only identifiers named err:
+//   - returned non-nil errors are replaced with nil (error_nilify)
+//   - returned nil errors are replaced with a sentinel error (error_sentinel)
+//   - %w in fmt.Errorf format strings is replaced with %v (error_wrap_verb)
+//   - errors.Is and errors.As calls are negated (error_is_negate)
+//   - `if err != nil { ...; return ... }` early returns are removed
+//     (error_check_removal)
+//
+// Without type information it falls back to identifiers named err and to
+// the syntactic fmt/errors package selectors.
 type ErrorHandlingMutator struct {
+	info *types.Info
+	// results maps each return statement to the result types of its
+	// enclosing function.
+	results map[*ast.ReturnStmt]*types.Tuple
 }
 
 // Name returns the name of the mutator.
@@ -22,45 +50,170 @@ func (m *ErrorHandlingMutator) Name() string {
 	return errorHandlingMutatorName
 }
 
-// CanMutate returns true if the node is a return statement containing an err identifier.
-func (m *ErrorHandlingMutator) CanMutate(node ast.Node) bool {
-	stmt, ok := node.(*ast.ReturnStmt)
-	if !ok {
-		return false
+// Prepare records the type information of the file about to be mutated.
+func (m *ErrorHandlingMutator) Prepare(file *ast.File, info *types.Info) {
+	m.info = info
+	m.results = nil
+
+	if info == nil || file == nil {
+		return
 	}
 
-	for _, expr := range stmt.Results {
-		if isErrIdent(expr) {
-			return true
+	m.results = make(map[*ast.ReturnStmt]*types.Tuple)
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		var (
+			sig  *types.Signature
+			body *ast.BlockStmt
+		)
+
+		switch fn := node.(type) {
+		case *ast.FuncDecl:
+			if obj, ok := info.Defs[fn.Name].(*types.Func); ok {
+				sig, _ = obj.Type().(*types.Signature)
+			}
+
+			body = fn.Body
+		case *ast.FuncLit:
+			sig, _ = info.Types[fn].Type.(*types.Signature)
+			body = fn.Body
 		}
-	}
 
-	return false
+		if sig != nil && body != nil {
+			m.recordReturns(body, sig.Results())
+		}
+
+		return true
+	})
+}
+
+// recordReturns maps the return statements directly inside body (not inside
+// nested function literals) to results.
+func (m *ErrorHandlingMutator) recordReturns(body *ast.BlockStmt, results *types.Tuple) {
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			m.results[n] = results
+		}
+
+		return true
+	})
+}
+
+// CanMutate returns true if the node is an error path this mutator handles.
+func (m *ErrorHandlingMutator) CanMutate(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.ReturnStmt:
+		for i, expr := range n.Results {
+			if m.isReturnedError(n, i, expr) || m.isReturnedNilError(n, i, expr) {
+				return true
+			}
+		}
+
+		return false
+	case *ast.CallExpr:
+		return m.wrapVerbLiteral(n) != nil || m.isErrorsPredicate(n)
+	case *ast.IfStmt:
+		return m.isErrorCheck(n)
+	default:
+		return false
+	}
 }
 
 // Mutate generates mutants for the given node.
 func (m *ErrorHandlingMutator) Mutate(node ast.Node, fset *token.FileSet) []Mutant {
-	stmt, ok := node.(*ast.ReturnStmt)
-	if !ok {
+	switch n := node.(type) {
+	case *ast.ReturnStmt:
+		return m.mutateReturn(n, fset)
+	case *ast.CallExpr:
+		return m.mutateCall(n, fset)
+	case *ast.IfStmt:
+		if !m.isErrorCheck(n) {
+			return nil
+		}
+
+		pos := fset.Position(n.Pos())
+
+		return []Mutant{{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        errorCheckRemovalType,
+			Original:    errorCheckString(n),
+			Mutated:     errorCheckRemoved,
+			Description: "Remove error check early return",
+		}}
+	default:
 		return nil
 	}
+}
 
+// mutateReturn generates nilify and sentinel mutants for a return statement.
+func (m *ErrorHandlingMutator) mutateReturn(stmt *ast.ReturnStmt, fset *token.FileSet) []Mutant {
 	pos := fset.Position(stmt.Pos())
 	mutants := make([]Mutant, 0, len(stmt.Results))
 
-	for _, expr := range stmt.Results {
-		ident, ok := expr.(*ast.Ident)
-		if !ok || ident.Name != errIdentName {
-			continue
+	for i, expr := range stmt.Results {
+		switch {
+		case m.isReturnedError(stmt, i, expr):
+			original := exprToString(expr)
+
+			mutants = append(mutants, Mutant{
+				Line:        pos.Line,
+				Column:      pos.Column,
+				Type:        errorNilifyType,
+				Original:    original,
+				Mutated:     nilIdentName,
+				Description: fmt.Sprintf("Replace return %s with return nil", original),
+			})
+		case m.isReturnedNilError(stmt, i, expr):
+			// The nil identifier itself is the target so that several nil
+			// results in one statement stay distinguishable.
+			nilPos := fset.Position(expr.Pos())
+
+			mutants = append(mutants, Mutant{
+				Line:        nilPos.Line,
+				Column:      nilPos.Column,
+				Type:        errorSentinelType,
+				Original:    nilIdentName,
+				Mutated:     errorSentinelExpr,
+				Description: "Replace returned nil error with a non-nil error",
+			})
 		}
+	}
+
+	return mutants
+}
+
+// mutateCall generates wrap verb and errors.Is/As negation mutants.
+func (m *ErrorHandlingMutator) mutateCall(call *ast.CallExpr, fset *token.FileSet) []Mutant {
+	var mutants []Mutant
+
+	if lit := m.wrapVerbLiteral(call); lit != nil {
+		pos := fset.Position(lit.Pos())
+
+		mutants = append(mutants, Mutant{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        errorWrapVerbType,
+			Original:    lit.Value,
+			Mutated:     strings.ReplaceAll(lit.Value, "%w", "%v"),
+			Description: "Replace %w with %v in fmt.Errorf (drop error wrapping)",
+		})
+	}
+
+	if m.isErrorsPredicate(call) {
+		pos := fset.Position(call.Pos())
+		original := exprToString(call)
 
 		mutants = append(mutants, Mutant{
 			Line:        pos.Line,
 			Column:      pos.Column,
-			Type:        errorNilifyType,
-			Original:    ident.Name,
-			Mutated:     nilIdentName,
-			Description: fmt.Sprintf("Replace return %s with return nil", ident.Name),
+			Type:        errorIsNegateType,
+			Original:    original,
+			Mutated:     "!" + original,
+			Description: fmt.Sprintf("Negate %s", original),
 		})
 	}
 
@@ -69,31 +222,224 @@ func (m *ErrorHandlingMutator) Mutate(node ast.Node, fset *token.FileSet) []Muta
 
 // Apply applies the mutation to the given AST node.
 func (m *ErrorHandlingMutator) Apply(node ast.Node, mutant Mutant) bool {
-	stmt, ok := node.(*ast.ReturnStmt)
-	if !ok {
+	switch mutant.Type {
+	case errorNilifyType:
+		stmt, ok := node.(*ast.ReturnStmt)
+		if !ok {
+			return false
+		}
+
+		for i, expr := range stmt.Results {
+			if exprToString(expr) != mutant.Original {
+				continue
+			}
+
+			stmt.Results[i] = &ast.Ident{Name: nilIdentName}
+
+			return true
+		}
+
 		return false
-	}
+	case errorWrapVerbType:
+		lit, ok := node.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING || lit.Value != mutant.Original {
+			return false
+		}
+
+		lit.Value = mutant.Mutated
 
-	if mutant.Type != errorNilifyType {
+		return true
+	default:
 		return false
 	}
+}
 
-	for i, expr := range stmt.Results {
-		ident, ok := expr.(*ast.Ident)
-		if !ok {
-			continue
+// ApplyWithCursor applies mutations that replace the node itself.
+func (m *ErrorHandlingMutator) ApplyWithCursor(node ast.Node, replaceFunc func(ast.Node), mutant Mutant) bool {
+	switch mutant.Type {
+	case errorSentinelType:
+		ident, ok := node.(*ast.Ident)
+		if !ok || ident.Name != nilIdentName {
+			return false
 		}
 
-		if ident.Name != mutant.Original {
-			continue
+		// The call takes the position of nil, so that the printer does not
+		// move the comments following the statement into its arguments.
+		pos := ident.Pos()
+
+		replaceFunc(&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   &ast.Ident{NamePos: pos, Name: "errors"},
+				Sel: &ast.Ident{NamePos: pos, Name: "New"},
+			},
+			Lparen: pos,
+			Args: []ast.Expr{&ast.BasicLit{
+				ValuePos: pos,
+				Kind:     token.STRING,
+				Value:    strconv.Quote(errorSentinelMessage),
+			}},
+			Rparen: pos,
+		})
+
+		return true
+	case errorIsNegateType:
+		call, ok := node.(*ast.CallExpr)
+		if !ok || exprToString(call) != mutant.Original {
+			return false
+		}
+
+		replaceFunc(&ast.UnaryExpr{Op: token.NOT, X: call})
+
+		return true
+	case errorCheckRemovalType:
+		stmt, ok := node.(*ast.IfStmt)
+		if !ok || stmt.Init != nil || errorCheckString(stmt) != mutant.Original {
+			return false
 		}
 
-		stmt.Results[i] = &ast.Ident{Name: nilIdentName}
+		replaceFunc(&ast.EmptyStmt{})
 
 		return true
+	default:
+		return false
+	}
+}
+
+// RequiredImports returns the packages referenced by the mutated code.
+func (m *ErrorHandlingMutator) RequiredImports(mutant Mutant) []string {
+	if mutant.Type == errorSentinelType {
+		return []string{"errors"}
+	}
+
+	return nil
+}
+
+// isReturnedError reports whether the i-th result of stmt is a non-nil
+// expression returned as an error.
+func (m *ErrorHandlingMutator) isReturnedError(stmt *ast.ReturnStmt, i int, expr ast.Expr) bool {
+	if isNilIdent(expr) {
+		return false
 	}
 
-	return false
+	if results, ok := m.results[stmt]; ok {
+		return isErrorResult(results, len(stmt.Results), i)
+	}
+
+	return m.info == nil && isErrIdent(expr)
+}
+
+// isReturnedNilError reports whether the i-th result of stmt is nil returned
+// as an error. This requires type information.
+func (m *ErrorHandlingMutator) isReturnedNilError(stmt *ast.ReturnStmt, i int, expr ast.Expr) bool {
+	if !isNilIdent(expr) {
+		return false
+	}
+
+	results, ok := m.results[stmt]
+
+	return ok && isErrorResult(results, len(stmt.Results), i)
+}
+
+// wrapVerbLiteral returns the format string literal of a fmt.Errorf call if it
+// contains the %w verb.
+func (m *ErrorHandlingMutator) wrapVerbLiteral(call *ast.CallExpr) *ast.BasicLit {
+	if !m.isPackageFunc(call, "fmt", "Errorf") || len(call.Args) == 0 {
+		return nil
+	}
+
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING || !strings.Contains(lit.Value, "%w") {
+		return nil
+	}
+
+	return lit
+}
+
+// isErrorsPredicate reports whether call is errors.Is or errors.As.
+func (m *ErrorHandlingMutator) isErrorsPredicate(call *ast.CallExpr) bool {
+	return m.isPackageFunc(call, "errors", "Is") || m.isPackageFunc(call, "errors", "As")
+}
+
+// isPackageFunc reports whether call calls the function name of the package
+// with the given import path.
+func (m *ErrorHandlingMutator) isPackageFunc(call *ast.CallExpr, pkgPath, name string) bool {
+	return isPackageFuncCall(m.info, call, pkgPath, name)
+}
+
+// isPackageFuncCall reports whether call calls the function name of the
+// package with the given import path. Without type information the package
+// is identified by its conventional name.
+func isPackageFuncCall(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+
+	if info != nil {
+		if fn, ok := info.Uses[sel.Sel].(*types.Func); ok {
+			return fn.Pkg() != nil && fn.Pkg().Path() == pkgPath
+		}
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+
+	return ok && ident.Name == pkgPath
+}
+
+// isErrorCheck reports whether stmt is `if <error> != nil { ...; return ... }`
+// without init statement or else branch.
+func (m *ErrorHandlingMutator) isErrorCheck(stmt *ast.IfStmt) bool {
+	if stmt.Init != nil || stmt.Else != nil || len(stmt.Body.List) == 0 {
+		return false
+	}
+
+	if _, ok := stmt.Body.List[len(stmt.Body.List)-1].(*ast.ReturnStmt); !ok {
+		return false
+	}
+
+	cond, ok := stmt.Cond.(*ast.BinaryExpr)
+	if !ok || cond.Op != token.NEQ {
+		return false
+	}
+
+	var checked ast.Expr
+
+	switch {
+	case isNilIdent(cond.Y):
+		checked = cond.X
+	case isNilIdent(cond.X):
+		checked = cond.Y
+	default:
+		return false
+	}
+
+	if m.info != nil {
+		if tv, ok := m.info.Types[checked]; ok && tv.Type != nil {
+			return isErrorType(tv.Type)
+		}
+	}
+
+	return isErrIdent(checked)
+}
+
+// errorCheckString renders the condition of an error check for reports.
+func errorCheckString(stmt *ast.IfStmt) string {
+	return "if " + exprToString(stmt.Cond) + " {...}"
+}
+
+// isErrorResult reports whether the i-th of n returned values is of type
+// error. Returns of a multi-value call (n != results.Len()) are skipped.
+func isErrorResult(results *types.Tuple, n, i int) bool {
+	if results == nil || results.Len() != n {
+		return false
+	}
+
+	return isErrorType(results.At(i).Type())
+}
+
+// isErrorType reports whether t is the predeclared error type.
+func isErrorType(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
 }
 
 // isErrIdent reports whether expr is an identifier named "err".
@@ -102,3 +448,10 @@ func isErrIdent(expr ast.Expr) bool {
 
 	return ok && ident.Name == errIdentName
 }
+
+// isNilIdent reports whether expr is the nil identifier.
+func isNilIdent(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+
+	return ok && ident.Name == nilIdentName
+}
@@ -2,9 +2,14 @@ package mutation
 
 import (
 	"go/ast"
+	"go/importer"
 	"go/parser"
 	"go/token"
+	"go/types"
+	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestErrorHandlingMutator_Name(t *testing.T) {
@@ -225,3 +230,256 @@ func TestErrorHandlingMutator_Apply_NonReturnStmt(t *testing.T) {
 		t.Error("Apply() = true, want false for non-ReturnStmt node")
 	}
 }
+
+// mutateTypeChecked type checks src, prepares the mutator with the result if
+// it is type aware and returns all mutants it generates as
+// "type: original -> mutated".
+func mutateTypeChecked(t *testing.T, mutator Mutator, src string) []string {
+	t.Helper()
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "test.go", src, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+
+	config := &types.Config{Importer: importer.Default()}
+	if _, err := config.Check("test", fset, []*ast.File{file}, info); err != nil {
+		t.Fatalf("Failed to type check: %v", err)
+	}
+
+	if tam, ok := mutator.(TypeAwareMutator); ok {
+		tam.Prepare(file, info)
+	}
+
+	var got []string
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n != nil && mutator.CanMutate(n) {
+			for _, m := range mutator.Mutate(n, fset) {
+				got = append(got, m.Type+": "+m.Original+" -> "+m.Mutated)
+			}
+		}
+
+		return true
+	})
+
+	return got
+}
+
+func TestErrorHandlingMutator_TypeAware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "any returned error expression is nilified",
+			src: `package main
+import "fmt"
+func f(n int) (int, error) { return 0, fmt.Errorf("bad %d", n) }`,
+			want: []string{
+				`error_nilify: fmt.Errorf("bad %d", n) -> nil`,
+			},
+		},
+		{
+			name: "error values not named err",
+			src: `package main
+type myErr struct{}
+func (*myErr) Error() string { return "" }
+func f() error { wrapErr := &myErr{}; return wrapErr }`,
+			want: []string{
+				"error_nilify: wrapErr -> nil",
+			},
+		},
+		{
+			name: "nil error replaced with sentinel, nil pointer left alone",
+			src: `package main
+func f() (*int, error) { return nil, nil }`,
+			want: []string{
+				`error_sentinel: nil -> errors.New("gomu: injected error")`,
+			},
+		},
+		{
+			name: "non-error results are ignored",
+			src: `package main
+func f() (int, string) { err := "x"; return 0, err }`,
+			want: nil,
+		},
+		{
+			name: "wrap verb dropped",
+			src: `package main
+import "fmt"
+func f(err error) error { return fmt.Errorf("op: %w", err) }`,
+			want: []string{
+				`error_nilify: fmt.Errorf("op: %w", err) -> nil`,
+				`error_wrap_verb: "op: %w" -> "op: %v"`,
+			},
+		},
+		{
+			name: "errors.Is and errors.As negated",
+			src: `package main
+import "errors"
+var target *error
+func f(err, other error) bool { return errors.Is(err, other) || errors.As(err, target) }`,
+			want: []string{
+				"error_is_negate: errors.Is(err, other) -> !errors.Is(err, other)",
+				"error_is_negate: errors.As(err, target) -> !errors.As(err, target)",
+			},
+		},
+		{
+			name: "error check early return removed",
+			src: `package main
+func g() error { return nil }
+func f() int {
+	failure := g()
+	if failure != nil {
+		return 1
+	}
+	return 0
+}`,
+			want: []string{
+				`error_sentinel: nil -> errors.New("gomu: injected error")`,
+				"error_check_removal: if failure != nil {...} -> <removed>",
+			},
+		},
+		{
+			name: "checks with init statement or non-error operand are skipped",
+			src: `package main
+func g() error { return nil }
+func f(p *int) int {
+	if err := g(); err != nil {
+		return 1
+	}
+	if p != nil {
+		return 2
+	}
+	return 0
+}`,
+			want: []string{
+				`error_sentinel: nil -> errors.New("gomu: injected error")`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := mutateTypeChecked(t, &ErrorHandlingMutator{}, tt.src)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mutants mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestErrorHandlingMutator_ApplyWithCursor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		node   ast.Node
+		mutant Mutant
+		want   string
+	}{
+		{
+			name:   "sentinel replaces nil",
+			node:   ast.NewIdent("nil"),
+			mutant: Mutant{Type: errorSentinelType, Original: "nil", Mutated: errorSentinelExpr},
+			want:   errorSentinelExpr,
+		},
+		{
+			name: "negate errors.Is",
+			node: &ast.CallExpr{
+				Fun:  &ast.SelectorExpr{X: ast.NewIdent("errors"), Sel: ast.NewIdent("Is")},
+				Args: []ast.Expr{ast.NewIdent("err"), ast.NewIdent("target")},
+			},
+			mutant: Mutant{Type: errorIsNegateType, Original: "errors.Is(err, target)"},
+			want:   "!errors.Is(err, target)",
+		},
+		{
+			name:   "sentinel ignores other identifiers",
+			node:   ast.NewIdent("err"),
+			mutant: Mutant{Type: errorSentinelType, Original: "nil", Mutated: errorSentinelExpr},
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+
+			applied := (&ErrorHandlingMutator{}).ApplyWithCursor(tt.node, func(n ast.Node) {
+				if expr, ok := n.(ast.Expr); ok {
+					got = exprToString(expr)
+				}
+			}, tt.mutant)
+
+			if applied != (tt.want != "") {
+				t.Errorf("ApplyWithCursor() = %v, want %v", applied, tt.want != "")
+			}
+
+			if got != tt.want {
+				t.Errorf("replacement = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorHandlingMutator_SentinelKeepsComments(t *testing.T) {
+	t.Parallel()
+
+	const src = `package main
+
+type file struct {
+	name string
+}
+
+func open(name string) (*file, error) {
+	return &file{
+		name: name,
+	}, nil
+}
+
+// close is documented.
+func close() {}
+`
+
+	mutant := Mutant{Type: errorSentinelType, Line: 10, Column: 5, Original: "nil", Mutated: errorSentinelExpr}
+
+	got, err := MutateSource("main.go", []byte(src), mutant, []Mutator{&ErrorHandlingMutator{}})
+	if err != nil {
+		t.Fatalf("MutateSource() error = %v", err)
+	}
+
+	want := "}, errors.New(\"gomu: injected error\")\n}\n\n// close is documented."
+	if !strings.Contains(string(got), want) {
+		t.Errorf("expected output to contain %q, got:\n%s", want, got)
+	}
+}
+
+func TestErrorHandlingMutator_RequiredImports(t *testing.T) {
+	t.Parallel()
+
+	mutator := &ErrorHandlingMutator{}
+
+	if diff := cmp.Diff([]string{"errors"}, mutator.RequiredImports(Mutant{Type: errorSentinelType})); diff != "" {
+		t.Errorf("RequiredImports() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := mutator.RequiredImports(Mutant{Type: errorNilifyType}); got != nil {
+		t.Errorf("RequiredImports() = %v, want nil", got)
+	}
+}
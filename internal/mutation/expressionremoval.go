@@ -15,6 +15,8 @@ const (
 // ExpressionRemovalMutator removes expression statements (e.g. function calls
 // evaluated for their side effects), testing whether those side effects are
 // properly covered by tests.
+//
+// Calls to panic are removed by the defer_panic mutator instead.
 type ExpressionRemovalMutator struct {
 }
 
@@ -25,15 +27,15 @@ func (m *ExpressionRemovalMutator) Name() string {
 
 // CanMutate returns true if the node can be mutated by this mutator.
 func (m *ExpressionRemovalMutator) CanMutate(node ast.Node) bool {
-	_, ok := node.(*ast.ExprStmt)
+	stmt, ok := node.(*ast.ExprStmt)
 
-	return ok
+	return ok && !isPanicCall(stmt.X)
 }
 
 // Mutate generates mutants for the given node.
 func (m *ExpressionRemovalMutator) Mutate(node ast.Node, fset *token.FileSet) []Mutant {
 	stmt, ok := node.(*ast.ExprStmt)
-	if !ok {
+	if !ok || isPanicCall(stmt.X) {
 		return nil
 	}
 
@@ -73,3 +75,15 @@ func (m *ExpressionRemovalMutator) ApplyWithCursor(node ast.Node, replaceFunc fu
 
 	return true
 }
+
+// isPanicCall reports whether expr calls panic.
+func isPanicCall(expr ast.Expr) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+
+	ident, ok := call.Fun.(*ast.Ident)
+
+	return ok && ident.Name == "panic"
+}
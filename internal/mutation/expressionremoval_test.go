@@ -58,6 +58,20 @@ func TestExpressionRemovalMutator_CanMutate(t *testing.T) {
 	}
 }
 
+func TestExpressionRemovalMutator_CanMutate_Panic(t *testing.T) {
+	t.Parallel()
+
+	mutator := &ExpressionRemovalMutator{}
+
+	// Calls to panic are removed by the defer_panic mutator.
+	fset := token.NewFileSet()
+	stmt := parseExprStmt(t, fset, `panic("unreachable")`)
+
+	if mutator.CanMutate(stmt) {
+		t.Error("CanMutate() = true, want false for panic call")
+	}
+}
+
 func TestExpressionRemovalMutator_CanMutate_NonExprStmt(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,272 @@
+package mutation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+const (
+	genericsMutatorName      = "generics"
+	cmpArgsSwapType          = "cmp_args_swap"
+	typeParamZeroCompareType = "type_param_zero_compare"
+	typeParamZeroReturnType  = "type_param_zero_return"
+
+	cmpPackagePath           = "cmp"
+	typeParamZeroValuePrefix = "*new("
+)
+
+// GenericsMutator mutates code written against type parameters:
+//   - the operands of cmp.Less and cmp.Compare are swapped, reversing the
+//     order they implement (cmp_args_swap)
+//   - an equality check between values of a type parameter compares against
+//     the zero value instead: `a == b` → `a == *new(T)`
+//     (type_param_zero_compare)
+//   - a returned value of a type parameter is replaced with its zero value:
+//     `return v` → `return *new(T)` (type_param_zero_return)
+//
+// Type parameters are only known from type information, so without it only
+// cmp calls are mutated. Operators on type parameters generated by other
+// mutators are checked against the constraint's type set by TypeChecker.
+type GenericsMutator struct {
+	info *types.Info
+}
+
+// Name returns the name of the mutator.
+func (m *GenericsMutator) Name() string {
+	return genericsMutatorName
+}
+
+// Prepare records the type information of the file about to be mutated.
+func (m *GenericsMutator) Prepare(_ *ast.File, info *types.Info) {
+	m.info = info
+}
+
+// CanMutate returns true if the node can be mutated by this mutator.
+func (m *GenericsMutator) CanMutate(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.CallExpr:
+		return m.isCmpCall(n)
+	case *ast.BinaryExpr:
+		return m.zeroCompareTypeParam(n) != nil
+	case *ast.ReturnStmt:
+		for _, result := range n.Results {
+			if m.zeroReturnTypeParam(result) != nil {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return false
+	}
+}
+
+// Mutate generates mutants for the given node.
+func (m *GenericsMutator) Mutate(node ast.Node, fset *token.FileSet) []Mutant {
+	switch n := node.(type) {
+	case *ast.CallExpr:
+		if !m.isCmpCall(n) {
+			return nil
+		}
+
+		pos := fset.Position(n.Pos())
+		original := exprToString(n)
+		mutated := fmt.Sprintf("%s(%s, %s)", exprToString(n.Fun), exprToString(n.Args[1]), exprToString(n.Args[0]))
+
+		return []Mutant{{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        cmpArgsSwapType,
+			Original:    original,
+			Mutated:     mutated,
+			Description: fmt.Sprintf("Swap the operands of %s", original),
+		}}
+	case *ast.BinaryExpr:
+		tp := m.zeroCompareTypeParam(n)
+		if tp == nil {
+			return nil
+		}
+
+		pos := fset.Position(n.Pos())
+		original := exprToString(n)
+		mutated := fmt.Sprintf("%s %s %s", exprToString(n.X), n.Op, typeParamZeroValue(tp))
+
+		return []Mutant{{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        typeParamZeroCompareType,
+			Original:    original,
+			Mutated:     mutated,
+			Description: fmt.Sprintf("Compare %s with the zero value of %s", exprToString(n.X), tp.Obj().Name()),
+		}}
+	case *ast.ReturnStmt:
+		var mutants []Mutant
+
+		for _, result := range n.Results {
+			tp := m.zeroReturnTypeParam(result)
+			if tp == nil {
+				continue
+			}
+
+			pos := fset.Position(result.Pos())
+			original := exprToString(result)
+			mutated := typeParamZeroValue(tp)
+
+			mutants = append(mutants, Mutant{
+				Line:        pos.Line,
+				Column:      pos.Column,
+				Type:        typeParamZeroReturnType,
+				Original:    original,
+				Mutated:     mutated,
+				Description: fmt.Sprintf("Return the zero value of %s instead of %s", tp.Obj().Name(), original),
+			})
+		}
+
+		return mutants
+	default:
+		return nil
+	}
+}
+
+// Apply swaps the operands of a cmp call or compares against the zero value.
+func (m *GenericsMutator) Apply(node ast.Node, mutant Mutant) bool {
+	switch mutant.Type {
+	case cmpArgsSwapType:
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 || exprToString(call) != mutant.Original {
+			return false
+		}
+
+		call.Args[0], call.Args[1] = call.Args[1], call.Args[0]
+
+		return true
+	case typeParamZeroCompareType:
+		expr, ok := node.(*ast.BinaryExpr)
+		if !ok || exprToString(expr) != mutant.Original {
+			return false
+		}
+
+		zero, ok := parseTypeParamZeroValue(mutant.Mutated)
+		if !ok {
+			return false
+		}
+
+		expr.Y = zero
+
+		return true
+	default:
+		return false
+	}
+}
+
+// ApplyWithCursor replaces a returned value with the zero value.
+func (m *GenericsMutator) ApplyWithCursor(node ast.Node, replaceFunc func(ast.Node), mutant Mutant) bool {
+	if mutant.Type != typeParamZeroReturnType {
+		return false
+	}
+
+	expr, ok := node.(ast.Expr)
+	if !ok || exprToString(expr) != mutant.Original {
+		return false
+	}
+
+	zero, ok := parseTypeParamZeroValue(mutant.Mutated)
+	if !ok {
+		return false
+	}
+
+	replaceFunc(zero)
+
+	return true
+}
+
+// isCmpCall reports whether call is cmp.Less or cmp.Compare with operands
+// that differ, so that swapping them changes the program.
+func (m *GenericsMutator) isCmpCall(call *ast.CallExpr) bool {
+	if len(call.Args) != 2 || exprToString(call.Args[0]) == exprToString(call.Args[1]) {
+		return false
+	}
+
+	return isPackageFuncCall(m.info, call, cmpPackagePath, "Less") ||
+		isPackageFuncCall(m.info, call, cmpPackagePath, "Compare")
+}
+
+// zeroCompareTypeParam returns the type parameter of an equality check
+// between values of that type parameter, unless the check is already
+// against the zero value.
+func (m *GenericsMutator) zeroCompareTypeParam(expr *ast.BinaryExpr) *types.TypeParam {
+	if expr.Op != token.EQL && expr.Op != token.NEQ {
+		return nil
+	}
+
+	tp := m.typeParamOf(expr.X)
+	if tp == nil || m.typeParamOf(expr.Y) != tp {
+		return nil
+	}
+
+	if exprToString(expr.Y) == typeParamZeroValue(tp) {
+		return nil
+	}
+
+	return tp
+}
+
+// zeroReturnTypeParam returns the type parameter of a returned value,
+// unless the value already is the zero value.
+func (m *GenericsMutator) zeroReturnTypeParam(expr ast.Expr) *types.TypeParam {
+	tp := m.typeParamOf(expr)
+	if tp == nil || exprToString(expr) == typeParamZeroValue(tp) {
+		return nil
+	}
+
+	return tp
+}
+
+// typeParamOf returns the type parameter that is the type of expr, or nil.
+func (m *GenericsMutator) typeParamOf(expr ast.Expr) *types.TypeParam {
+	if m.info == nil {
+		return nil
+	}
+
+	tv, ok := m.info.Types[expr]
+	if !ok || tv.Type == nil {
+		return nil
+	}
+
+	tp, _ := types.Unalias(tv.Type).(*types.TypeParam)
+
+	return tp
+}
+
+// typeParamZeroValue renders the zero value of a type parameter.
+func typeParamZeroValue(tp *types.TypeParam) string {
+	return typeParamZeroValuePrefix + tp.Obj().Name() + ")"
+}
+
+// parseTypeParamZeroValue builds the `*new(T)` expression that ends a
+// mutated expression.
+func parseTypeParamZeroValue(mutated string) (ast.Expr, bool) {
+	if i := strings.LastIndex(mutated, typeParamZeroValuePrefix); i >= 0 {
+		mutated = mutated[i:]
+	}
+
+	name, ok := strings.CutPrefix(mutated, typeParamZeroValuePrefix)
+	if !ok {
+		return nil, false
+	}
+
+	name, ok = strings.CutSuffix(name, ")")
+	if !ok || !token.IsIdentifier(name) {
+		return nil, false
+	}
+
+	return &ast.StarExpr{
+		X: &ast.CallExpr{
+			Fun:  ast.NewIdent("new"),
+			Args: []ast.Expr{ast.NewIdent(name)},
+		},
+	}, true
+}
@@ -0,0 +1,171 @@
+package mutation
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenericsMutator_Name(t *testing.T) {
+	t.Parallel()
+
+	mutator := &GenericsMutator{}
+
+	if mutator.Name() != genericsMutatorName {
+		t.Errorf("Name() = %q, want %q", mutator.Name(), genericsMutatorName)
+	}
+}
+
+func TestGenericsMutator_TypeAware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "cmp calls",
+			src: `package main
+import "cmp"
+func f[T cmp.Ordered](a, b T) (bool, int, bool) { return cmp.Less(a, b), cmp.Compare(b, a), cmp.Less(a, a) }`,
+			want: []string{
+				"cmp_args_swap: cmp.Less(a, b) -> cmp.Less(b, a)",
+				"cmp_args_swap: cmp.Compare(b, a) -> cmp.Compare(a, b)",
+			},
+		},
+		{
+			name: "comparable equality",
+			src: `package main
+func f[T comparable](a, b T) bool { return a == b || a != *new(T) }`,
+			want: []string{"type_param_zero_compare: a == b -> a == *new(T)"},
+		},
+		{
+			name: "returned type parameter values",
+			src: `package main
+func f[K comparable, V any](m map[K]V, k K) (V, K) {
+	if v, ok := m[k]; ok {
+		return v, k
+	}
+	return *new(V), k
+}`,
+			want: []string{
+				"type_param_zero_return: v -> *new(V)",
+				"type_param_zero_return: k -> *new(K)",
+				"type_param_zero_return: k -> *new(K)",
+			},
+		},
+		{
+			name: "receiver type parameters",
+			src: `package main
+type stack[E any] struct{ items []E }
+func (s *stack[E]) peek() E { return s.items[len(s.items)-1] }`,
+			want: []string{"type_param_zero_return: s.items[len(s.items)-1] -> *new(E)"},
+		},
+		{
+			name: "non-generic code is skipped",
+			src: `package main
+func f(a, b int) (bool, int) { return a == b, a }`,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := mutateTypeChecked(t, &GenericsMutator{}, tt.src)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mutants mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenericsMutator_ApplyMutant(t *testing.T) {
+	t.Parallel()
+
+	const src = `package main
+
+import "cmp"
+
+func Index[T comparable](s []T, v T) int {
+	for i, e := range s {
+		if e == v {
+			return i
+		}
+	}
+	return -1
+}
+
+func Max[T cmp.Ordered](a, b T) T {
+	if cmp.Less(a, b) {
+		return b
+	}
+	return a
+}
+`
+
+	tests := []struct {
+		name   string
+		mutant Mutant
+		want   string
+	}{
+		{
+			name: "cmp operands swapped",
+			mutant: Mutant{
+				Type: cmpArgsSwapType, Line: 15, Column: 5,
+				Original: "cmp.Less(a, b)", Mutated: "cmp.Less(b, a)",
+			},
+			want: "if cmp.Less(b, a) {",
+		},
+		{
+			name: "equality against zero value",
+			mutant: Mutant{
+				Type: typeParamZeroCompareType, Line: 7, Column: 6,
+				Original: "e == v", Mutated: "e == *new(T)",
+			},
+			want: "if e == *new(T) {",
+		},
+		{
+			name: "zero value returned",
+			mutant: Mutant{
+				Type: typeParamZeroReturnType, Line: 16, Column: 10,
+				Original: "b", Mutated: "*new(T)",
+			},
+			want: "return *new(T)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+
+			file, err := parser.ParseFile(fset, "generic.go", src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("failed to parse source: %v", err)
+			}
+
+			if !ApplyMutant(fset, file, tt.mutant, []Mutator{&GenericsMutator{}}) {
+				t.Fatal("ApplyMutant() = false, want true")
+			}
+
+			var buf bytes.Buffer
+			if err := format.Node(&buf, fset, file); err != nil {
+				t.Fatalf("failed to format file: %v", err)
+			}
+
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("expected output to contain %q, got:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package mutation
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+const higherOrderType = "higher_order"
+
+// maxEnumerated is the largest number of combinations in a file that are all
+// enumerated to sample from. Larger numbers are sampled by drawing random
+// combinations, since enumerating them grows exponentially with the order.
+const maxEnumerated = 10000
+
+// sampleDraws is the number of random combinations drawn per higher-order
+// mutant to sample when combinations are not enumerated.
+const sampleDraws = 10
+
+// higherOrderSeed seeds the sampling of higher-order mutants, so that the same
+// source always yields the same mutants.
+const higherOrderSeed = 0x676f6d75
+
+// combine returns up to e.orderLimit higher-order mutants, each combining
+// e.order of mutants from the same function that mutate disjoint nodes.
+// functions maps the offsets of the mutated nodes to the declarations of the
+// functions containing them, so that functions of the same name, e.g. init,
+// are not combined.
+// Combinations are sampled with a fixed seed, uniformly if there are few
+// enough to enumerate. Combinations that do not type check are counted as
+// filtered.
+func (e *Engine) combine(mutants []Mutant, functions map[int]*ast.FuncDecl, viability *ViabilityChecker) []Mutant {
+	if e.orderLimit <= 0 {
+		return nil
+	}
+
+	var (
+		decls      []*ast.FuncDecl
+		candidates = make(map[*ast.FuncDecl][]Mutant)
+	)
+
+	for _, m := range mutants {
+		decl := functions[m.Offset]
+		if decl == nil || !hasLocator(m) {
+			continue
+		}
+
+		if candidates[decl] == nil {
+			decls = append(decls, decl)
+		}
+
+		candidates[decl] = append(candidates[decl], m)
+	}
+
+	groups := make([][]Mutant, len(decls))
+	weights := make([]float64, len(decls))
+	total := 0.0
+
+	for i, decl := range decls {
+		groups[i] = candidates[decl]
+		weights[i] = binomial(len(groups[i]), e.order)
+		total += weights[i]
+	}
+
+	rng := newHigherOrderRand()
+
+	var sample [][]Mutant
+
+	if total <= maxEnumerated {
+		s := &sampler{
+			limit: e.orderLimit,
+			rng:   rng,
+		}
+
+		for _, group := range groups {
+			combinations(group, e.order, nil, s.offer)
+		}
+
+		sample = s.sample
+	} else {
+		sample = drawCombinations(groups, weights, total, e.order, e.orderLimit, rng)
+	}
+
+	combined := make([]Mutant, 0, len(sample))
+
+	for _, components := range sample {
+		m := higherOrder(components)
+
+		if viability != nil && !viability.IsViable(m) {
+			e.filtered++
+
+			continue
+		}
+
+		combined = append(combined, m)
+	}
+
+	return combined
+}
+
+// combinations calls fn with every combination of order mutants from
+// mutants, in order, whose nodes do not overlap with those of chosen.
+func combinations(mutants []Mutant, order int, chosen []Mutant, fn func([]Mutant)) {
+	if len(chosen) == order {
+		fn(chosen)
+
+		return
+	}
+
+	for i, m := range mutants {
+		if overlapsAny(m, chosen) {
+			continue
+		}
+
+		combinations(mutants[i+1:], order, append(chosen, m), fn)
+	}
+}
+
+// drawCombinations returns up to limit distinct combinations of order
+// mutants from the same group whose nodes do not overlap, drawn at random with
+// groups picked in proportion to weights, their numbers of combinations. It
+// makes at most limit*sampleDraws draws, so it is bounded however many
+// combinations there are. The combinations are ordered by group and position.
+func drawCombinations(groups [][]Mutant, weights []float64, total float64, order, limit int, rng *rand.Rand) [][]Mutant {
+	type draw struct {
+		group   int
+		indices []int
+	}
+
+	var (
+		draws []draw
+		seen  = make(map[string]bool)
+	)
+
+	for range limit * sampleDraws {
+		if len(draws) == limit {
+			break
+		}
+
+		group := pickWeighted(weights, total, rng)
+		if len(groups[group]) < order {
+			continue
+		}
+
+		indices := rng.Perm(len(groups[group]))[:order]
+		slices.Sort(indices)
+
+		key := fmt.Sprint(group, indices)
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		if overlapping(groups[group], indices) {
+			continue
+		}
+
+		draws = append(draws, draw{group: group, indices: indices})
+	}
+
+	slices.SortFunc(draws, func(a, b draw) int {
+		return cmp.Or(cmp.Compare(a.group, b.group), slices.Compare(a.indices, b.indices))
+	})
+
+	sample := make([][]Mutant, len(draws))
+
+	for i, d := range draws {
+		sample[i] = make([]Mutant, len(d.indices))
+		for j, index := range d.indices {
+			sample[i][j] = groups[d.group][index]
+		}
+	}
+
+	return sample
+}
+
+// overlapping reports whether the nodes of any two of the mutants at indices
+// overlap.
+func overlapping(mutants []Mutant, indices []int) bool {
+	chosen := make([]Mutant, 0, len(indices))
+
+	for _, index := range indices {
+		if overlapsAny(mutants[index], chosen) {
+			return true
+		}
+
+		chosen = append(chosen, mutants[index])
+	}
+
+	return false
+}
+
+// pickWeighted returns a random index of weights, picked in proportion to its
+// weight out of total.
+func pickWeighted(weights []float64, total float64, rng *rand.Rand) int {
+	r := rng.Float64() * total
+
+	for i, weight := range weights {
+		if r < weight {
+			return i
+		}
+
+		r -= weight
+	}
+
+	return len(weights) - 1
+}
+
+// binomial returns the number of combinations of k out of n elements, as a
+// float64 since it can be huge.
+func binomial(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+
+	result := 1.0
+	for i := range k {
+		result *= float64(n-i) / float64(i+1)
+	}
+
+	return result
+}
+
+// overlapsAny reports whether the node of mutant overlaps the node of any of
+// mutants.
+func overlapsAny(mutant Mutant, mutants []Mutant) bool {
+	for _, m := range mutants {
+		if mutant.Offset < m.EndOffset && m.Offset < mutant.EndOffset {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newHigherOrderRand returns the source of randomness for sampling
+// higher-order mutants.
+func newHigherOrderRand() *rand.Rand {
+	return rand.New(rand.NewPCG(higherOrderSeed, higherOrderSeed))
+}
+
+// sampler keeps a uniform sample of at most limit of the combinations
+// offered to it (reservoir sampling), in the order they were offered.
+type sampler struct {
+	limit   int
+	rng     *rand.Rand
+	offered int
+	sample  [][]Mutant
+}
+
+func (s *sampler) offer(components []Mutant) {
+	s.offered++
+
+	i := len(s.sample)
+	if i >= s.limit {
+		i = s.rng.IntN(s.offered)
+		if i >= s.limit {
+			return
+		}
+	}
+
+	combination := append([]Mutant(nil), components...)
+
+	if i == len(s.sample) {
+		s.sample = append(s.sample, combination)
+
+		return
+	}
+
+	// Evict the i-th combination and append the new one to keep the sample
+	// in offer order.
+	copy(s.sample[i:], s.sample[i+1:])
+	s.sample[len(s.sample)-1] = combination
+}
+
+// higherOrder returns the higher-order mutant combining components, located
+// at its first component.
+func higherOrder(components []Mutant) Mutant {
+	originals := make([]string, len(components))
+	mutated := make([]string, len(components))
+	descriptions := make([]string, len(components))
+
+	for i, m := range components {
+		originals[i] = m.Original
+		mutated[i] = m.Mutated
+		descriptions[i] = m.Description
+	}
+
+	first := components[0]
+
+	return Mutant{
+		FilePath:    first.FilePath,
+		Line:        first.Line,
+		Column:      first.Column,
+		Type:        higherOrderType,
+		Original:    strings.Join(originals, "; "),
+		Mutated:     strings.Join(mutated, "; "),
+		Description: fmt.Sprintf("Combined %d mutations: %s", len(components), strings.Join(descriptions, "; ")),
+		Function:    first.Function,
+		Components:  components,
+	}
+}
+
+// components returns the first-order mutants mutant consists of.
+func components(mutant Mutant) []Mutant {
+	if len(mutant.Components) > 0 {
+		return mutant.Components
+	}
+
+	return []Mutant{mutant}
+}
+
+// enclosingFunction returns the name of function, e.g. Add or Calc.Add for a
+// method, if node is inside it, or "".
+func enclosingFunction(function *ast.FuncDecl, node ast.Node) string {
+	if function == nil || node.Pos() < function.Pos() || node.End() > function.End() {
+		return ""
+	}
+
+	if function.Recv == nil || len(function.Recv.List) == 0 {
+		return function.Name.Name
+	}
+
+	recv := function.Recv.List[0].Type
+
+	for {
+		switch t := recv.(type) {
+		case *ast.StarExpr:
+			recv = t.X
+		case *ast.IndexExpr:
+			recv = t.X
+		case *ast.IndexListExpr:
+			recv = t.X
+		case *ast.Ident:
+			return t.Name + "." + function.Name.Name
+		default:
+			return function.Name.Name
+		}
+	}
+}
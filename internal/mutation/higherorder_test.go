@@ -0,0 +1,280 @@
+package mutation
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const higherOrderSrc = `package m
+
+type Stack[T any] struct{ items []T }
+
+func (s *Stack[T]) Len() int {
+	return len(s.items) + 0
+}
+
+func Between(x, lo, hi int) bool {
+	return x >= lo && x < hi
+}
+`
+
+func TestGenerateMutants_HigherOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/m\n\ngo 1.21\n"), 0600); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+
+	path := filepath.Join(tmpDir, "m.go")
+	if err := os.WriteFile(path, []byte(higherOrderSrc), 0600); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	engine, err := New(WithHigherOrder(2, 1000))
+	if err != nil {
+		t.Fatalf("Failed to create mutation engine: %v", err)
+	}
+
+	mutants, err := engine.GenerateMutants(path)
+	if err != nil {
+		t.Fatalf("Failed to generate mutants: %v", err)
+	}
+
+	functions := make(map[string]bool)
+	higherOrder := 0
+
+	for _, m := range mutants {
+		functions[m.Function] = true
+
+		if m.Type != higherOrderType {
+			continue
+		}
+
+		higherOrder++
+
+		if len(m.Components) != 2 {
+			t.Fatalf("Mutant %s has %d components, want 2", m.ID, len(m.Components))
+		}
+
+		first, second := m.Components[0], m.Components[1]
+		if first.Function != second.Function {
+			t.Errorf("Mutant %s combines mutants of %s and %s", m.ID, first.Function, second.Function)
+		}
+
+		if overlapsAny(first, m.Components[1:]) {
+			t.Errorf("Mutant %s combines overlapping mutants %s and %s", m.ID, first.Type, second.Type)
+		}
+
+		mutated, err := MutateSource(path, []byte(higherOrderSrc), m, engine.mutators)
+		if err != nil {
+			t.Errorf("MutateSource(%s) error = %v", m.Description, err)
+
+			continue
+		}
+
+		for _, c := range m.Components {
+			single, err := MutateSource(path, []byte(higherOrderSrc), c, engine.mutators)
+			if err == nil && string(single) == string(mutated) {
+				t.Errorf("Mutant %s only applied its %s component", m.ID, c.Type)
+			}
+		}
+	}
+
+	if higherOrder == 0 {
+		t.Fatal("Expected higher-order mutants")
+	}
+
+	if !functions["Stack.Len"] || !functions["Between"] {
+		t.Errorf("Expected mutants in Stack.Len and Between, got functions %v", functions)
+	}
+
+	limited, err := New(WithHigherOrder(2, 3))
+	if err != nil {
+		t.Fatalf("Failed to create mutation engine: %v", err)
+	}
+
+	sampled, err := limited.GenerateMutants(path)
+	if err != nil {
+		t.Fatalf("Failed to generate mutants: %v", err)
+	}
+
+	if got := len(sampled) - (len(mutants) - higherOrder); got != 3 {
+		t.Errorf("Expected 3 sampled higher-order mutants, got %d", got)
+	}
+}
+
+func TestGenerateMutants_HigherOrderSameName(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/m\n\ngo 1.21\n"), 0600); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+
+	src := `package m
+
+var a, b int
+
+func init() {
+	a = b + 1
+	b = a * 2
+}
+
+func init() {
+	b = a - 1
+	a = b / 2
+}
+`
+
+	path := filepath.Join(tmpDir, "m.go")
+	if err := os.WriteFile(path, []byte(src), 0600); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	engine, err := New(WithHigherOrder(2, 1000))
+	if err != nil {
+		t.Fatalf("Failed to create mutation engine: %v", err)
+	}
+
+	mutants, err := engine.GenerateMutants(path)
+	if err != nil {
+		t.Fatalf("Failed to generate mutants: %v", err)
+	}
+
+	higherOrder := 0
+
+	for _, m := range mutants {
+		if m.Type != higherOrderType {
+			continue
+		}
+
+		higherOrder++
+
+		// The first init function ends at line 8.
+		if first, second := m.Components[0], m.Components[1]; (first.Line < 8) != (second.Line < 8) {
+			t.Errorf("Mutant %s combines mutants of both init functions at lines %d and %d", m.ID, first.Line, second.Line)
+		}
+	}
+
+	if higherOrder == 0 {
+		t.Fatal("Expected higher-order mutants")
+	}
+}
+
+func TestSampler(t *testing.T) {
+	t.Parallel()
+
+	run := func() []string {
+		s := &sampler{limit: 5, rng: newHigherOrderRand()}
+
+		for i := range 100 {
+			s.offer([]Mutant{{ID: string(rune('a' + i%26)), Line: i}})
+		}
+
+		ids := make([]string, 0, len(s.sample))
+		last := -1
+
+		for _, components := range s.sample {
+			if components[0].Line <= last {
+				t.Errorf("Sample is not in offer order: %d after %d", components[0].Line, last)
+			}
+
+			last = components[0].Line
+			ids = append(ids, components[0].ID)
+		}
+
+		return ids
+	}
+
+	first := run()
+	if len(first) != 5 {
+		t.Fatalf("Sample has %d combinations, want 5", len(first))
+	}
+
+	if diff := cmp.Diff(first, run()); diff != "" {
+		t.Errorf("Sampling is not reproducible (-first +second):\n%s", diff)
+	}
+}
+
+func TestCombine_ManyCandidates(t *testing.T) {
+	t.Parallel()
+
+	// C(200, 4) is about 64 million combinations, too many to enumerate.
+	mutants := make([]Mutant, 200)
+	for i := range mutants {
+		mutants[i] = Mutant{
+			ID:        string(rune('a'+i%26)) + string(rune('a'+i/26)),
+			Function:  "F",
+			NodeKind:  "*ast.BinaryExpr",
+			Offset:    i * 10,
+			EndOffset: i*10 + 5,
+		}
+	}
+
+	decl := &ast.FuncDecl{Name: ast.NewIdent("F")}
+	functions := make(map[int]*ast.FuncDecl, len(mutants))
+
+	for _, m := range mutants {
+		functions[m.Offset] = decl
+	}
+
+	run := func() []string {
+		e := &Engine{order: 4, orderLimit: 50}
+
+		combined := e.combine(mutants, functions, nil)
+		if len(combined) != 50 {
+			t.Fatalf("combine() returned %d mutants, want 50", len(combined))
+		}
+
+		keys := make([]string, len(combined))
+		seen := make(map[string]bool)
+
+		for i, m := range combined {
+			if len(m.Components) != 4 {
+				t.Fatalf("Mutant has %d components, want 4", len(m.Components))
+			}
+
+			for j := 1; j < len(m.Components); j++ {
+				if overlapsAny(m.Components[j], m.Components[:j]) {
+					t.Errorf("Mutant %s combines overlapping mutants", m.Original)
+				}
+			}
+
+			keys[i] = m.Components[0].ID + m.Components[1].ID + m.Components[2].ID + m.Components[3].ID
+			if seen[keys[i]] {
+				t.Errorf("Combination %s sampled twice", keys[i])
+			}
+
+			seen[keys[i]] = true
+		}
+
+		return keys
+	}
+
+	if diff := cmp.Diff(run(), run()); diff != "" {
+		t.Errorf("Sampling is not reproducible (-first +second):\n%s", diff)
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		n, k int
+		want float64
+	}{
+		{n: 5, k: 2, want: 10},
+		{n: 200, k: 4, want: 64684950},
+		{n: 3, k: 4, want: 0},
+		{n: 3, k: 0, want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := binomial(tt.n, tt.k); got != tt.want {
+			t.Errorf("binomial(%d, %d) = %v, want %v", tt.n, tt.k, got, tt.want)
+		}
+	}
+}
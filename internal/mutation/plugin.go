@@ -0,0 +1,59 @@
+package mutation
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	pluginsMu sync.RWMutex
+	// plugins are the mutators registered in addition to the built-in ones.
+	plugins []Mutator
+)
+
+// Register adds m to the mutators of every Engine created afterwards. The
+// same m is shared by all engines, so it must be safe for concurrent use.
+// Register panics if the name of m is empty or already taken, as it is meant
+// to be called from init functions.
+func Register(m Mutator) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+
+	if err := checkName(m, append(getAllMutators(), plugins...)); err != nil {
+		panic("mutation: Register called with " + err.Error())
+	}
+
+	plugins = append(plugins, m)
+}
+
+// checkName returns an error if the name of m is empty or already taken by
+// one of mutators.
+func checkName(m Mutator, mutators []Mutator) error {
+	name := m.Name()
+	if name == "" {
+		return errors.New("an unnamed mutator")
+	}
+
+	for _, existing := range mutators {
+		if existing.Name() == name {
+			return fmt.Errorf("a duplicate mutator %q", name)
+		}
+	}
+
+	return nil
+}
+
+// Mutators returns the built-in mutators followed by the ones added with
+// Register, the mutators of an Engine without extra ones.
+func Mutators() []Mutator {
+	return append(getAllMutators(), registeredMutators()...)
+}
+
+// registeredMutators returns the mutators added with Register.
+func registeredMutators() []Mutator {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+
+	return append([]Mutator(nil), plugins...)
+}
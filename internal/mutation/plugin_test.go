@@ -0,0 +1,106 @@
+package mutation
+
+import (
+	"go/ast"
+	"go/token"
+	"sync"
+	"testing"
+)
+
+// namedMutator is a mutator that never mutates anything.
+type namedMutator struct {
+	name string
+}
+
+func (m *namedMutator) Name() string                                 { return m.name }
+func (m *namedMutator) CanMutate(_ ast.Node) bool                    { return false }
+func (m *namedMutator) Mutate(_ ast.Node, _ *token.FileSet) []Mutant { return nil }
+func (m *namedMutator) Apply(_ ast.Node, _ Mutant) bool              { return false }
+
+// testPlugin is registered once per test binary, since registration cannot
+// be undone and tests may run several times with -count.
+var (
+	testPlugin         = &namedMutator{name: "test_plugin"}
+	registerTestPlugin sync.Once
+)
+
+func TestRegister(t *testing.T) {
+	registerTestPlugin.Do(func() { Register(testPlugin) })
+
+	engine, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	mutators := engine.GetMutators()
+	if mutators[len(mutators)-1] != testPlugin {
+		t.Errorf("expected the registered mutator after the built-in ones, got %v", mutators[len(mutators)-1])
+	}
+
+	if defaults := Mutators(); len(defaults) != len(mutators) || defaults[len(defaults)-1] != testPlugin {
+		t.Errorf("expected Mutators to match the mutators of an engine, got %d mutators", len(defaults))
+	}
+
+	tests := []struct {
+		name string
+		m    Mutator
+	}{
+		{name: "empty name", m: &namedMutator{}},
+		{name: "built-in name", m: &namedMutator{name: arithmeticMutatorName}},
+		{name: "registered name", m: &namedMutator{name: "test_plugin"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected Register to panic")
+				}
+			}()
+
+			Register(tt.m)
+		})
+	}
+}
+
+func TestWithMutators(t *testing.T) {
+	extra := &namedMutator{name: "engine_only"}
+
+	engine, err := New(WithMutators(extra))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	mutators := engine.GetMutators()
+	if mutators[len(mutators)-1] != extra {
+		t.Errorf("expected the engine's mutator last, got %v", mutators[len(mutators)-1])
+	}
+
+	other, err := New()
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	for _, m := range other.GetMutators() {
+		if m == extra {
+			t.Error("expected the mutator to be added to its engine only")
+		}
+	}
+
+	tests := []struct {
+		name     string
+		mutators []Mutator
+	}{
+		{name: "empty name", mutators: []Mutator{&namedMutator{}}},
+		{name: "built-in name", mutators: []Mutator{&namedMutator{name: arithmeticMutatorName}}},
+		{name: "same name twice", mutators: []Mutator{&namedMutator{name: "twice"}, &namedMutator{name: "twice"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(WithMutators(tt.mutators...)); err == nil {
+				t.Error("expected New to fail")
+			}
+		})
+	}
+}
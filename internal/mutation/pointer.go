@@ -0,0 +1,387 @@
+package mutation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+const (
+	pointerMutatorName         = "pointer_semantics"
+	addressOfRemovalType       = "address_of_removal"
+	structFieldRemovalType     = "struct_field_removal"
+	fieldAssignmentRemovalType = "field_assignment_removal"
+	pointerCopySwapType        = "pointer_copy_swap"
+
+	pointerRemoved = "<removed>"
+)
+
+// PointerMutator mutates value and pointer semantics:
+//   - `x := &T{...}` becomes `x := T{...}`, so that x holds a copy instead of
+//     a shared pointer (address_of_removal)
+//   - keyed fields are removed from struct literals, exposing unchecked
+//     zero-value defaults (struct_field_removal)
+//   - field assignments `x.f = v` are removed (field_assignment_removal)
+//   - for variables whose fields are assigned later, copying through a
+//     pointer is swapped with aliasing it: `v := *p` becomes `v := p` and
+//     `v := p` becomes `v := *p` (pointer_copy_swap)
+//
+// Field assignments are not removed by the assignment_removal mutator, so
+// that they can be tuned on their own. Mutants that no longer type check,
+// e.g. a copy passed where a pointer is required, are filtered by the
+// viability check; swapping copies needs type information.
+type PointerMutator struct {
+	info *types.Info
+	// addressOfDefs holds the &T{...} expressions that initialise variables
+	// without an explicit type.
+	addressOfDefs map[*ast.UnaryExpr]bool
+	// mutatedVars holds the variables whose fields are assigned.
+	mutatedVars map[types.Object]bool
+}
+
+// Name returns the name of the mutator.
+func (m *PointerMutator) Name() string {
+	return pointerMutatorName
+}
+
+// Prepare records the type information of the file about to be mutated, the
+// &T{...} expressions initialising variables and the variables whose fields
+// are assigned.
+func (m *PointerMutator) Prepare(file *ast.File, info *types.Info) {
+	m.info = info
+	m.addressOfDefs = make(map[*ast.UnaryExpr]bool)
+	m.mutatedVars = make(map[types.Object]bool)
+
+	if file == nil {
+		return
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE {
+				m.recordAddressOf(n.Rhs)
+
+				return true
+			}
+
+			for _, lhs := range n.Lhs {
+				m.recordMutatedVar(lhs)
+			}
+		case *ast.IncDecStmt:
+			m.recordMutatedVar(n.X)
+		case *ast.ValueSpec:
+			if n.Type == nil {
+				m.recordAddressOf(n.Values)
+			}
+		}
+
+		return true
+	})
+}
+
+// recordAddressOf records the &T{...} expressions among the initial values
+// of variables declared without a type.
+func (m *PointerMutator) recordAddressOf(values []ast.Expr) {
+	for _, value := range values {
+		if unary, ok := value.(*ast.UnaryExpr); ok && isAddressOfLit(unary) {
+			m.addressOfDefs[unary] = true
+		}
+	}
+}
+
+// recordMutatedVar records the variable at the root of a field selector that
+// is assigned, e.g. v in v.a.b = x.
+func (m *PointerMutator) recordMutatedVar(lhs ast.Expr) {
+	if m.info == nil {
+		return
+	}
+
+	sel, ok := lhs.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+
+	for {
+		inner, ok := sel.X.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+
+		sel = inner
+	}
+
+	if ident, ok := sel.X.(*ast.Ident); ok {
+		if obj, ok := m.info.Uses[ident].(*types.Var); ok {
+			m.mutatedVars[obj] = true
+		}
+	}
+}
+
+// CanMutate returns true if the node can be mutated by this mutator.
+func (m *PointerMutator) CanMutate(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.UnaryExpr:
+		return m.addressOfDefs[n]
+	case *ast.CompositeLit:
+		return m.isStructLit(n) && hasKeyedFields(n)
+	case *ast.AssignStmt:
+		return isFieldAssignment(n) || m.swappedCopy(n) != nil
+	default:
+		return false
+	}
+}
+
+// Mutate generates mutants for the given node.
+func (m *PointerMutator) Mutate(node ast.Node, fset *token.FileSet) []Mutant {
+	switch n := node.(type) {
+	case *ast.UnaryExpr:
+		if !m.addressOfDefs[n] {
+			return nil
+		}
+
+		return exprMutants(n, addressOfRemovalType, []ast.Expr{n.X}, fset)
+	case *ast.CompositeLit:
+		if !m.isStructLit(n) {
+			return nil
+		}
+
+		return mutateKeyedFields(n, fset)
+	case *ast.AssignStmt:
+		return m.mutateAssign(n, fset)
+	default:
+		return nil
+	}
+}
+
+// mutateAssign generates field assignment removal and copy swap mutants.
+func (m *PointerMutator) mutateAssign(stmt *ast.AssignStmt, fset *token.FileSet) []Mutant {
+	pos := fset.Position(stmt.Pos())
+	original := stmtToString(stmt)
+
+	if isFieldAssignment(stmt) {
+		return []Mutant{{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        fieldAssignmentRemovalType,
+			Original:    original,
+			Mutated:     pointerRemoved,
+			Description: fmt.Sprintf("Remove field assignment %s", original),
+		}}
+	}
+
+	swapped := m.swappedCopy(stmt)
+	if swapped == nil {
+		return nil
+	}
+
+	mutated := stmtToString(swapped)
+
+	return []Mutant{{
+		Line:        pos.Line,
+		Column:      pos.Column,
+		Type:        pointerCopySwapType,
+		Original:    original,
+		Mutated:     mutated,
+		Description: fmt.Sprintf("Replace %s with %s", original, mutated),
+	}}
+}
+
+// mutateKeyedFields generates a removal mutant for each keyed field of a
+// struct literal.
+func mutateKeyedFields(lit *ast.CompositeLit, fset *token.FileSet) []Mutant {
+	var mutants []Mutant
+
+	for _, elt := range lit.Elts {
+		if _, ok := elt.(*ast.KeyValueExpr); !ok {
+			continue
+		}
+
+		pos := fset.Position(elt.Pos())
+		original := exprToString(elt)
+
+		mutants = append(mutants, Mutant{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        structFieldRemovalType,
+			Original:    original,
+			Mutated:     pointerRemoved,
+			Description: fmt.Sprintf("Remove field %s from struct literal", original),
+		})
+	}
+
+	return mutants
+}
+
+// Apply swaps a copy through a pointer with aliasing it.
+func (m *PointerMutator) Apply(node ast.Node, mutant Mutant) bool {
+	if mutant.Type != pointerCopySwapType {
+		return false
+	}
+
+	stmt, ok := node.(*ast.AssignStmt)
+	if !ok || stmt.Tok != token.DEFINE || len(stmt.Rhs) != 1 || stmtToString(stmt) != mutant.Original {
+		return false
+	}
+
+	swapped := swapDeref(stmt)
+	if stmtToString(swapped) != mutant.Mutated {
+		return false
+	}
+
+	*stmt = *swapped
+
+	return true
+}
+
+// ApplyWithCursor removes &, struct fields and field assignments.
+func (m *PointerMutator) ApplyWithCursor(node ast.Node, replaceFunc func(ast.Node), mutant Mutant) bool {
+	switch mutant.Type {
+	case addressOfRemovalType:
+		unary, ok := node.(*ast.UnaryExpr)
+		if !ok || !isAddressOfLit(unary) || exprToString(unary) != mutant.Original {
+			return false
+		}
+
+		replaceFunc(unary.X)
+
+		return true
+	case structFieldRemovalType:
+		kv, ok := node.(*ast.KeyValueExpr)
+		if !ok || exprToString(kv) != mutant.Original {
+			return false
+		}
+
+		replaceFunc(nil)
+
+		return true
+	case fieldAssignmentRemovalType:
+		stmt, ok := node.(*ast.AssignStmt)
+		if !ok || !isFieldAssignment(stmt) || stmtToString(stmt) != mutant.Original {
+			return false
+		}
+
+		replaceFunc(&ast.EmptyStmt{})
+
+		return true
+	default:
+		return false
+	}
+}
+
+// swappedCopy returns the copy swap of `v := *p` or `v := p`, where p points
+// to a struct and the fields of v are assigned, or nil.
+func (m *PointerMutator) swappedCopy(stmt *ast.AssignStmt) *ast.AssignStmt {
+	if m.info == nil || stmt.Tok != token.DEFINE || len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+		return nil
+	}
+
+	ident, ok := stmt.Lhs[0].(*ast.Ident)
+	if !ok || !m.mutatedVars[m.info.Defs[ident]] {
+		return nil
+	}
+
+	ptr := stmt.Rhs[0]
+	if star, ok := ptr.(*ast.StarExpr); ok {
+		ptr = star.X
+	} else if _, ok := ptr.(*ast.Ident); !ok {
+		// Only pointers held by variables are dereferenced, so that
+		// function calls and &T{...} are left alone.
+		return nil
+	}
+
+	if !m.isStructPointer(ptr) {
+		return nil
+	}
+
+	return swapDeref(stmt)
+}
+
+// isStructPointer reports whether expr is a pointer to a struct.
+func (m *PointerMutator) isStructPointer(expr ast.Expr) bool {
+	tv, ok := m.info.Types[expr]
+	if !ok || tv.Type == nil {
+		return false
+	}
+
+	ptr, ok := tv.Type.Underlying().(*types.Pointer)
+	if !ok {
+		return false
+	}
+
+	_, ok = ptr.Elem().Underlying().(*types.Struct)
+
+	return ok
+}
+
+// isStructLit reports whether lit is a struct literal. Without type
+// information, literals of named types with keyed fields are assumed to be
+// structs.
+func (m *PointerMutator) isStructLit(lit *ast.CompositeLit) bool {
+	if m.info != nil {
+		if tv, ok := m.info.Types[lit]; ok && tv.Type != nil {
+			_, ok := tv.Type.Underlying().(*types.Struct)
+
+			return ok
+		}
+	}
+
+	switch lit.Type.(type) {
+	case *ast.StructType, *ast.Ident, *ast.SelectorExpr:
+		return true
+	default:
+		return false
+	}
+}
+
+// hasKeyedFields reports whether lit has a keyed element.
+func hasKeyedFields(lit *ast.CompositeLit) bool {
+	for _, elt := range lit.Elts {
+		if _, ok := elt.(*ast.KeyValueExpr); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isAddressOfLit reports whether expr is &T{...}.
+func isAddressOfLit(expr *ast.UnaryExpr) bool {
+	if expr.Op != token.AND {
+		return false
+	}
+
+	_, ok := expr.X.(*ast.CompositeLit)
+
+	return ok
+}
+
+// isFieldAssignment reports whether stmt is `x.f = v`.
+func isFieldAssignment(stmt *ast.AssignStmt) bool {
+	if stmt.Tok != token.ASSIGN || len(stmt.Lhs) != 1 {
+		return false
+	}
+
+	_, ok := stmt.Lhs[0].(*ast.SelectorExpr)
+
+	return ok
+}
+
+// swapDeref returns a copy of `v := *p` as `v := p` and of `v := p` as
+// `v := *p`.
+func swapDeref(stmt *ast.AssignStmt) *ast.AssignStmt {
+	rhs := stmt.Rhs[0]
+	if star, ok := rhs.(*ast.StarExpr); ok {
+		rhs = star.X
+	} else {
+		rhs = &ast.StarExpr{X: rhs}
+	}
+
+	return &ast.AssignStmt{
+		Lhs:    stmt.Lhs,
+		TokPos: stmt.TokPos,
+		Tok:    stmt.Tok,
+		Rhs:    []ast.Expr{rhs},
+	}
+}
@@ -0,0 +1,184 @@
+package mutation
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPointerMutator_Name(t *testing.T) {
+	t.Parallel()
+
+	mutator := &PointerMutator{}
+
+	if mutator.Name() != pointerMutatorName {
+		t.Errorf("Name() = %q, want %q", mutator.Name(), pointerMutatorName)
+	}
+}
+
+func TestPointerMutator_TypeAware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "address of composite literal",
+			src: `package main
+type config struct{ retries int }
+func f() (*config, int) {
+	c := &config{}
+	var d = &config{}
+	return &config{}, c.retries + d.retries
+}`,
+			want: []string{
+				"address_of_removal: &config{} -> config{}",
+				"address_of_removal: &config{} -> config{}",
+			},
+		},
+		{
+			name: "keyed struct fields",
+			src: `package main
+type point struct{ x, y int }
+func f() (point, point, map[string]int) {
+	return point{x: 1, y: 2}, point{1, 2}, map[string]int{"x": 1}
+}`,
+			want: []string{
+				"struct_field_removal: x: 1 -> <removed>",
+				"struct_field_removal: y: 2 -> <removed>",
+			},
+		},
+		{
+			name: "field assignments",
+			src: `package main
+type counter struct{ n int }
+func f(c *counter, n int) {
+	c.n = n
+	c.n += n
+	n = 0
+}`,
+			want: []string{"field_assignment_removal: c.n = n -> <removed>"},
+		},
+		{
+			name: "copy then mutate",
+			src: `package main
+type state struct{ count int }
+func f(p *state) (state, *state, state) {
+	v := *p
+	v.count++
+	q := p
+	q.count = 1
+	r := *p
+	return v, q, r
+}`,
+			want: []string{
+				"pointer_copy_swap: v := *p -> v := p",
+				"pointer_copy_swap: q := p -> q := *p",
+				"field_assignment_removal: q.count = 1 -> <removed>",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := mutateTypeChecked(t, &PointerMutator{}, tt.src)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mutants mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPointerMutator_ApplyMutant(t *testing.T) {
+	t.Parallel()
+
+	const src = `package main
+
+type Session struct {
+	User  string
+	Admin bool
+}
+
+func Promote(p *Session) *Session {
+	s := &Session{User: "root", Admin: false}
+	copied := *p
+	copied.Admin = true
+	return s
+}
+`
+
+	tests := []struct {
+		name   string
+		mutant Mutant
+		want   string
+	}{
+		{
+			name: "address of removed",
+			mutant: Mutant{
+				Type: addressOfRemovalType, Line: 9, Column: 7,
+				Original: `&Session{User: "root", Admin: false}`, Mutated: `Session{User: "root", Admin: false}`,
+			},
+			want: `s := Session{User: "root", Admin: false}`,
+		},
+		{
+			name: "struct field removed",
+			mutant: Mutant{
+				Type: structFieldRemovalType, Line: 9, Column: 30,
+				Original: "Admin: false", Mutated: pointerRemoved,
+			},
+			want: `s := &Session{User: "root"}`,
+		},
+		{
+			name: "field assignment removed",
+			mutant: Mutant{
+				Type: fieldAssignmentRemovalType, Line: 11, Column: 2,
+				Original: "copied.Admin = true", Mutated: pointerRemoved,
+			},
+			want: "copied := *p\n\n\treturn s",
+		},
+		{
+			name: "copy swapped with alias",
+			mutant: Mutant{
+				Type: pointerCopySwapType, Line: 10, Column: 2,
+				Original: "copied := *p", Mutated: "copied := p",
+			},
+			want: "copied := p\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+
+			file, err := parser.ParseFile(fset, "session.go", src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("failed to parse source: %v", err)
+			}
+
+			if !ApplyMutant(fset, file, tt.mutant, []Mutator{&PointerMutator{}}) {
+				t.Fatal("ApplyMutant() = false, want true")
+			}
+
+			var buf bytes.Buffer
+			if err := format.Node(&buf, fset, file); err != nil {
+				t.Fatalf("failed to format file: %v", err)
+			}
+
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("expected output to contain %q, got:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
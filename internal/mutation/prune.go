@@ -0,0 +1,123 @@
+package mutation
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+)
+
+// sufficientReplacements holds, for each relational operator, the operator
+// replacements that subsume all the others: a test set killing these kills
+// every other relational operator replacement at the same site (Kaminski,
+// Ammann and Offutt, "Better predicate testing", 2011).
+var sufficientReplacements = map[string][]string{
+	"<":  {"<=", "!="},
+	">":  {">=", "!="},
+	"<=": {"<", "=="},
+	">=": {">", "=="},
+	"==": {"<=", ">="},
+	"!=": {"<", ">"},
+}
+
+// pruneSubsumed drops the relational operator replacements that are
+// subsumed by the sufficient replacements at the same site. Sites where a
+// sufficient replacement was filtered, e.g. because the operands are not
+// ordered, are left alone.
+func (e *Engine) pruneSubsumed(mutants []Mutant) []Mutant {
+	type site struct {
+		line, column int
+		original     string
+	}
+
+	replacements := make(map[site]map[string]bool)
+
+	for _, m := range mutants {
+		if m.Type != conditionalBinaryType {
+			continue
+		}
+
+		s := site{m.Line, m.Column, m.Original}
+		if replacements[s] == nil {
+			replacements[s] = make(map[string]bool)
+		}
+
+		replacements[s][m.Mutated] = true
+	}
+
+	pruned := mutants[:0]
+
+	for _, m := range mutants {
+		if m.Type == conditionalBinaryType && isSubsumed(m, replacements[site{m.Line, m.Column, m.Original}]) {
+			e.subsumed++
+
+			continue
+		}
+
+		pruned = append(pruned, m)
+	}
+
+	return pruned
+}
+
+// isSubsumed reports whether mutant is not a sufficient replacement of its
+// operator while all sufficient replacements are among replacements.
+func isSubsumed(mutant Mutant, replacements map[string]bool) bool {
+	sufficient, ok := sufficientReplacements[mutant.Original]
+	if !ok {
+		return false
+	}
+
+	for _, op := range sufficient {
+		if op == mutant.Mutated {
+			return false
+		}
+
+		if !replacements[op] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pruneDuplicates drops the mutants of filePath whose mutated source, as
+// printed from the mutated AST, is identical to that of an earlier mutant.
+// Mutants that cannot be applied are kept so that execution reports them.
+func (e *Engine) pruneDuplicates(filePath string, mutants []Mutant) []Mutant {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return mutants
+	}
+
+	seen := make(map[[sha256.Size]byte]bool, len(mutants))
+	pruned := mutants[:0]
+
+	for _, m := range mutants {
+		mutated, err := MutateSource(filePath, src, m, e.mutators)
+		if err != nil {
+			pruned = append(pruned, m)
+
+			continue
+		}
+
+		sum := sha256.Sum256(mutated)
+		if seen[sum] {
+			e.duplicates++
+
+			continue
+		}
+
+		seen[sum] = true
+
+		pruned = append(pruned, m)
+	}
+
+	return pruned
+}
+
+// renumber assigns sequential IDs to the mutants of filePath.
+func renumber(filePath string, mutants []Mutant) {
+	for i := range mutants {
+		mutants[i].ID = fmt.Sprintf("%s_%d", filePath, i)
+	}
+}
@@ -0,0 +1,135 @@
+package mutation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPruneSubsumed(t *testing.T) {
+	t.Parallel()
+
+	site := func(original string, mutated ...string) []Mutant {
+		mutants := make([]Mutant, 0, len(mutated))
+		for _, op := range mutated {
+			mutants = append(mutants, Mutant{Type: conditionalBinaryType, Line: 4, Column: 9, Original: original, Mutated: op})
+		}
+
+		return mutants
+	}
+
+	tests := []struct {
+		name    string
+		mutants []Mutant
+		want    []string
+	}{
+		{
+			name:    "all replacements of <",
+			mutants: site("<", "<=", ">", ">=", "==", "!="),
+			want:    []string{"<=", "!="},
+		},
+		{
+			name:    "all replacements of ==",
+			mutants: site("==", "!=", "<", "<=", ">", ">="),
+			want:    []string{"<=", ">="},
+		},
+		{
+			name:    "sufficient replacement missing",
+			mutants: site("==", "!="),
+			want:    []string{"!="},
+		},
+		{
+			name: "other mutation types kept",
+			mutants: append(site("<", "<=", ">", "!="),
+				Mutant{Type: arithmeticBinaryType, Line: 4, Column: 9, Original: "+", Mutated: "-"}),
+			want: []string{"<=", "!=", "-"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			engine := &Engine{}
+
+			pruned := engine.pruneSubsumed(tt.mutants)
+			if len(pruned) != len(tt.want) {
+				t.Fatalf("pruneSubsumed() kept %d mutants, want %d", len(pruned), len(tt.want))
+			}
+
+			for i, m := range pruned {
+				if m.Mutated != tt.want[i] {
+					t.Errorf("pruned[%d].Mutated = %q, want %q", i, m.Mutated, tt.want[i])
+				}
+			}
+
+			if got := len(tt.mutants) - len(tt.want); engine.SubsumedMutants() != got {
+				t.Errorf("SubsumedMutants() = %d, want %d", engine.SubsumedMutants(), got)
+			}
+		})
+	}
+}
+
+func TestGenerateMutants_PrunesDuplicates(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	src := `package m
+
+import "sync"
+
+func Wait(wg *sync.WaitGroup) {
+	wg.Wait()
+}
+
+func Less(a, b int) bool {
+	return a < b
+}
+`
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/m\n\ngo 1.21\n"), 0600); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+
+	path := filepath.Join(tmpDir, "m.go")
+	if err := os.WriteFile(path, []byte(src), 0600); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	engine, err := New(WithSubsumption(true))
+	if err != nil {
+		t.Fatalf("Failed to create mutation engine: %v", err)
+	}
+
+	mutants, err := engine.GenerateMutants(path)
+	if err != nil {
+		t.Fatalf("Failed to generate mutants: %v", err)
+	}
+
+	if engine.DuplicateMutants() == 0 {
+		t.Error("Expected removals of wg.Wait() to be pruned as duplicates")
+	}
+
+	if engine.SubsumedMutants() == 0 {
+		t.Error("Expected replacements of < to be pruned as subsumed")
+	}
+
+	seen := make(map[string]bool)
+
+	for i, m := range mutants {
+		if want := fmt.Sprintf("%s_%d", path, i); m.ID != want {
+			t.Errorf("mutants[%d].ID = %q, want %q", i, m.ID, want)
+		}
+
+		mutated, err := MutateSource(path, []byte(src), m, engine.mutators)
+		if err != nil {
+			continue
+		}
+
+		if seen[string(mutated)] {
+			t.Errorf("Mutant %s (%s) duplicates an earlier mutant", m.ID, m.Type)
+		}
+
+		seen[string(mutated)] = true
+	}
+}
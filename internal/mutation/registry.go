@@ -11,16 +11,25 @@ func getAllMutators() []Mutator {
 		&BoundaryValueMutator{},
 		&BranchMutator{},
 		&BreakContinueMutator{},
+		&CallSwapMutator{},
+		&CollectionMutator{},
+		&ConcurrencyMutator{},
 		&ConditionalMutator{},
+		&ConstantMutator{},
+		&ContextMutator{},
+		&DeferPanicMutator{},
 		&EmptyBlockMutator{},
 		&ErrorHandlingMutator{},
 		&ExpressionRemovalMutator{},
+		&GenericsMutator{},
 		&InvertNegativesMutator{},
 		&LogicalMutator{},
 		&LoopConditionMutator{},
+		&PointerMutator{},
 		&RemoveSelfAssignmentsMutator{},
 		&ReturnMutator{},
 		&StatementRemovalMutator{},
 		&StringLiteralMutator{},
+		&SwitchMutator{},
 	}
 }
@@ -112,6 +112,11 @@ func (m *ReturnMutator) mutateBasicLit(lit *ast.BasicLit, pos token.Position) []
 		return nil
 	}
 
+	// Returning the zero value already would leave the source unchanged.
+	if lit.Value == mutated {
+		return nil
+	}
+
 	return []Mutant{{
 		Line:        pos.Line,
 		Column:      pos.Column,
@@ -255,6 +255,27 @@ func TestReturnMutator_Mutate_ZeroValue(t *testing.T) {
 	}
 }
 
+func TestReturnMutator_Mutate_ZeroValueUnchanged(t *testing.T) {
+	t.Parallel()
+
+	mutator := &ReturnMutator{}
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "test.go", "package main\nfunc f() (int, string) { return 0, \"\" }", 0)
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+
+	fn, ok := file.Decls[0].(*ast.FuncDecl)
+	if !ok {
+		t.Fatal("FuncDecl not found")
+	}
+
+	if mutants := mutator.Mutate(fn.Body.List[0], fset); len(mutants) != 0 {
+		t.Errorf("Expected no mutants for zero values, got %v", mutants)
+	}
+}
+
 func TestReturnMutator_Apply_BoolLiteral(t *testing.T) {
 	t.Parallel()
 
@@ -17,10 +17,11 @@ const (
 // StatementRemovalMutator removes standalone statements that carry a side
 // effect, testing whether that side effect is covered by tests.
 //
-// To avoid generating duplicate mutants, assignment statements and expression
-// statements are handled by the dedicated assignment_removal and
-// expression_removal mutators; this mutator covers the remaining removable
-// statement kinds: increment/decrement, defer, go, and channel send.
+// To avoid generating duplicate mutants, assignment statements, expression
+// statements and defer statements are handled by the dedicated
+// assignment_removal, expression_removal and defer_panic mutators; this
+// mutator covers the remaining removable statement kinds:
+// increment/decrement, go, and channel send.
 type StatementRemovalMutator struct {
 }
 
@@ -77,11 +78,12 @@ func (m *StatementRemovalMutator) ApplyWithCursor(node ast.Node, replaceFunc fun
 }
 
 // isRemovableStatement reports whether node is a statement kind handled by this
-// mutator. AssignStmt and ExprStmt are intentionally excluded (handled by the
-// assignment_removal and expression_removal mutators respectively).
+// mutator. AssignStmt, ExprStmt and DeferStmt are intentionally excluded
+// (handled by the assignment_removal, expression_removal and defer_panic
+// mutators respectively).
 func isRemovableStatement(node ast.Node) bool {
 	switch node.(type) {
-	case *ast.IncDecStmt, *ast.DeferStmt, *ast.GoStmt, *ast.SendStmt:
+	case *ast.IncDecStmt, *ast.GoStmt, *ast.SendStmt:
 		return true
 	default:
 		return false
@@ -57,7 +57,6 @@ func TestStatementRemovalMutator_CanMutate(t *testing.T) {
 	}{
 		{name: "increment", code: "i++"},
 		{name: "decrement", code: "i--"},
-		{name: "defer", code: "defer f()"},
 		{name: "go", code: "go f()"},
 		{name: "send", code: "ch <- 1"},
 	}
@@ -82,8 +81,9 @@ func TestStatementRemovalMutator_CanMutate_Excluded(t *testing.T) {
 	mutator := &StatementRemovalMutator{}
 	fset := token.NewFileSet()
 
-	// Assignment and expression statements are handled by other mutators.
-	src := "package main\nfunc test() {\n\ta := 0\n\ta = 1\n\tf()\n}"
+	// Assignment, expression and defer statements are handled by other
+	// mutators.
+	src := "package main\nfunc test() {\n\ta := 0\n\ta = 1\n\tf()\n\tdefer f()\n}"
 
 	file, err := parser.ParseFile(fset, "test.go", src, 0)
 	if err != nil {
@@ -92,7 +92,7 @@ func TestStatementRemovalMutator_CanMutate_Excluded(t *testing.T) {
 
 	ast.Inspect(file, func(n ast.Node) bool {
 		switch n.(type) {
-		case *ast.AssignStmt, *ast.ExprStmt:
+		case *ast.AssignStmt, *ast.ExprStmt, *ast.DeferStmt:
 			if mutator.CanMutate(n) {
 				t.Errorf("CanMutate() = true, want false for %T", n)
 			}
@@ -113,7 +113,6 @@ func TestStatementRemovalMutator_Mutate(t *testing.T) {
 		wantOriginal string
 	}{
 		{name: "increment", code: "i++", wantOriginal: "i++"},
-		{name: "defer", code: "defer f()", wantOriginal: "defer f()"},
 		{name: "go", code: "go f()", wantOriginal: "go f()"},
 		{name: "send", code: "ch <- 1", wantOriginal: "ch <- 1"},
 	}
@@ -204,8 +203,8 @@ func TestStatementRemovalMutator_ApplyWithCursor(t *testing.T) {
 			wantReplace: true,
 		},
 		{
-			name:        "removes defer statement",
-			code:        "defer f()",
+			name:        "removes go statement",
+			code:        "go f()",
 			mutantType:  statementRemovalType,
 			expected:    true,
 			wantReplace: true,
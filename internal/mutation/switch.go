@@ -0,0 +1,271 @@
+package mutation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+const (
+	switchMutatorName         = "switch"
+	switchCaseRemovalType     = "switch_case_removal"
+	switchCaseBodyRemovalType = "switch_case_body_removal"
+	switchDefaultSwapType     = "switch_default_swap"
+	fallthroughRemovalType    = "fallthrough_removal"
+	typeSwitchTypeRemovalType = "type_switch_type_removal"
+
+	switchRemoved     = "<removed>"
+	switchEmptyBody   = "<empty body>"
+	switchDefaultBody = "<default body>"
+)
+
+// SwitchMutator mutates switch and type switch statements:
+//   - case clauses, including default, are removed (switch_case_removal)
+//   - case bodies are emptied when a default clause would otherwise take
+//     over, so that the mutant differs from removing the case
+//     (switch_case_body_removal)
+//   - the body of a case is swapped with the body of the default clause
+//     (switch_default_swap)
+//   - fallthrough statements are removed (fallthrough_removal)
+//   - a type is dropped from a multi-type case of a type switch
+//     (type_switch_type_removal)
+type SwitchMutator struct{}
+
+// Name returns the name of the mutator.
+func (m *SwitchMutator) Name() string {
+	return switchMutatorName
+}
+
+// CanMutate returns true if the node can be mutated by this mutator.
+func (m *SwitchMutator) CanMutate(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.SwitchStmt:
+		return len(n.Body.List) > 0
+	case *ast.TypeSwitchStmt:
+		return len(n.Body.List) > 0
+	case *ast.BranchStmt:
+		return n.Tok == token.FALLTHROUGH
+	default:
+		return false
+	}
+}
+
+// Mutate generates mutants for the given node.
+func (m *SwitchMutator) Mutate(node ast.Node, fset *token.FileSet) []Mutant {
+	switch n := node.(type) {
+	case *ast.SwitchStmt:
+		return mutateCaseClauses(n.Body, fset)
+	case *ast.TypeSwitchStmt:
+		return append(mutateCaseClauses(n.Body, fset), mutateCaseTypes(n.Body, fset)...)
+	case *ast.BranchStmt:
+		if n.Tok != token.FALLTHROUGH {
+			return nil
+		}
+
+		pos := fset.Position(n.Pos())
+
+		return []Mutant{{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        fallthroughRemovalType,
+			Original:    n.Tok.String(),
+			Mutated:     switchRemoved,
+			Description: "Remove fallthrough",
+		}}
+	default:
+		return nil
+	}
+}
+
+// mutateCaseClauses generates removal, body emptying and default swap
+// mutants for the clauses of a switch body.
+func mutateCaseClauses(body *ast.BlockStmt, fset *token.FileSet) []Mutant {
+	defaultClause := findDefaultCase(body)
+
+	var mutants []Mutant
+
+	for _, stmt := range body.List {
+		clause, ok := stmt.(*ast.CaseClause)
+		if !ok {
+			continue
+		}
+
+		pos := fset.Position(clause.Pos())
+		original := caseClauseString(clause)
+
+		mutants = append(mutants, Mutant{
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Type:        switchCaseRemovalType,
+			Original:    original,
+			Mutated:     switchRemoved,
+			Description: fmt.Sprintf("Remove %s", original),
+		})
+
+		// Without a default clause, emptying or swapping a case is the same
+		// as removing it.
+		if defaultClause == nil || clause == defaultClause {
+			continue
+		}
+
+		if len(clause.Body) > 0 {
+			mutants = append(mutants, Mutant{
+				Line:        pos.Line,
+				Column:      pos.Column,
+				Type:        switchCaseBodyRemovalType,
+				Original:    original,
+				Mutated:     switchEmptyBody,
+				Description: fmt.Sprintf("Empty the body of %s", original),
+			})
+		}
+
+		if stmtsToString(clause.Body) != stmtsToString(defaultClause.Body) {
+			mutants = append(mutants, Mutant{
+				Line:        pos.Line,
+				Column:      pos.Column,
+				Type:        switchDefaultSwapType,
+				Original:    original,
+				Mutated:     switchDefaultBody,
+				Description: fmt.Sprintf("Swap the body of %s with the default body", original),
+			})
+		}
+	}
+
+	return mutants
+}
+
+// mutateCaseTypes generates a mutant dropping each type of the multi-type
+// cases of a type switch body.
+func mutateCaseTypes(body *ast.BlockStmt, fset *token.FileSet) []Mutant {
+	var mutants []Mutant
+
+	for _, stmt := range body.List {
+		clause, ok := stmt.(*ast.CaseClause)
+		if !ok || len(clause.List) < 2 {
+			continue
+		}
+
+		for _, typ := range clause.List {
+			pos := fset.Position(typ.Pos())
+			original := exprToString(typ)
+
+			mutants = append(mutants, Mutant{
+				Line:        pos.Line,
+				Column:      pos.Column,
+				Type:        typeSwitchTypeRemovalType,
+				Original:    original,
+				Mutated:     switchRemoved,
+				Description: fmt.Sprintf("Remove %s from %s", original, caseClauseString(clause)),
+			})
+		}
+	}
+
+	return mutants
+}
+
+// Apply empties the body of a case clause.
+func (m *SwitchMutator) Apply(node ast.Node, mutant Mutant) bool {
+	if mutant.Type != switchCaseBodyRemovalType {
+		return false
+	}
+
+	clause, ok := node.(*ast.CaseClause)
+	if !ok || caseClauseString(clause) != mutant.Original {
+		return false
+	}
+
+	clause.Body = nil
+
+	return true
+}
+
+// ApplyWithCursor removes fallthrough statements and types of type switch
+// cases.
+func (m *SwitchMutator) ApplyWithCursor(node ast.Node, replaceFunc func(ast.Node), mutant Mutant) bool {
+	switch mutant.Type {
+	case fallthroughRemovalType:
+		stmt, ok := node.(*ast.BranchStmt)
+		if !ok || stmt.Tok != token.FALLTHROUGH {
+			return false
+		}
+
+		replaceFunc(&ast.EmptyStmt{})
+
+		return true
+	case typeSwitchTypeRemovalType:
+		expr, ok := node.(ast.Expr)
+		if !ok || exprToString(expr) != mutant.Original {
+			return false
+		}
+
+		replaceFunc(nil)
+
+		return true
+	default:
+		return false
+	}
+}
+
+// ApplyInBlock removes a case clause from a switch body or swaps its body
+// with the default clause's.
+func (m *SwitchMutator) ApplyInBlock(block *ast.BlockStmt, index int, mutant Mutant) bool {
+	if mutant.Type != switchCaseRemovalType && mutant.Type != switchDefaultSwapType {
+		return false
+	}
+
+	clause, ok := block.List[index].(*ast.CaseClause)
+	if !ok || caseClauseString(clause) != mutant.Original {
+		return false
+	}
+
+	if mutant.Type == switchCaseRemovalType {
+		block.List = append(block.List[:index], block.List[index+1:]...)
+
+		return true
+	}
+
+	defaultClause := findDefaultCase(block)
+	if defaultClause == nil || defaultClause == clause {
+		return false
+	}
+
+	clause.Body, defaultClause.Body = defaultClause.Body, clause.Body
+
+	return true
+}
+
+// findDefaultCase returns the default clause of a switch body, or nil.
+func findDefaultCase(body *ast.BlockStmt) *ast.CaseClause {
+	for _, stmt := range body.List {
+		if clause, ok := stmt.(*ast.CaseClause); ok && clause.List == nil {
+			return clause
+		}
+	}
+
+	return nil
+}
+
+// caseClauseString renders the header of a case clause for reports.
+func caseClauseString(clause *ast.CaseClause) string {
+	if clause.List == nil {
+		return "default:"
+	}
+
+	exprs := make([]string, 0, len(clause.List))
+	for _, expr := range clause.List {
+		exprs = append(exprs, exprToString(expr))
+	}
+
+	return "case " + strings.Join(exprs, ", ") + ":"
+}
+
+// stmtsToString renders a statement list for comparison.
+func stmtsToString(stmts []ast.Stmt) string {
+	rendered := make([]string, 0, len(stmts))
+	for _, stmt := range stmts {
+		rendered = append(rendered, stmtToString(stmt))
+	}
+
+	return strings.Join(rendered, "\n")
+}
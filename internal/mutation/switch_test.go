@@ -0,0 +1,216 @@
+package mutation
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSwitchMutator_Name(t *testing.T) {
+	t.Parallel()
+
+	mutator := &SwitchMutator{}
+
+	if mutator.Name() != switchMutatorName {
+		t.Errorf("Name() = %q, want %q", mutator.Name(), switchMutatorName)
+	}
+}
+
+func TestSwitchMutator_Mutate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "switch without default",
+			src: `package main
+func f(n int) string {
+	switch n {
+	case 1, 2:
+		return "small"
+	case 3:
+		return "three"
+	}
+	return ""
+}`,
+			want: []string{
+				"switch_case_removal: case 1, 2: -> <removed>",
+				"switch_case_removal: case 3: -> <removed>",
+			},
+		},
+		{
+			name: "switch with default",
+			src: `package main
+func f(n int) string {
+	s := ""
+	switch {
+	case n < 0:
+		s = "negative"
+	case n == 0:
+	default:
+		s = "positive"
+	}
+	return s
+}`,
+			want: []string{
+				"switch_case_removal: case n < 0: -> <removed>",
+				"switch_case_body_removal: case n < 0: -> <empty body>",
+				"switch_default_swap: case n < 0: -> <default body>",
+				"switch_case_removal: case n == 0: -> <removed>",
+				"switch_default_swap: case n == 0: -> <default body>",
+				"switch_case_removal: default: -> <removed>",
+			},
+		},
+		{
+			name: "fallthrough",
+			src: `package main
+func f(n int) (s string) {
+	switch n {
+	case 1:
+		s += "one"
+		fallthrough
+	case 2:
+		s += "two"
+	}
+	return s
+}`,
+			want: []string{
+				"switch_case_removal: case 1: -> <removed>",
+				"switch_case_removal: case 2: -> <removed>",
+				"fallthrough_removal: fallthrough -> <removed>",
+			},
+		},
+		{
+			name: "type switch with multi-type case",
+			src: `package main
+func f(v any) bool {
+	switch v.(type) {
+	case int, *string:
+		return true
+	}
+	return false
+}`,
+			want: []string{
+				"switch_case_removal: case int, *string: -> <removed>",
+				"type_switch_type_removal: int -> <removed>",
+				"type_switch_type_removal: *string -> <removed>",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := mutateTypeChecked(t, &SwitchMutator{}, tt.src)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mutants mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSwitchMutator_ApplyMutant(t *testing.T) {
+	t.Parallel()
+
+	const src = `package main
+
+func Handle(op string, v any) (s string) {
+	switch op {
+	case "get":
+		s = "got"
+		fallthrough
+	case "put":
+		s += "put"
+	default:
+		s = "unknown"
+	}
+	switch v.(type) {
+	case int, string:
+		s += "!"
+	}
+	return s
+}
+`
+
+	tests := []struct {
+		name    string
+		mutant  Mutant
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "case removed",
+			mutant:  Mutant{Type: switchCaseRemovalType, Line: 8, Column: 2, Original: `case "put":`},
+			notWant: []string{`case "put":`},
+		},
+		{
+			name:    "case body emptied",
+			mutant:  Mutant{Type: switchCaseBodyRemovalType, Line: 8, Column: 2, Original: `case "put":`},
+			want:    []string{`case "put": default:`},
+			notWant: []string{`s += "put"`},
+		},
+		{
+			name:   "case body swapped with default",
+			mutant: Mutant{Type: switchDefaultSwapType, Line: 8, Column: 2, Original: `case "put":`},
+			want:   []string{`case "put": s = "unknown" default: s += "put"`},
+		},
+		{
+			name:    "fallthrough removed",
+			mutant:  Mutant{Type: fallthroughRemovalType, Line: 7, Column: 3, Original: "fallthrough"},
+			notWant: []string{"fallthrough"},
+		},
+		{
+			name:   "type dropped from case",
+			mutant: Mutant{Type: typeSwitchTypeRemovalType, Line: 14, Column: 12, Original: "string"},
+			want:   []string{"case int:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+
+			file, err := parser.ParseFile(fset, "handle.go", src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("failed to parse source: %v", err)
+			}
+
+			if !ApplyMutant(fset, file, tt.mutant, []Mutator{&SwitchMutator{}}) {
+				t.Fatal("ApplyMutant() = false, want true")
+			}
+
+			var buf bytes.Buffer
+			if err := format.Node(&buf, fset, file); err != nil {
+				t.Fatalf("failed to format file: %v", err)
+			}
+
+			// Moved statements keep their positions, so compare ignoring
+			// layout.
+			got := strings.Join(strings.Fields(buf.String()), " ")
+
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, buf.String())
+				}
+			}
+
+			for _, notWant := range tt.notWant {
+				if strings.Contains(buf.String(), notWant) {
+					t.Errorf("expected output not to contain %q, got:\n%s", notWant, buf.String())
+				}
+			}
+		})
+	}
+}
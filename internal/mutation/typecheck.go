@@ -18,9 +18,19 @@ func NewTypeChecker(typeInfo *types.Info) *TypeChecker {
 
 // IsValidMutation checks if a mutation is valid for the given node.
 //
+// These are cheap per-type checks; the mutants that pass them and survive
+// pruning are type checked exactly by a ViabilityChecker, so that mutants
+// which would not compile (e.g. unused variables after statement removal)
+// never reach the toolchain.
+func (tc *TypeChecker) IsValidMutation(node ast.Node, mutant Mutant) bool {
+	return tc.isValidForType(node, mutant)
+}
+
+// isValidForType checks if a mutation is valid for the type of the given node.
+//
 // When adding a new mutation type, you MUST add a case here explicitly.
 // This ensures that type-based validation is considered for every mutation type.
-func (tc *TypeChecker) IsValidMutation(node ast.Node, mutant Mutant) bool {
+func (tc *TypeChecker) isValidForType(node ast.Node, mutant Mutant) bool {
 	if tc.typeInfo == nil {
 		// No type info available, assume valid
 		return true
@@ -48,7 +58,69 @@ func (tc *TypeChecker) IsValidMutation(node ast.Node, mutant Mutant) bool {
 		returnBoolLiteralType,
 		returnZeroValueType,
 		branchConditionType,
-		errorNilifyType:
+		errorNilifyType,
+		// Error mutants are generated from type information already; the
+		// viability check catches e.g. a shadowed errors package.
+		errorSentinelType,
+		errorWrapVerbType,
+		errorIsNegateType,
+		errorCheckRemovalType,
+		// Concurrency mutants only match sync methods and builtins when
+		// type information is available.
+		lockPairRemovalType,
+		rlockToLockType,
+		waitGroupAddRemovalType,
+		waitGroupDoneRemovalType,
+		closeRemovalType,
+		chanBufferSizeType,
+		selectCaseRemovalType,
+		selectDefaultInsertType,
+		selectDefaultRemovalType,
+		// Context mutants are generated from context.Context typed values.
+		contextBackgroundType,
+		contextCancelRemovalType,
+		contextDoneRemovalType,
+		contextTimeoutType,
+		// Index offsets are only generated for slices, arrays and strings;
+		// the viability check catches constant indexes going out of range.
+		sliceBoundType,
+		indexOffsetType,
+		lenDecrementType,
+		appendRemovalType,
+		elementRemovalType,
+		mapLookupOkFalseType,
+		switchCaseRemovalType,
+		switchCaseBodyRemovalType,
+		switchDefaultSwapType,
+		fallthroughRemovalType,
+		typeSwitchTypeRemovalType,
+		// Call swaps are resolved through types.Info.Uses; configured
+		// siblings with a different signature fail the viability check.
+		callSwapType,
+		callResultType,
+		callLessInversionType,
+		// Generics mutants are generated for type parameters only; the
+		// zero value *new(T) is valid for every constraint.
+		cmpArgsSwapType,
+		typeParamZeroCompareType,
+		typeParamZeroReturnType,
+		// Constants are only negated for signed operands; the viability
+		// check catches overflows and negative indexes and lengths.
+		constantValueType,
+		durationUnitType,
+		// Removing a panic may leave a function without a terminating
+		// statement; the viability check catches it.
+		deferRemovalType,
+		deferImmediateType,
+		recoverRemovalType,
+		panicRemovalType,
+		panicToReturnType,
+		// Copies that are used where a pointer is required, and the other
+		// way round, are caught by the viability check.
+		addressOfRemovalType,
+		structFieldRemovalType,
+		fieldAssignmentRemovalType,
+		pointerCopySwapType:
 		return true
 
 	default:
@@ -123,9 +195,7 @@ func (tc *TypeChecker) isValidConditionalMutation(node ast.Node, mutant Mutant)
 
 // isNilIdent checks if an expression is the nil identifier.
 func (tc *TypeChecker) isNilIdent(expr ast.Expr) bool {
-	ident, ok := expr.(*ast.Ident)
-
-	return ok && ident.Name == nilIdentName
+	return isNilIdent(expr)
 }
 
 // getExprType returns the type of an expression.
@@ -235,6 +305,12 @@ func isInvalidType(t types.Type) bool {
 
 // isArithmeticOpValidForType checks if an arithmetic operator is valid for a type.
 func (tc *TypeChecker) isArithmeticOpValidForType(t types.Type, op string) bool {
+	if tp, ok := types.Unalias(t).(*types.TypeParam); ok {
+		return allTypeSetTerms(tp, func(term types.Type) bool {
+			return tc.isArithmeticOpValidForType(term, op)
+		})
+	}
+
 	underlying := t.Underlying()
 
 	switch underlying := underlying.(type) {
@@ -246,9 +322,10 @@ func (tc *TypeChecker) isArithmeticOpValidForType(t types.Type, op string) bool
 			return op == "+"
 		}
 
-		// Numeric types: all arithmetic operators are valid
+		// Numeric types: all arithmetic operators are valid, except that
+		// % requires integers
 		if info&types.IsNumeric != 0 {
-			return true
+			return op != "%" || info&types.IsInteger != 0
 		}
 
 		return false
@@ -261,6 +338,18 @@ func (tc *TypeChecker) isArithmeticOpValidForType(t types.Type, op string) bool
 
 // isComparisonOpValidForType checks if a comparison operator is valid for a type.
 func (tc *TypeChecker) isComparisonOpValidForType(t types.Type, op string) bool {
+	if tp, ok := types.Unalias(t).(*types.TypeParam); ok {
+		// Equality only requires a comparable constraint, which the
+		// original comparison already relies on.
+		if op == "==" || op == "!=" {
+			return types.Comparable(tp)
+		}
+
+		return allTypeSetTerms(tp, func(term types.Type) bool {
+			return tc.isComparisonOpValidForType(term, op)
+		})
+	}
+
 	underlying := t.Underlying()
 
 	switch underlying := underlying.(type) {
@@ -297,6 +386,79 @@ func (tc *TypeChecker) isComparisonOpValidForType(t types.Type, op string) bool
 	}
 }
 
+// allTypeSetTerms reports whether valid holds for every type in the type set
+// of a type parameter's constraint. Constraints without type terms, such as
+// any and comparable, do not permit any operator, so they report false.
+func allTypeSetTerms(tp *types.TypeParam, valid func(types.Type) bool) bool {
+	iface, ok := tp.Constraint().Underlying().(*types.Interface)
+	if !ok {
+		return false
+	}
+
+	terms, restricted := interfaceTerms(iface)
+	if !restricted {
+		return false
+	}
+
+	for _, term := range terms {
+		if !valid(term) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// interfaceTerms returns the types of the type terms embedded in an
+// interface, and whether the interface restricts its type set by them.
+//
+// The type set of an interface is the intersection of its embedded
+// elements; the union of their terms is returned instead, which may only
+// reject operators the intersection would permit.
+func interfaceTerms(iface *types.Interface) ([]types.Type, bool) {
+	var (
+		terms      []types.Type
+		restricted bool
+	)
+
+	for i := range iface.NumEmbeddeds() {
+		embedded, ok := elementTerms(iface.EmbeddedType(i))
+		if !ok {
+			continue
+		}
+
+		terms = append(terms, embedded...)
+		restricted = true
+	}
+
+	return terms, restricted
+}
+
+// elementTerms returns the types of an interface element: a union, an
+// embedded interface or a single type.
+func elementTerms(t types.Type) ([]types.Type, bool) {
+	switch elem := t.Underlying().(type) {
+	case *types.Union:
+		var terms []types.Type
+
+		for i := range elem.Len() {
+			sub, ok := elementTerms(elem.Term(i).Type())
+			if !ok {
+				// A union with an unrestricted interface, e.g. any | int.
+				return nil, false
+			}
+
+			terms = append(terms, sub...)
+		}
+
+		return terms, true
+	case *types.Interface:
+		return interfaceTerms(elem)
+	default:
+		return []types.Type{t}, true
+	}
+}
+
 // assignToBinaryOp converts an assignment operator string to binary operator string.
 func (tc *TypeChecker) assignToBinaryOp(op string) string {
 	switch op {
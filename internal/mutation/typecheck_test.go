@@ -2,6 +2,7 @@ package mutation
 
 import (
 	"go/ast"
+	"go/importer"
 	"go/parser"
 	"go/token"
 	"go/types"
@@ -258,6 +259,143 @@ func foo() bool {
 	}
 }
 
+func TestTypeChecker_TypeParameters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		code     string
+		mutant   Mutant
+		expected bool
+	}{
+		{
+			name: "subtraction valid for every numeric term",
+			code: `package test
+type number interface{ ~int | ~int64 | ~float64 }
+func sum[T number](a, b T) T { return a + b }`,
+			mutant:   Mutant{Type: arithmeticBinaryType, Mutated: "-"},
+			expected: true,
+		},
+		{
+			name: "remainder invalid for float terms",
+			code: `package test
+type number interface{ ~int | ~float64 }
+func mul[T number](a, b T) T { return a * b }`,
+			mutant:   Mutant{Type: arithmeticBinaryType, Mutated: "%"},
+			expected: false,
+		},
+		{
+			name: "remainder invalid for floats",
+			code: `package test
+func mul(a, b float64) float64 { return a * b }`,
+			mutant:   Mutant{Type: arithmeticBinaryType, Mutated: "%"},
+			expected: false,
+		},
+		{
+			name: "subtraction invalid with a string term",
+			code: `package test
+import "cmp"
+func add[T cmp.Ordered](a, b T) T { return a + b }`,
+			mutant:   Mutant{Type: arithmeticBinaryType, Mutated: "-"},
+			expected: false,
+		},
+		{
+			name: "compound assignment through nested constraints",
+			code: `package test
+type signed interface{ ~int | ~int32 }
+type integer interface{ signed | ~uint }
+func inc[T integer](a, b T) T { a += b; return a }`,
+			mutant:   Mutant{Type: arithmeticAssignType, Mutated: "*="},
+			expected: true,
+		},
+		{
+			name: "constraint with methods and type terms",
+			code: `package test
+type celsius interface {
+	~int | ~float64
+	String() string
+}
+func avg[T celsius](a, b T) T { return (a + b) / 2 }`,
+			mutant:   Mutant{Type: arithmeticBinaryType, Mutated: "*"},
+			expected: true,
+		},
+		{
+			name: "ordering valid for cmp.Ordered",
+			code: `package test
+import "cmp"
+func less[T cmp.Ordered](a, b T) bool { return a < b }`,
+			mutant:   Mutant{Type: conditionalBinaryType, Mutated: ">="},
+			expected: true,
+		},
+		{
+			name: "ordering invalid for comparable",
+			code: `package test
+func eq[T comparable](a, b T) bool { return a == b }`,
+			mutant:   Mutant{Type: conditionalBinaryType, Mutated: "<"},
+			expected: false,
+		},
+		{
+			name: "equality valid for comparable",
+			code: `package test
+func eq[T comparable](a, b T) bool { return a == b }`,
+			mutant:   Mutant{Type: conditionalBinaryType, Mutated: "!="},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+
+			f, err := parser.ParseFile(fset, "test.go", tt.code, 0)
+			if err != nil {
+				t.Fatalf("failed to parse code: %v", err)
+			}
+
+			info := &types.Info{
+				Types: make(map[ast.Expr]types.TypeAndValue),
+			}
+
+			config := &types.Config{Importer: importer.Default()}
+			if _, err := config.Check("test", fset, []*ast.File{f}, info); err != nil {
+				t.Fatalf("failed to type check: %v", err)
+			}
+
+			// The mutated node is the first binary expression or compound
+			// assignment of the last function.
+			decl, ok := f.Decls[len(f.Decls)-1].(*ast.FuncDecl)
+			if !ok {
+				t.Fatal("last declaration is not a function")
+			}
+
+			var node ast.Node
+
+			ast.Inspect(decl.Body, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.BinaryExpr:
+					node = n
+				case *ast.AssignStmt:
+					if n.Tok != token.ASSIGN && n.Tok != token.DEFINE {
+						node = n
+					}
+				}
+
+				return node == nil
+			})
+
+			if node == nil {
+				t.Fatal("no mutated node found")
+			}
+
+			if got := NewTypeChecker(info).IsValidMutation(node, tt.mutant); got != tt.expected {
+				t.Errorf("IsValidMutation() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestFilterMutants(t *testing.T) {
 	code := `package test
 func foo() {
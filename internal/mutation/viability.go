@@ -0,0 +1,213 @@
+package mutation
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/sivchari/gomu/internal/analysis"
+)
+
+var (
+	// errNotApplied is returned when no mutator can apply a mutant in memory.
+	errNotApplied = errors.New("mutation could not be applied")
+	// errTypeErrors is returned when the original package does not type check.
+	errTypeErrors = errors.New("package has type errors")
+)
+
+// ViabilityChecker decides whether a mutant compiles by applying it to a fresh
+// copy of the file's AST and type checking it with go/types together with the
+// declarations of the other files of its package, without invoking the go
+// toolchain. Imports resolve to the packages checked when the package was
+// loaded.
+//
+// The function bodies of the other files are not checked again: a mutant only
+// changes its own file, and the other files only contribute declarations to
+// it. Mutants that break other files, e.g. by changing a constant used as an
+// array index there, are left to execution.
+type ViabilityChecker struct {
+	pkg      *analysis.LoadedPackage
+	filePath string
+	src      []byte
+	others   []*ast.File
+	// files are the token files of others, ordered by base.
+	files    []*token.File
+	mutators []Mutator
+	fallback types.Importer
+}
+
+// NewViabilityChecker creates a checker for mutants of filePath, which must be
+// one of the files of pkg. fset is the file set pkg was loaded with.
+func NewViabilityChecker(fset *token.FileSet, pkg *analysis.LoadedPackage, filePath string, mutators []Mutator) (*ViabilityChecker, error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	if _, ok := pkg.Files[absPath]; !ok {
+		return nil, fmt.Errorf("file %s is not part of package %s", filePath, pkg.Dir)
+	}
+
+	if len(pkg.Errors) > 0 {
+		// Type errors in the unmodified package would make every mutant
+		// look broken.
+		return nil, fmt.Errorf("%w: %s: %v", errTypeErrors, pkg.Dir, pkg.Errors)
+	}
+
+	src, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	// Unchanged files are shared between checks; go/types does not modify
+	// the AST it checks.
+	others := make([]*ast.File, 0, len(pkg.Files)-1)
+	files := make([]*token.File, 0, len(pkg.Files)-1)
+
+	for path, file := range pkg.Files {
+		if path != absPath {
+			others = append(others, withoutBodies(file))
+			files = append(files, fset.File(file.Pos()))
+		}
+	}
+
+	slices.SortFunc(files, func(a, b *token.File) int { return a.Base() - b.Base() })
+
+	return &ViabilityChecker{
+		pkg:      pkg,
+		filePath: absPath,
+		src:      src,
+		others:   others,
+		files:    files,
+		mutators: mutators,
+		fallback: importer.Default(),
+	}, nil
+}
+
+// Check applies mutant to a fresh parse of the file and type checks it with
+// the rest of the package. It returns the first type error, or nil if the
+// mutant compiles.
+//
+// The file is parsed into a file set of its own, since a file set never
+// releases its files.
+func (vc *ViabilityChecker) Check(mutant Mutant) error {
+	fset := vc.newFileSet()
+
+	file, err := parser.ParseFile(fset, vc.filePath, vc.src, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	if !ApplyMutant(fset, file, mutant, vc.mutators) {
+		return errNotApplied
+	}
+
+	files := make([]*ast.File, 0, len(vc.others)+1)
+	files = append(files, vc.others...)
+	files = append(files, file)
+
+	var firstErr error
+
+	config := &types.Config{
+		Importer: vc,
+		Error: func(err error) {
+			// Imports of the other files that are only used in their
+			// bodies are reported as unused.
+			if typeErr, ok := err.(types.Error); ok && typeErr.Soft && typeErr.Fset.File(typeErr.Pos) != fset.File(file.Pos()) {
+				return
+			}
+
+			if firstErr == nil {
+				firstErr = err
+			}
+		},
+	}
+
+	_, _ = config.Check(vc.pkg.Types.Path(), fset, files, nil)
+
+	return firstErr
+}
+
+// newFileSet returns a file set with the unchanged files of the package at
+// the positions of their shared ASTs, so that it can be used to type check
+// them with a fresh parse of the mutated file.
+func (vc *ViabilityChecker) newFileSet() *token.FileSet {
+	fset := token.NewFileSet()
+
+	for _, file := range vc.files {
+		fset.AddFile(file.Name(), file.Base(), file.Size()).SetLines(file.Lines())
+	}
+
+	return fset
+}
+
+// withoutBodies returns a copy of file whose function declarations have no
+// bodies. file itself is left unchanged.
+func withoutBodies(file *ast.File) *ast.File {
+	stripped := *file
+	stripped.Decls = make([]ast.Decl, len(file.Decls))
+
+	for i, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+			copied := *fn
+			copied.Body = nil
+			decl = &copied
+		}
+
+		stripped.Decls[i] = decl
+	}
+
+	return &stripped
+}
+
+// filterViable drops the mutants that do not type check and counts them as
+// filtered.
+func (e *Engine) filterViable(vc *ViabilityChecker, mutants []Mutant) []Mutant {
+	viable := mutants[:0]
+
+	for _, m := range mutants {
+		if !vc.IsViable(m) {
+			e.filtered++
+
+			continue
+		}
+
+		viable = append(viable, m)
+	}
+
+	return viable
+}
+
+// IsViable reports whether mutant type checks. Mutants that cannot be applied
+// in memory are reported as viable so that execution surfaces the failure.
+func (vc *ViabilityChecker) IsViable(mutant Mutant) bool {
+	err := vc.Check(mutant)
+
+	return err == nil || errors.Is(err, errNotApplied)
+}
+
+// Import resolves imports from the loaded package's dependencies, falling back
+// to the default importer for imports added by a mutation.
+func (vc *ViabilityChecker) Import(path string) (*types.Package, error) {
+	if path == "unsafe" {
+		return types.Unsafe, nil
+	}
+
+	if pkg, ok := vc.pkg.Imports[path]; ok {
+		return pkg, nil
+	}
+
+	pkg, err := vc.fallback.Import(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import %s: %w", path, err)
+	}
+
+	return pkg, nil
+}
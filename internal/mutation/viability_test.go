@@ -0,0 +1,388 @@
+package mutation
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sivchari/gomu/internal/analysis"
+)
+
+const viabilitySrc = `package calc
+
+func Sum(xs []int) int {
+	total := 0
+	for _, x := range xs {
+		total += x
+	}
+
+	return total
+}
+
+func Double(n int) int {
+	return n * 2
+}
+`
+
+// newViabilityFixture writes viabilitySrc into a module and returns a checker
+// for it together with all mutants the registered mutators generate.
+func newViabilityFixture(t *testing.T) (*ViabilityChecker, []Mutant) {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "calc.go")
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/calc\n\ngo 1.21\n"), 0600); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+
+	if err := os.WriteFile(filePath, []byte(viabilitySrc), 0600); err != nil {
+		t.Fatalf("Failed to write calc.go: %v", err)
+	}
+
+	analyzer, err := analysis.New()
+	if err != nil {
+		t.Fatalf("Failed to create analyzer: %v", err)
+	}
+
+	fileInfo, err := analyzer.ParseFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+
+	if fileInfo.Package == nil {
+		t.Fatal("Expected package to be loaded")
+	}
+
+	mutators := getAllMutators()
+
+	vc, err := NewViabilityChecker(analyzer.GetFileSet(), fileInfo.Package, filePath, mutators)
+	if err != nil {
+		t.Fatalf("NewViabilityChecker() error = %v", err)
+	}
+
+	var mutants []Mutant
+
+	ast.Inspect(fileInfo.FileAST, func(node ast.Node) bool {
+		if node == nil {
+			return false
+		}
+
+		for _, m := range mutators {
+			if m.CanMutate(node) {
+				mutants = append(mutants, m.Mutate(node, analyzer.GetFileSet())...)
+			}
+		}
+
+		return true
+	})
+
+	return vc, mutants
+}
+
+// findMutant returns the mutant with the given type and mutated value on line.
+func findMutant(t *testing.T, mutants []Mutant, mutantType, mutated string, line int) Mutant {
+	t.Helper()
+
+	for _, m := range mutants {
+		if m.Type == mutantType && m.Mutated == mutated && m.Line == line {
+			return m
+		}
+	}
+
+	t.Fatalf("No %s mutant to %q found", mutantType, mutated)
+
+	return Mutant{}
+}
+
+func TestViabilityChecker_IsViable(t *testing.T) {
+	t.Parallel()
+
+	vc, mutants := newViabilityFixture(t)
+
+	tests := []struct {
+		name   string
+		mutant Mutant
+		want   bool
+	}{
+		{
+			name:   "operator replacement compiles",
+			mutant: findMutant(t, mutants, arithmeticBinaryType, "/", 13),
+			want:   true,
+		},
+		{
+			name:   "emptied function body misses its return",
+			mutant: findMutant(t, mutants, emptyBlockType, "{}", 3),
+			want:   false,
+		},
+		{
+			name:   "emptied loop body leaves range variable unused",
+			mutant: findMutant(t, mutants, emptyBlockType, "{}", 5),
+			want:   false,
+		},
+		{
+			name:   "mutant that cannot be applied is left to execution",
+			mutant: Mutant{Type: "unknown_type", Line: 1, Column: 1},
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := vc.IsViable(tt.mutant); got != tt.want {
+				t.Errorf("IsViable() = %v, want %v (Check error: %v)", got, tt.want, vc.Check(tt.mutant))
+			}
+		})
+	}
+}
+
+func TestViabilityChecker_CheckReportsTypeError(t *testing.T) {
+	t.Parallel()
+
+	vc, mutants := newViabilityFixture(t)
+
+	err := vc.Check(findMutant(t, mutants, emptyBlockType, "{}", 5))
+	if err == nil {
+		t.Fatal("Expected a type error")
+	}
+
+	if !strings.Contains(err.Error(), "declared and not used: x") {
+		t.Errorf("Expected unused variable error, got %v", err)
+	}
+}
+
+func TestNewViabilityChecker_FileNotInPackage(t *testing.T) {
+	t.Parallel()
+
+	pkg := &analysis.LoadedPackage{Dir: "/repo", Files: map[string]*ast.File{}}
+
+	if _, err := NewViabilityChecker(nil, pkg, "/repo/missing.go", nil); err == nil {
+		t.Error("Expected error for a file outside the package")
+	}
+}
+
+const genericViabilitySrc = `package calc
+
+import "cmp"
+
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+func Sum[T Number](xs []T) T {
+	var total T
+	for _, x := range xs {
+		total += x
+	}
+
+	return total / T(len(xs))
+}
+
+func Max[T cmp.Ordered](a, b T) T {
+	if a < b {
+		return b
+	}
+
+	return a + a
+}
+
+func Index[T comparable](xs []T, v T) int {
+	for i, x := range xs {
+		if x == v {
+			return i
+		}
+	}
+
+	return -1
+}
+`
+
+// TestTypeChecker_GenericConstraints checks that operator mutants on type
+// parameters which pass the per-type checks also compile, i.e. that no
+// mutant violates the constraint of its operands.
+func TestTypeChecker_GenericConstraints(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "calc.go")
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/calc\n\ngo 1.21\n"), 0600); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+
+	if err := os.WriteFile(filePath, []byte(genericViabilitySrc), 0600); err != nil {
+		t.Fatalf("Failed to write calc.go: %v", err)
+	}
+
+	analyzer, err := analysis.New()
+	if err != nil {
+		t.Fatalf("Failed to create analyzer: %v", err)
+	}
+
+	fileInfo, err := analyzer.ParseFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+
+	mutators := getAllMutators()
+
+	vc, err := NewViabilityChecker(analyzer.GetFileSet(), fileInfo.Package, filePath, mutators)
+	if err != nil {
+		t.Fatalf("NewViabilityChecker() error = %v", err)
+	}
+
+	for _, m := range mutators {
+		if tam, ok := m.(TypeAwareMutator); ok {
+			tam.Prepare(fileInfo.FileAST, fileInfo.TypeInfo)
+		}
+	}
+
+	constraintTypes := map[string]bool{
+		arithmeticBinaryType:     true,
+		arithmeticAssignType:     true,
+		conditionalBinaryType:    true,
+		cmpArgsSwapType:          true,
+		typeParamZeroCompareType: true,
+		typeParamZeroReturnType:  true,
+	}
+
+	tc := NewTypeChecker(fileInfo.TypeInfo)
+	checked := 0
+
+	ast.Inspect(fileInfo.FileAST, func(node ast.Node) bool {
+		if node == nil {
+			return false
+		}
+
+		for _, m := range mutators {
+			if !m.CanMutate(node) {
+				continue
+			}
+
+			for _, mutant := range m.Mutate(node, analyzer.GetFileSet()) {
+				if !constraintTypes[mutant.Type] || !tc.IsValidMutation(node, mutant) {
+					continue
+				}
+
+				checked++
+
+				if err := vc.Check(mutant); err != nil {
+					t.Errorf("%s mutant %s -> %s on line %d does not compile: %v", mutant.Type, mutant.Original, mutant.Mutated, mutant.Line, err)
+				}
+			}
+		}
+
+		return true
+	})
+
+	if checked == 0 {
+		t.Fatal("no mutants checked")
+	}
+}
+
+func TestViabilityChecker_SharedFileSetUnchanged(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":    "module example.com/calc\n\ngo 1.21\n",
+		"helper.go": "package calc\n\nfunc double(x int) int { return x * 2 }\n",
+		"calc.go":   "package calc\n\nfunc Calc(a, b int) int {\n\treturn double(a) + b\n}\n",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	analyzer, err := analysis.New()
+	if err != nil {
+		t.Fatalf("Failed to create analyzer: %v", err)
+	}
+
+	filePath := filepath.Join(tmpDir, "calc.go")
+
+	fileInfo, err := analyzer.ParseFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+
+	vc, err := NewViabilityChecker(analyzer.GetFileSet(), fileInfo.Package, filePath, getAllMutators())
+	if err != nil {
+		t.Fatalf("NewViabilityChecker() error = %v", err)
+	}
+
+	base := analyzer.GetFileSet().Base()
+	mutant := Mutant{Type: arithmeticBinaryType, Line: 4, Column: 9, Original: "+", Mutated: "-"}
+
+	for range 10 {
+		if err := vc.Check(mutant); err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+	}
+
+	if got := analyzer.GetFileSet().Base(); got != base {
+		t.Errorf("Shared file set grew from base %d to %d", base, got)
+	}
+}
+
+func TestViabilityChecker_OtherFilesWithoutBodies(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	// strings is only used in the body of shout, which is not checked again.
+	files := map[string]string{
+		"go.mod":   "module example.com/calc\n\ngo 1.21\n",
+		"shout.go": "package calc\n\nimport \"strings\"\n\nfunc shout(s string) string { return strings.ToUpper(s) }\n",
+		"calc.go":  "package calc\n\nfunc Calc(a, b int) string {\n\tx := a + b\n\n\treturn shout(\"\") + string(rune(x))\n}\n",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	analyzer, err := analysis.New()
+	if err != nil {
+		t.Fatalf("Failed to create analyzer: %v", err)
+	}
+
+	filePath := filepath.Join(tmpDir, "calc.go")
+
+	fileInfo, err := analyzer.ParseFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+
+	vc, err := NewViabilityChecker(analyzer.GetFileSet(), fileInfo.Package, filePath, getAllMutators())
+	if err != nil {
+		t.Fatalf("NewViabilityChecker() error = %v", err)
+	}
+
+	viable := Mutant{Type: arithmeticBinaryType, Line: 4, Column: 7, Original: "+", Mutated: "-"}
+	if err := vc.Check(viable); err != nil {
+		t.Errorf("Check() error = %v, want nil", err)
+	}
+
+	// Errors in the mutated file are still reported.
+	emptied := Mutant{Type: emptyBlockType, Line: 3, Column: 28, Original: "{...}", Mutated: "{}"}
+	if err := vc.Check(emptied); err == nil || !strings.Contains(err.Error(), "missing return") {
+		t.Errorf("Check() error = %v, want missing return", err)
+	}
+
+	for path, file := range fileInfo.Package.Files {
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body == nil {
+				t.Errorf("Function %s of %s lost its body", fn.Name.Name, path)
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ package report
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"text/template"
@@ -15,20 +16,24 @@ import (
 // Generator handles report generation.
 type Generator struct {
 	outputFormat string
+	out          io.Writer
 }
 
 // Summary contains the complete results of a mutation testing run.
 type Summary struct {
-	TotalFiles     int                    `json:"totalFiles"`
-	ProcessedFiles int                    `json:"processedFiles"`
-	TotalMutants   int                    `json:"totalMutants"`
-	KilledMutants  int                    `json:"killedMutants"`
-	Results        []mutation.Result      `json:"results"`
-	Files          map[string]*FileReport `json:"files"`
-	Duration       time.Duration          `json:"duration"`
-	Statistics     Statistics             `json:"statistics"`
-	Timestamp      time.Time              `json:"timestamp"`
-	Version        string                 `json:"version,omitempty"`
+	TotalFiles       int                    `json:"totalFiles"`
+	ProcessedFiles   int                    `json:"processedFiles"`
+	TotalMutants     int                    `json:"totalMutants"`
+	FilteredMutants  int                    `json:"filteredMutants"`  // Mutants discarded by type checking before execution
+	DuplicateMutants int                    `json:"duplicateMutants"` // Mutants pruned as producing the same source as another mutant
+	SubsumedMutants  int                    `json:"subsumedMutants"`  // Mutants pruned as subsumed by other mutants at the same site
+	KilledMutants    int                    `json:"killedMutants"`
+	Results          []mutation.Result      `json:"results"`
+	Files            map[string]*FileReport `json:"files"`
+	Duration         time.Duration          `json:"duration"`
+	Statistics       Statistics             `json:"statistics"`
+	Timestamp        time.Time              `json:"timestamp"`
+	Version          string                 `json:"version,omitempty"`
 }
 
 // FileReport represents a report for a single file.
@@ -46,6 +51,7 @@ type Statistics struct {
 	TimedOut      int                       `json:"timedOut"`
 	Errors        int                       `json:"errors"`
 	NotViable     int                       `json:"notViable"`
+	Equivalent    int                       `json:"equivalent"`
 	Score         float64                   `json:"mutationScore"`
 	Coverage      float64                   `json:"lineCoverage,omitempty"`
 	MutationTypes map[string]TypeStatistics `json:"mutationTypes,omitempty"`
@@ -67,15 +73,22 @@ func New(outputFormat string) (*Generator, error) {
 
 	return &Generator{
 		outputFormat: outputFormat,
+		out:          os.Stdout,
 	}, nil
 }
 
+// SetOutput sets the writer for console reports and notices about written
+// report files. It defaults to standard output.
+func (g *Generator) SetOutput(w io.Writer) {
+	g.out = w
+}
+
 const gomuVersion = "0.1.0"
 
 // Generate creates and outputs the mutation testing report.
 func (g *Generator) Generate(summary *Summary) error {
 	// Calculate statistics
-	summary.Statistics = g.calculateStatistics(summary.Results)
+	summary.Statistics = CalculateStatistics(summary.Results)
 	summary.Timestamp = time.Now()
 	summary.Version = gomuVersion
 
@@ -95,7 +108,8 @@ func (g *Generator) Generate(summary *Summary) error {
 	}
 }
 
-func (g *Generator) calculateStatistics(results []mutation.Result) Statistics {
+// CalculateStatistics aggregates the statistics of results.
+func CalculateStatistics(results []mutation.Result) Statistics {
 	stats := Statistics{
 		MutationTypes: make(map[string]TypeStatistics),
 	}
@@ -112,6 +126,8 @@ func (g *Generator) calculateStatistics(results []mutation.Result) Statistics {
 			stats.Errors++
 		case mutation.StatusNotViable:
 			stats.NotViable++
+		case mutation.StatusEquivalent:
+			stats.Equivalent++
 		}
 
 		// Track mutation type statistics
@@ -133,8 +149,9 @@ func (g *Generator) calculateStatistics(results []mutation.Result) Statistics {
 		stats.MutationTypes[mutationType] = typeStats
 	}
 
-	// Calculate mutation score excluding NOT_VIABLE mutants
-	validMutants := len(results) - stats.NotViable
+	// Calculate mutation score excluding NOT_VIABLE and EQUIVALENT mutants,
+	// neither of which can be killed
+	validMutants := len(results) - stats.NotViable - stats.Equivalent
 	if validMutants > 0 {
 		stats.Score = float64(stats.Killed) / float64(validMutants) * 100
 	}
@@ -153,7 +170,7 @@ func (g *Generator) generateJSON(summary *Summary) error {
 		return fmt.Errorf("failed to write output file: %w", err)
 	}
 
-	fmt.Printf("Report written to %s\n", outputFile)
+	fmt.Fprintf(g.out, "Report written to %s\n", outputFile)
 
 	return nil
 }
@@ -166,7 +183,7 @@ func (g *Generator) generateText(summary *Summary) error {
 		return fmt.Errorf("failed to write output file: %w", err)
 	}
 
-	fmt.Printf("Report written to %s\n", outputFile)
+	fmt.Fprintf(g.out, "Report written to %s\n", outputFile)
 
 	return nil
 }
@@ -189,6 +206,11 @@ Results:
   Timed out:  %d (%.1f%%)
   Errors:     %d (%.1f%%)
   Not viable: %d (%.1f%%)
+  Equivalent: %d (%.1f%%)
+
+Filtered by type checking: %d
+Pruned as duplicates:      %d
+Pruned as subsumed:        %d
 
 Mutation Score: %.1f%%
 
@@ -201,6 +223,10 @@ Mutation Score: %.1f%%
 		stats.TimedOut, percentage(stats.TimedOut, summary.TotalMutants),
 		stats.Errors, percentage(stats.Errors, summary.TotalMutants),
 		stats.NotViable, percentage(stats.NotViable, summary.TotalMutants),
+		stats.Equivalent, percentage(stats.Equivalent, summary.TotalMutants),
+		summary.FilteredMutants,
+		summary.DuplicateMutants,
+		summary.SubsumedMutants,
 		stats.Score,
 	)
 
@@ -219,6 +245,10 @@ Mutation Score: %.1f%%
 					result.Mutant.Original,
 					result.Mutant.Mutated,
 				)
+
+				if result.Mutant.Diff != "" {
+					report += indent(result.Mutant.Diff, "    ")
+				}
 			}
 		}
 	}
@@ -226,9 +256,48 @@ Mutation Score: %.1f%%
 	return report
 }
 
+// indent prefixes every line of s with prefix.
+func indent(s, prefix string) string {
+	lines := strings.SplitAfter(strings.TrimSuffix(s, "\n"), "\n")
+
+	return prefix + strings.Join(lines, prefix) + "\n"
+}
+
+// diffLine is a line of a unified diff with the CSS class it is rendered with
+// in HTML reports.
+type diffLine struct {
+	Class string
+	Text  string
+}
+
+// diffLines splits a unified diff into lines classified for rendering.
+func diffLines(diff string) []diffLine {
+	var lines []diffLine
+
+	for _, text := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		class := "context"
+
+		switch {
+		case strings.HasPrefix(text, "---"), strings.HasPrefix(text, "+++"):
+			class = "file"
+		case strings.HasPrefix(text, "@@"):
+			class = "hunk"
+		case strings.HasPrefix(text, "-"):
+			class = "removed"
+		case strings.HasPrefix(text, "+"):
+			class = "added"
+		}
+
+		lines = append(lines, diffLine{Class: class, Text: text})
+	}
+
+	return lines
+}
+
 func (g *Generator) generateHTML(summary *Summary) error {
 	funcMap := template.FuncMap{
 		"percentage": percentage,
+		"diffLines":  diffLines,
 	}
 
 	tmpl, err := template.New("html_report").Funcs(funcMap).Parse(htmlTemplate)
@@ -246,7 +315,7 @@ func (g *Generator) generateHTML(summary *Summary) error {
 		return fmt.Errorf("failed to write HTML output file: %w", err)
 	}
 
-	fmt.Printf("Report written to %s\n", outputFile)
+	fmt.Fprintf(g.out, "Report written to %s\n", outputFile)
 
 	return nil
 }
@@ -406,6 +475,10 @@ const htmlTemplate = `<!DOCTYPE html>
         .stat-item.timed-out { border-left: 4px solid #f39c12; }
         .stat-item.error { border-left: 4px solid #e67e22; }
         .stat-item.not-viable { border-left: 4px solid #8e44ad; }
+        .stat-item.equivalent { border-left: 4px solid #17a2b8; }
+        .stat-item.filtered { border-left: 4px solid #95a5a6; }
+        .stat-item.duplicate { border-left: 4px solid #7f8c8d; }
+        .stat-item.subsumed { border-left: 4px solid #34495e; }
         .stat-number {
             font-size: 32px;
             font-weight: bold;
@@ -570,6 +643,10 @@ const htmlTemplate = `<!DOCTYPE html>
             background: #e8d5f0;
             color: #5a2d6e;
         }
+        .mutant-status.EQUIVALENT {
+            background: #d1ecf1;
+            color: #0c5460;
+        }
         .mutant-item.KILLED {
             border-left-color: #28a745;
         }
@@ -585,6 +662,9 @@ const htmlTemplate = `<!DOCTYPE html>
         .mutant-item.NOT_VIABLE {
             border-left-color: #8e44ad;
         }
+        .mutant-item.EQUIVALENT {
+            border-left-color: #17a2b8;
+        }
         .filters {
             margin-bottom: 20px;
             display: flex;
@@ -680,30 +760,32 @@ const htmlTemplate = `<!DOCTYPE html>
             font-family: 'Monaco', 'Consolas', monospace;
             color: #495057;
         }
-        .mutant-context {
+        .mutant-diff {
             margin-top: 15px;
             border-top: 1px solid #e0e0e0;
             padding-top: 15px;
         }
-        .mutant-context h4 {
+        .mutant-diff h4 {
             margin: 0 0 10px 0;
             color: #495057;
             font-size: 14px;
         }
-        .mutant-context pre {
+        .mutant-diff pre {
             background: #f8f9fa;
             padding: 12px;
             border-radius: 6px;
             border: 1px solid #e0e0e0;
             overflow-x: auto;
             margin: 0;
-        }
-        .mutant-context code {
             font-family: 'Monaco', 'Consolas', monospace;
             font-size: 13px;
-            color: #495057;
             line-height: 1.4;
         }
+        .mutant-diff .file { color: #6c757d; font-weight: 600; }
+        .mutant-diff .hunk { color: #6f42c1; }
+        .mutant-diff .removed { background: #ffeef0; color: #b31d28; }
+        .mutant-diff .added { background: #e6ffed; color: #22863a; }
+        .mutant-diff .context { color: #495057; }
         .test-execution {
             margin-top: 15px;
             border-top: 1px solid #e0e0e0;
@@ -892,7 +974,7 @@ const htmlTemplate = `<!DOCTYPE html>
                         
                         if (filter === 'all') {
                             shouldShow = true;
-                        } else if (filter === 'SURVIVED' || filter === 'KILLED' || filter === 'TIMED_OUT' || filter === 'ERROR' || filter === 'NOT_VIABLE') {
+                        } else if (filter === 'SURVIVED' || filter === 'KILLED' || filter === 'TIMED_OUT' || filter === 'ERROR' || filter === 'NOT_VIABLE' || filter === 'EQUIVALENT') {
                             shouldShow = status === filter;
                         } else {
                             shouldShow = type === filter;
@@ -987,6 +1069,24 @@ const htmlTemplate = `<!DOCTYPE html>
                         <div class="stat-number">{{.Statistics.NotViable}}</div>
                         <div class="stat-label">Not Viable ({{printf "%.1f" (percentage .Statistics.NotViable .TotalMutants)}}%)</div>
                     </div>
+                    <div class="stat-item equivalent">
+                        <div class="stat-number">{{.Statistics.Equivalent}}</div>
+                        <div class="stat-label">Equivalent ({{printf "%.1f" (percentage .Statistics.Equivalent .TotalMutants)}}%)</div>
+                    </div>
+                    <div class="stat-item filtered">
+                        <div class="stat-number">{{.FilteredMutants}}</div>
+                        <div class="stat-label">Filtered (type checking)</div>
+                    </div>
+                    <div class="stat-item duplicate">
+                        <div class="stat-number">{{.DuplicateMutants}}</div>
+                        <div class="stat-label">Pruned (duplicates)</div>
+                    </div>
+                    {{if .SubsumedMutants}}
+                    <div class="stat-item subsumed">
+                        <div class="stat-number">{{.SubsumedMutants}}</div>
+                        <div class="stat-label">Pruned (subsumed)</div>
+                    </div>
+                    {{end}}
                 </div>
             </div>
             
@@ -1046,6 +1146,7 @@ const htmlTemplate = `<!DOCTYPE html>
                     <button class="filter-btn" data-filter="SURVIVED">Survived</button>
                     <button class="filter-btn" data-filter="KILLED">Killed</button>
                     <button class="filter-btn" data-filter="NOT_VIABLE">Not Viable</button>
+                    <button class="filter-btn" data-filter="EQUIVALENT">Equivalent</button>
                     <button class="filter-btn" data-filter="arithmetic">Arithmetic</button>
                     <button class="filter-btn" data-filter="conditional">Conditional</button>
                     <button class="filter-btn" data-filter="logical">Logical</button>
@@ -1067,10 +1168,11 @@ const htmlTemplate = `<!DOCTYPE html>
                         <div class="mutant-change">
                             <span class="original">{{.Mutant.Original}}</span> → <span class="mutated">{{.Mutant.Mutated}}</span>
                         </div>
-                        {{if .Mutant.Context}}
-                        <div class="mutant-context">
-                            <h4>📝 Code Context</h4>
-                            <pre><code>{{.Mutant.Context}}</code></pre>
+                        {{if .Mutant.Diff}}
+                        <div class="mutant-diff">
+                            <h4>🔀 Diff</h4>
+                            <pre>{{range diffLines .Mutant.Diff}}<span class="{{.Class}}">{{html .Text}}</span>
+{{end}}</pre>
                         </div>
                         {{end}}
                         {{if .TestOutput}}
@@ -1119,20 +1221,24 @@ const htmlTemplate = `<!DOCTYPE html>
 func (g *Generator) generateConsole(summary *Summary) error {
 	stats := summary.Statistics
 
-	fmt.Println()
-	fmt.Println("Mutation Testing Results")
-	fmt.Println("========================")
-	fmt.Printf("Files: %d/%d processed\n", summary.ProcessedFiles, summary.TotalFiles)
-	fmt.Printf("Mutants: %d total\n", summary.TotalMutants)
-	fmt.Printf("Duration: %v\n", summary.Duration)
-	fmt.Println()
-	fmt.Printf("Killed:     %d (%.1f%%)\n", stats.Killed, percentage(stats.Killed, summary.TotalMutants))
-	fmt.Printf("Survived:   %d (%.1f%%)\n", stats.Survived, percentage(stats.Survived, summary.TotalMutants))
-	fmt.Printf("Timed out:  %d (%.1f%%)\n", stats.TimedOut, percentage(stats.TimedOut, summary.TotalMutants))
-	fmt.Printf("Errors:     %d (%.1f%%)\n", stats.Errors, percentage(stats.Errors, summary.TotalMutants))
-	fmt.Printf("Not viable: %d (%.1f%%)\n", stats.NotViable, percentage(stats.NotViable, summary.TotalMutants))
-	fmt.Println()
-	fmt.Printf("Mutation Score: %.1f%%\n", stats.Score)
+	fmt.Fprintln(g.out)
+	fmt.Fprintln(g.out, "Mutation Testing Results")
+	fmt.Fprintln(g.out, "========================")
+	fmt.Fprintf(g.out, "Files: %d/%d processed\n", summary.ProcessedFiles, summary.TotalFiles)
+	fmt.Fprintf(g.out, "Mutants: %d total\n", summary.TotalMutants)
+	fmt.Fprintf(g.out, "Duration: %v\n", summary.Duration)
+	fmt.Fprintln(g.out)
+	fmt.Fprintf(g.out, "Killed:     %d (%.1f%%)\n", stats.Killed, percentage(stats.Killed, summary.TotalMutants))
+	fmt.Fprintf(g.out, "Survived:   %d (%.1f%%)\n", stats.Survived, percentage(stats.Survived, summary.TotalMutants))
+	fmt.Fprintf(g.out, "Timed out:  %d (%.1f%%)\n", stats.TimedOut, percentage(stats.TimedOut, summary.TotalMutants))
+	fmt.Fprintf(g.out, "Errors:     %d (%.1f%%)\n", stats.Errors, percentage(stats.Errors, summary.TotalMutants))
+	fmt.Fprintf(g.out, "Not viable: %d (%.1f%%)\n", stats.NotViable, percentage(stats.NotViable, summary.TotalMutants))
+	fmt.Fprintf(g.out, "Equivalent: %d (%.1f%%)\n", stats.Equivalent, percentage(stats.Equivalent, summary.TotalMutants))
+	fmt.Fprintf(g.out, "Filtered:   %d (discarded by type checking)\n", summary.FilteredMutants)
+	fmt.Fprintf(g.out, "Duplicates: %d (pruned before execution)\n", summary.DuplicateMutants)
+	fmt.Fprintf(g.out, "Subsumed:   %d (pruned before execution)\n", summary.SubsumedMutants)
+	fmt.Fprintln(g.out)
+	fmt.Fprintf(g.out, "Mutation Score: %.1f%%\n", stats.Score)
 
 	return nil
 }
@@ -17,11 +17,6 @@ func abs(x float64) float64 {
 }
 
 func TestCalculateStatistics(t *testing.T) {
-	generator, err := New("json")
-	if err != nil {
-		t.Fatalf("Failed to create generator: %v", err)
-	}
-
 	results := []mutation.Result{
 		{Mutant: mutation.Mutant{ID: "1", Type: "arithmetic"}, Status: mutation.StatusKilled},
 		{Mutant: mutation.Mutant{ID: "2", Type: "arithmetic"}, Status: mutation.StatusKilled},
@@ -31,7 +26,7 @@ func TestCalculateStatistics(t *testing.T) {
 		{Mutant: mutation.Mutant{ID: "6", Type: "conditional"}, Status: mutation.StatusNotViable},
 	}
 
-	stats := generator.calculateStatistics(results)
+	stats := CalculateStatistics(results)
 
 	if stats.Killed != 2 {
 		t.Errorf("Expected Killed 2, got %d", stats.Killed)
@@ -121,12 +116,7 @@ func TestCalculateStatistics(t *testing.T) {
 }
 
 func TestCalculateStatistics_EmptyResults(t *testing.T) {
-	generator, err := New("json")
-	if err != nil {
-		t.Fatalf("Failed to create generator: %v", err)
-	}
-
-	stats := generator.calculateStatistics([]mutation.Result{})
+	stats := CalculateStatistics([]mutation.Result{})
 
 	if stats.Killed != 0 {
 		t.Errorf("Expected Killed 0, got %d", stats.Killed)
@@ -338,9 +328,10 @@ func TestFormatTextReport(t *testing.T) {
 	}
 
 	summary := &Summary{
-		TotalFiles:     1,
-		ProcessedFiles: 1,
-		TotalMutants:   2,
+		TotalFiles:      1,
+		ProcessedFiles:  1,
+		TotalMutants:    2,
+		FilteredMutants: 3,
 		Results: []mutation.Result{
 			{
 				Mutant: mutation.Mutant{
@@ -352,6 +343,7 @@ func TestFormatTextReport(t *testing.T) {
 					Original:    "+",
 					Mutated:     "-",
 					Description: "Replace + with -",
+					Diff:        "--- a/test.go\n+++ b/test.go\n@@ -10 +10 @@\n-\treturn a + b\n+\treturn a - b\n",
 				},
 				Status: mutation.StatusSurvived,
 			},
@@ -383,6 +375,10 @@ func TestFormatTextReport(t *testing.T) {
 		t.Error("Report should contain duration")
 	}
 
+	if !strings.Contains(report, "Filtered by type checking: 3") {
+		t.Error("Report should contain filtered mutant count")
+	}
+
 	if !strings.Contains(report, "Mutation Score: 0.0%") {
 		t.Error("Report should contain mutation score")
 	}
@@ -395,6 +391,10 @@ func TestFormatTextReport(t *testing.T) {
 	if !strings.Contains(report, "test.go:10:5 - Replace + with - (+ -> -)") {
 		t.Error("Report should contain survived mutant details")
 	}
+
+	if !strings.Contains(report, "    @@ -10 +10 @@\n    -\treturn a + b\n    +\treturn a - b\n") {
+		t.Error("Report should contain the indented diff of survived mutants")
+	}
 }
 
 func TestFormatTextReport_NoSurvivedMutants(t *testing.T) {
@@ -451,7 +451,7 @@ func TestGenerateHTML(t *testing.T) {
 					Mutated:     "-",
 					Description: "Replace + with -",
 					Function:    "calculateSum",
-					Context:     "func calculateSum(a, b int) int {\n    return a + b\n}",
+					Diff:        "@@ -2 +2 @@\n-\treturn a < b\n+\treturn a <= b\n",
 				},
 				Status:        mutation.StatusKilled,
 				ExecutionTime: 120,
@@ -490,7 +490,6 @@ func TestGenerateHTML(t *testing.T) {
 					Mutated:     "!=",
 					Description: "Replace == with !=",
 					Function:    "checkEqual",
-					Context:     "func checkEqual(a, b int) bool {\n    return a == b\n}",
 				},
 				Status:        mutation.StatusSurvived,
 				ExecutionTime: 85,
@@ -559,6 +558,11 @@ func TestGenerateHTML(t *testing.T) {
 		"Survived",
 		"test.go:15:8",
 		"Replace == with !=",
+		`<span class="hunk">@@ -2 +2 @@</span>`,
+		`<span class="removed">-	return a &lt; b</span>`,
+		`<span class="added">+	return a &lt;= b</span>`,
+		`<div class="stat-item filtered">`,
+		`<div class="stat-item duplicate">`,
 	}
 
 	for _, element := range expectedElements {
@@ -590,11 +594,6 @@ func TestPercentage(t *testing.T) {
 }
 
 func TestCalculateStatistics_UnknownMutationType(t *testing.T) {
-	generator, err := New("json")
-	if err != nil {
-		t.Fatalf("Failed to create generator: %v", err)
-	}
-
 	results := []mutation.Result{
 		{
 			Mutant: mutation.Mutant{
@@ -612,7 +611,7 @@ func TestCalculateStatistics_UnknownMutationType(t *testing.T) {
 		},
 	}
 
-	stats := generator.calculateStatistics(results)
+	stats := CalculateStatistics(results)
 
 	// Check that unknown type is tracked
 	if _, ok := stats.MutationTypes["unknown"]; !ok {
@@ -634,11 +633,6 @@ func TestCalculateStatistics_UnknownMutationType(t *testing.T) {
 }
 
 func TestCalculateStatistics_AllNotViable(t *testing.T) {
-	generator, err := New("json")
-	if err != nil {
-		t.Fatalf("Failed to create generator: %v", err)
-	}
-
 	results := []mutation.Result{
 		{
 			Mutant: mutation.Mutant{ID: "1"},
@@ -650,7 +644,7 @@ func TestCalculateStatistics_AllNotViable(t *testing.T) {
 		},
 	}
 
-	stats := generator.calculateStatistics(results)
+	stats := CalculateStatistics(results)
 
 	// When all mutants are not viable, score should be 0
 	if stats.Score != 0 {
@@ -658,6 +652,26 @@ func TestCalculateStatistics_AllNotViable(t *testing.T) {
 	}
 }
 
+func TestCalculateStatistics_Equivalent(t *testing.T) {
+	results := []mutation.Result{
+		{Mutant: mutation.Mutant{ID: "1"}, Status: mutation.StatusKilled},
+		{Mutant: mutation.Mutant{ID: "2"}, Status: mutation.StatusSurvived},
+		{Mutant: mutation.Mutant{ID: "3"}, Status: mutation.StatusEquivalent},
+		{Mutant: mutation.Mutant{ID: "4"}, Status: mutation.StatusEquivalent},
+	}
+
+	stats := CalculateStatistics(results)
+
+	if stats.Equivalent != 2 {
+		t.Errorf("Expected Equivalent 2, got %d", stats.Equivalent)
+	}
+
+	// Score should be 1/2 * 100 = 50.0 (excluding EQUIVALENT)
+	if abs(stats.Score-50.0) > 0.000001 {
+		t.Errorf("Expected Score 50.0, got %f", stats.Score)
+	}
+}
+
 func TestGenerateJSON_WriteError(t *testing.T) {
 	generator, err := New("json")
 	if err != nil {
@@ -753,7 +767,6 @@ func TestGenerateHTML_ComplexData(t *testing.T) {
 					Mutated:     ">",
 					Description: "Replace < with >",
 					Function:    "Compare<T>",
-					Context:     "if a < b { return \"less\" }",
 				},
 				Status: mutation.StatusKilled,
 				TestOutput: []mutation.TestInfo{
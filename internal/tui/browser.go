@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/sivchari/gomu/pkg/gomu"
+)
+
+// ANSI escape sequences used to color the browser.
+const (
+	colorRed   = "\x1b[31m"
+	colorGreen = "\x1b[32m"
+	colorCyan  = "\x1b[36m"
+	reverse    = "\x1b[7m"
+	reset      = "\x1b[0m"
+)
+
+// key is a key pressed in the browser.
+type key int
+
+const (
+	keyNone key = iota
+	keyUp
+	keyDown
+	keyQuit
+)
+
+// readKey reads the next key pressed from r.
+func readKey(r *bufio.Reader) (key, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return keyNone, fmt.Errorf("failed to read key: %w", err)
+	}
+
+	switch b {
+	case 'k':
+		return keyUp, nil
+	case 'j':
+		return keyDown, nil
+	case 'q', 3: // 3 is Ctrl-C in raw mode
+		return keyQuit, nil
+	case 0x1b:
+		// Arrow keys are sent as ESC [ A and ESC [ B.
+		if next, err := r.ReadByte(); err != nil || next != '[' {
+			return keyNone, nil
+		}
+
+		arrow, err := r.ReadByte()
+		if err != nil {
+			return keyNone, nil
+		}
+
+		switch arrow {
+		case 'A':
+			return keyUp, nil
+		case 'B':
+			return keyDown, nil
+		}
+	}
+
+	return keyNone, nil
+}
+
+// browser lists the survived mutants and shows the diff of the selected one.
+type browser struct {
+	// dir is the directory file paths are shown relative to.
+	dir       string
+	survivors []gomu.Result
+	selected  int
+}
+
+// handle moves the selection for k and reports whether browsing goes on.
+func (b *browser) handle(k key) bool {
+	switch k {
+	case keyUp:
+		b.selected = max(b.selected-1, 0)
+	case keyDown:
+		b.selected = min(b.selected+1, len(b.survivors)-1)
+	case keyQuit:
+		return false
+	case keyNone:
+	}
+
+	return true
+}
+
+// render renders the browser in a screen of width columns and height rows.
+func (b *browser) render(width, height int) []string {
+	lines := []string{
+		truncate(fmt.Sprintf("Survived mutants: %d   ↑/↓ or j/k move, q quits", len(b.survivors)), width),
+		"",
+	}
+
+	// The list takes up to half of the screen, scrolled to the selection.
+	listHeight := min(len(b.survivors), max((height-len(lines))/2, 1))
+	first := min(max(b.selected-listHeight+1, 0), len(b.survivors)-listHeight)
+
+	for i := first; i < first+listHeight; i++ {
+		mutant := b.survivors[i].Mutant
+
+		line := truncate(fmt.Sprintf("  %s:%d:%d  %s", relativePath(b.dir, mutant.FilePath), mutant.Line, mutant.Column, mutant.Description), width)
+		if i == b.selected {
+			line = reverse + line + reset
+		}
+
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "")
+
+	mutant := b.survivors[b.selected].Mutant
+	if mutant.Function != "" {
+		lines = append(lines, truncate("Function: "+mutant.Function, width))
+	}
+
+	lines = append(lines, truncate(fmt.Sprintf("Mutation: %s -> %s", mutant.Original, mutant.Mutated), width), "")
+
+	for _, line := range strings.Split(strings.TrimRight(mutant.Diff, "\n"), "\n") {
+		if len(lines) >= height {
+			break
+		}
+
+		lines = append(lines, colorDiffLine(truncate(line, width)))
+	}
+
+	return lines
+}
+
+// colorDiffLine colors a line of a unified diff.
+func colorDiffLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		return line
+	case strings.HasPrefix(line, "+"):
+		return colorGreen + line + reset
+	case strings.HasPrefix(line, "-"):
+		return colorRed + line + reset
+	case strings.HasPrefix(line, "@@"):
+		return colorCyan + line + reset
+	default:
+		return line
+	}
+}
@@ -0,0 +1,260 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sivchari/gomu/pkg/gomu"
+)
+
+// barWidth is the width of the progress bars.
+const barWidth = 30
+
+// progress is the state of a run, built from its events.
+type progress struct {
+	// dir is the directory file paths are shown relative to.
+	dir     string
+	workers int
+
+	totalFiles    int
+	analyzedFiles int
+	generated     int
+	running       int
+	finished      int
+	killed        int
+	survived      int
+	// excluded counts not viable and equivalent mutants, which cannot be
+	// killed and are left out of the score.
+	excluded int
+
+	start        time.Time
+	firstStarted time.Time
+	files        []*fileProgress
+	byPath       map[string]*fileProgress
+	survivors    []gomu.Result
+	done         bool
+}
+
+// fileProgress is the progress of the mutants of a file.
+type fileProgress struct {
+	path     string
+	mutants  int
+	finished int
+	killed   int
+	excluded int
+	err      string
+}
+
+// newProgress creates the state of a run tested by workers workers.
+func newProgress(dir string, workers int) *progress {
+	return &progress{
+		dir:     dir,
+		workers: workers,
+		byPath:  make(map[string]*fileProgress),
+	}
+}
+
+// handle updates the state with event.
+func (p *progress) handle(event gomu.Event) {
+	switch event.Type {
+	case gomu.EventRunStarted:
+		p.start = event.Time
+		p.totalFiles = event.Files
+	case gomu.EventFileAnalyzed:
+		p.analyzedFiles++
+		file := p.file(event.File)
+		file.mutants = event.Mutants
+		file.err = event.Error
+	case gomu.EventMutantGenerated:
+		p.generated++
+	case gomu.EventMutantStarted:
+		if p.firstStarted.IsZero() {
+			p.firstStarted = event.Time
+		}
+
+		p.running++
+	case gomu.EventMutantFinished:
+		p.running--
+		p.finished++
+		p.record(*event.Result)
+	case gomu.EventRunFinished:
+		p.done = true
+	}
+}
+
+// record counts the result of a finished mutant.
+func (p *progress) record(result gomu.Result) {
+	file := p.file(result.Mutant.FilePath)
+	file.finished++
+
+	switch result.Status {
+	case gomu.StatusKilled:
+		p.killed++
+		file.killed++
+	case gomu.StatusSurvived:
+		p.survived++
+		p.survivors = append(p.survivors, result)
+	case gomu.StatusNotViable, gomu.StatusEquivalent:
+		p.excluded++
+		file.excluded++
+	case gomu.StatusTimedOut, gomu.StatusError:
+	}
+}
+
+// file returns the progress of the file at path, adding it if needed.
+func (p *progress) file(path string) *fileProgress {
+	file, ok := p.byPath[path]
+	if !ok {
+		file = &fileProgress{path: path}
+		p.byPath[path] = file
+		p.files = append(p.files, file)
+	}
+
+	return file
+}
+
+// estimatedMutants estimates the number of mutants of the run, assuming the
+// files not analyzed yet have as many mutants as the analyzed ones on
+// average.
+func (p *progress) estimatedMutants() int {
+	if p.analyzedFiles == 0 || p.analyzedFiles >= p.totalFiles {
+		return p.generated
+	}
+
+	return p.generated + p.generated*(p.totalFiles-p.analyzedFiles)/p.analyzedFiles
+}
+
+// eta estimates the time left at now from the rate mutants finished so far,
+// and reports whether it could.
+func (p *progress) eta(now time.Time) (time.Duration, bool) {
+	if p.finished == 0 || p.done {
+		return 0, false
+	}
+
+	perMutant := now.Sub(p.firstStarted) / time.Duration(p.finished)
+	remaining := max(p.estimatedMutants()-p.finished, 0)
+
+	return perMutant * time.Duration(remaining), true
+}
+
+// render renders the progress at now in a screen of width columns and height
+// rows.
+func (p *progress) render(now time.Time, width, height int) []string {
+	elapsed := time.Duration(0)
+	if !p.start.IsZero() {
+		elapsed = now.Sub(p.start)
+	}
+
+	status := "running"
+	if p.done {
+		status = "finished"
+	}
+
+	header := fmt.Sprintf("gomu %s  %s elapsed", status, formatDuration(elapsed))
+	if eta, ok := p.eta(now); ok {
+		header += "  ETA " + formatDuration(eta)
+	}
+
+	estimated := p.estimatedMutants()
+
+	mutants := fmt.Sprintf("Mutants  %s %d/%d", bar(p.finished, estimated), p.finished, p.generated)
+	if estimated > p.generated {
+		mutants += fmt.Sprintf(" (~%d expected)", estimated)
+	}
+
+	lines := []string{
+		header,
+		"",
+		fmt.Sprintf("Files    %s %d/%d", bar(p.analyzedFiles, p.totalFiles), p.analyzedFiles, p.totalFiles),
+		mutants,
+		fmt.Sprintf("Workers  %s %d/%d busy", workerBar(p.running, p.workers), p.running, p.workers),
+		fmt.Sprintf("Score    %s  killed %d  survived %d  other %d",
+			formatScore(p.killed, p.finished-p.excluded), p.killed, p.survived, p.finished-p.killed-p.survived-p.excluded),
+		"",
+		fmt.Sprintf("%7s  %4s/%-4s  %s", "Score", "Done", "All", "File"),
+	}
+
+	// Show the files analyzed last that fit on the screen.
+	files := p.files
+	if room := height - len(lines); len(files) > room {
+		files = files[len(files)-max(room, 0):]
+	}
+
+	for _, file := range files {
+		score := formatScore(file.killed, file.finished-file.excluded)
+		if file.err != "" {
+			score = "error"
+		}
+
+		lines = append(lines, fmt.Sprintf("%7s  %4d/%-4d  %s", score, file.finished, file.mutants, relativePath(p.dir, file.path)))
+	}
+
+	for i, line := range lines {
+		lines[i] = truncate(line, width)
+	}
+
+	return lines
+}
+
+// bar renders a progress bar of done out of total.
+func bar(done, total int) string {
+	filled := 0
+	if total > 0 {
+		filled = min(done*barWidth/total, barWidth)
+	}
+
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled) + "]"
+}
+
+// workerBar renders a box per worker, filled for the busy ones.
+func workerBar(busy, workers int) string {
+	busy = min(max(busy, 0), workers)
+
+	return strings.Repeat("■", busy) + strings.Repeat("□", workers-busy)
+}
+
+// formatScore formats the percentage of killed out of total mutants.
+func formatScore(killed, total int) string {
+	if total <= 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.1f%%", float64(killed)/float64(total)*100)
+}
+
+// formatDuration formats d as minutes and seconds.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+
+	return fmt.Sprintf("%02d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// relativePath returns path relative to dir if it is inside it.
+func relativePath(dir, path string) string {
+	if dir == "" {
+		return path
+	}
+
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+
+	return rel
+}
+
+// truncate cuts s to width runes.
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+
+	return string(runes[:width])
+}
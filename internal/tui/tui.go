@@ -0,0 +1,200 @@
+// Package tui provides the interactive terminal UI of gomu run: live progress
+// while the mutants are tested and a browser of the survived mutants once the
+// run finished.
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/sivchari/gomu/pkg/gomu"
+)
+
+// refreshInterval is how often the live progress is redrawn.
+const refreshInterval = 100 * time.Millisecond
+
+// ANSI escape sequences used to draw the screen.
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	leaveAltScreen = "\x1b[?25h\x1b[?1049l"
+	cursorHome     = "\x1b[H"
+	clearLine      = "\x1b[K"
+	clearBelow     = "\x1b[J"
+)
+
+// Default screen size, used when it cannot be read from the terminal.
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// UI shows the progress of a run in a terminal.
+type UI struct {
+	out *os.File
+	in  *os.File
+
+	mu       sync.Mutex
+	progress *progress
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Supported reports whether the UI can be shown on out.
+func Supported(out *os.File) bool {
+	return term.IsTerminal(int(out.Fd()))
+}
+
+// New creates a UI drawn on out and reading keys from in, for a run testing
+// workers mutants in parallel.
+func New(out, in *os.File, workers int) *UI {
+	dir, _ := os.Getwd()
+
+	return &UI{
+		out:      out,
+		in:       in,
+		progress: newProgress(dir, workers),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Handle updates the progress with event. It is meant to be passed to
+// gomu.WithEventHandler.
+func (u *UI) Handle(event gomu.Event) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.progress.handle(event)
+}
+
+// Start switches to the alternate screen and starts drawing the progress.
+func (u *UI) Start() {
+	fmt.Fprint(u.out, enterAltScreen)
+
+	go u.refresh()
+}
+
+// Close stops drawing the progress and, if mutants survived and keys can be
+// read, lets the user browse them until they quit. It then restores the
+// screen.
+func (u *UI) Close() error {
+	u.stopDrawing()
+
+	defer u.restore()
+
+	u.mu.Lock()
+	survivors := u.progress.survivors
+	u.mu.Unlock()
+
+	if len(survivors) == 0 || !term.IsTerminal(int(u.in.Fd())) {
+		return nil
+	}
+
+	return u.browse(&browser{dir: u.progress.dir, survivors: survivors})
+}
+
+// Stop stops drawing the progress and restores the screen, without browsing
+// the surviving mutants, e.g. after an interrupted run.
+func (u *UI) Stop() {
+	u.stopDrawing()
+	u.restore()
+}
+
+// stopDrawing stops drawing the progress, once it has been drawn a last time.
+func (u *UI) stopDrawing() {
+	close(u.stop)
+	<-u.done
+}
+
+// restore leaves the alternate screen, back to the screen before Start.
+func (u *UI) restore() {
+	fmt.Fprint(u.out, leaveAltScreen)
+}
+
+// refresh draws the progress until Close is called.
+func (u *UI) refresh() {
+	defer close(u.done)
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		u.drawProgress()
+
+		select {
+		case <-u.stop:
+			u.drawProgress()
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drawProgress draws the current progress.
+func (u *UI) drawProgress() {
+	width, height := u.size()
+
+	u.mu.Lock()
+	lines := u.progress.render(time.Now(), width, height)
+	u.mu.Unlock()
+
+	u.draw(lines)
+}
+
+// browse draws b and handles keys until the user quits.
+func (u *UI) browse(b *browser) error {
+	fd := int(u.in.Fd())
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to enable raw mode: %w", err)
+	}
+
+	defer func() { _ = term.Restore(fd, state) }()
+
+	reader := bufio.NewReader(u.in)
+
+	for {
+		u.draw(b.render(u.size()))
+
+		k, err := readKey(reader)
+		if err != nil || !b.handle(k) {
+			return nil
+		}
+	}
+}
+
+// draw replaces the screen with lines. Lines end with \r\n as the terminal
+// may be in raw mode.
+func (u *UI) draw(lines []string) {
+	var sb strings.Builder
+
+	sb.WriteString(cursorHome)
+
+	for _, line := range lines {
+		sb.WriteString(line + clearLine + "\r\n")
+	}
+
+	sb.WriteString(clearBelow)
+
+	fmt.Fprint(u.out, sb.String())
+}
+
+// size returns the width and height of the terminal.
+func (u *UI) size() (int, int) {
+	width, height, err := term.GetSize(int(u.out.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return defaultWidth, defaultHeight
+	}
+
+	// Keep the last row free so that the trailing newline does not scroll.
+	return width, height - 1
+}
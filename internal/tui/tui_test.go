@@ -0,0 +1,240 @@
+package tui
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sivchari/gomu/pkg/gomu"
+)
+
+func TestProgress(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	finished := func(path string, status gomu.Status) gomu.Event {
+		return gomu.Event{
+			Type:   gomu.EventMutantFinished,
+			Time:   start.Add(10 * time.Second),
+			Result: &gomu.Result{Mutant: gomu.Mutant{FilePath: path, Diff: "-a\n+b\n"}, Status: status},
+		}
+	}
+
+	p := newProgress("/repo", 4)
+
+	events := []gomu.Event{
+		{Type: gomu.EventRunStarted, Time: start, Files: 4},
+		{Type: gomu.EventFileAnalyzed, File: "/repo/a.go", Mutants: 4},
+		{Type: gomu.EventMutantGenerated},
+		{Type: gomu.EventMutantGenerated},
+		{Type: gomu.EventMutantGenerated},
+		{Type: gomu.EventMutantGenerated},
+		{Type: gomu.EventFileAnalyzed, File: "/repo/b.go", Error: "syntax error"},
+		{Type: gomu.EventMutantStarted, Time: start},
+		{Type: gomu.EventMutantStarted, Time: start},
+		{Type: gomu.EventMutantStarted, Time: start},
+		finished("/repo/a.go", gomu.StatusKilled),
+		finished("/repo/a.go", gomu.StatusSurvived),
+		{Type: gomu.EventMutantStarted, Time: start},
+		finished("/repo/a.go", gomu.StatusNotViable),
+	}
+
+	for _, event := range events {
+		p.handle(event)
+	}
+
+	if p.running != 1 || p.finished != 3 || p.killed != 1 || p.survived != 1 || p.excluded != 1 {
+		t.Errorf("unexpected counts: running %d, finished %d, killed %d, survived %d, excluded %d",
+			p.running, p.finished, p.killed, p.survived, p.excluded)
+	}
+
+	if len(p.survivors) != 1 {
+		t.Errorf("expected 1 survivor, got %d", len(p.survivors))
+	}
+
+	// 4 mutants in 2 of 4 files.
+	if got := p.estimatedMutants(); got != 8 {
+		t.Errorf("estimatedMutants() = %d, want 8", got)
+	}
+
+	// 3 mutants finished in 15s, 5s each, 5 more expected.
+	eta, ok := p.eta(start.Add(15 * time.Second))
+	if !ok || eta != 25*time.Second {
+		t.Errorf("eta() = %v, %t, want 25s", eta, ok)
+	}
+
+	screen := strings.Join(p.render(start.Add(15*time.Second), 100, 40), "\n")
+
+	for _, want := range []string{
+		"gomu running  00:15 elapsed  ETA 00:25",
+		"Files    [███████████████░░░░░░░░░░░░░░░] 2/4",
+		"3/4 (~8 expected)",
+		"■□□□ 1/4 busy",
+		"Score    50.0%  killed 1  survived 1  other 0",
+		"  50.0%     3/4     a.go",
+		"  error     0/0     b.go",
+	} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("screen does not contain %q:\n%s", want, screen)
+		}
+	}
+
+	p.handle(gomu.Event{Type: gomu.EventRunFinished})
+
+	if _, ok := p.eta(start); ok {
+		t.Error("expected no ETA once the run finished")
+	}
+}
+
+func TestProgress_RenderFitsScreen(t *testing.T) {
+	p := newProgress("", 2)
+	p.handle(gomu.Event{Type: gomu.EventRunStarted, Files: 20})
+
+	for i := range 20 {
+		p.handle(gomu.Event{Type: gomu.EventFileAnalyzed, File: strings.Repeat("x", i+1) + ".go"})
+	}
+
+	lines := p.render(time.Now(), 20, 12)
+
+	if len(lines) != 12 {
+		t.Errorf("expected 12 lines, got %d", len(lines))
+	}
+
+	for _, line := range lines {
+		if n := len([]rune(line)); n > 20 {
+			t.Errorf("line %q is %d runes wide", line, n)
+		}
+	}
+}
+
+func TestReadKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []key
+	}{
+		{
+			name:  "vi keys",
+			input: "jkq",
+			want:  []key{keyDown, keyUp, keyQuit},
+		},
+		{
+			name:  "arrow keys",
+			input: "\x1b[B\x1b[A",
+			want:  []key{keyDown, keyUp},
+		},
+		{
+			name:  "ctrl-c quits",
+			input: "\x03",
+			want:  []key{keyQuit},
+		},
+		{
+			name:  "other keys are ignored",
+			input: "x\x1b[C",
+			want:  []key{keyNone, keyNone},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := bufio.NewReader(strings.NewReader(tt.input))
+
+			for _, want := range tt.want {
+				got, err := readKey(r)
+				if err != nil {
+					t.Fatalf("readKey failed: %v", err)
+				}
+
+				if got != want {
+					t.Errorf("readKey() = %v, want %v", got, want)
+				}
+			}
+
+			if _, err := readKey(r); err == nil {
+				t.Error("expected an error at the end of the input")
+			}
+		})
+	}
+}
+
+func TestBrowser(t *testing.T) {
+	survivor := func(line int) gomu.Result {
+		return gomu.Result{
+			Mutant: gomu.Mutant{
+				FilePath:    "/repo/calc.go",
+				Line:        line,
+				Column:      9,
+				Original:    "+",
+				Mutated:     "-",
+				Description: "Replace + with -",
+				Function:    "Add",
+				Diff:        "--- a/calc.go\n+++ b/calc.go\n@@ -1 +1 @@\n-return a + b\n+return a - b\n",
+			},
+			Status: gomu.StatusSurvived,
+		}
+	}
+
+	b := &browser{dir: "/repo", survivors: []gomu.Result{survivor(1), survivor(2), survivor(3)}}
+
+	steps := []struct {
+		key      key
+		selected int
+		goOn     bool
+	}{
+		{key: keyUp, selected: 0, goOn: true},
+		{key: keyDown, selected: 1, goOn: true},
+		{key: keyDown, selected: 2, goOn: true},
+		{key: keyDown, selected: 2, goOn: true},
+		{key: keyNone, selected: 2, goOn: true},
+		{key: keyQuit, selected: 2, goOn: false},
+	}
+
+	for _, step := range steps {
+		if goOn := b.handle(step.key); goOn != step.goOn || b.selected != step.selected {
+			t.Errorf("after key %v: selected %d, go on %t, want %d, %t", step.key, b.selected, goOn, step.selected, step.goOn)
+		}
+	}
+
+	screen := strings.Join(b.render(80, 24), "\n")
+
+	for _, want := range []string{
+		"Survived mutants: 3",
+		reverse + "  calc.go:3:9  Replace + with -" + reset,
+		"Function: Add",
+		"Mutation: + -> -",
+		"--- a/calc.go\n+++ b/calc.go",
+		colorRed + "-return a + b" + reset,
+		colorGreen + "+return a - b" + reset,
+	} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("screen does not contain %q:\n%s", want, screen)
+		}
+	}
+}
+
+func TestUI_Stop(t *testing.T) {
+	out, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	if err != nil {
+		t.Fatalf("failed to create output: %v", err)
+	}
+	defer out.Close()
+
+	ui := New(out, os.Stdin, 1)
+	ui.Start()
+	ui.Handle(gomu.Event{
+		Type:   gomu.EventMutantFinished,
+		Time:   time.Now(),
+		Result: &gomu.Result{Mutant: gomu.Mutant{FilePath: "a.go"}, Status: gomu.StatusSurvived},
+	})
+	ui.Stop()
+
+	written, err := os.ReadFile(out.Name())
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+
+	if !strings.HasPrefix(string(written), enterAltScreen) || !strings.HasSuffix(string(written), leaveAltScreen) {
+		t.Errorf("expected the output to leave the alternate screen it entered, got %q", written)
+	}
+}
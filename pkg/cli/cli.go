@@ -0,0 +1,348 @@
+// Package cli implements the gomu command line. Commands that add mutators,
+// e.g. by importing a package registering them, run it with Main.
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/sivchari/gomu/internal/execution"
+	"github.com/sivchari/gomu/internal/tui"
+	"github.com/sivchari/gomu/pkg/gomu"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Version information set by ldflags.
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+
+	verbose bool
+)
+
+// errInterrupted is returned when a run is interrupted, to exit with
+// exitInterrupted.
+var errInterrupted = errors.New("interrupted")
+
+// exitInterrupted is the exit status after an interrupt, as for SIGINT in a
+// shell.
+const exitInterrupted = 130
+
+var rootCmd = &cobra.Command{
+	Use:   "gomu",
+	Short: "A high-performance mutation testing tool for Go",
+	Long: `gomu is a mutation testing tool that helps validate the quality of your Go test suite.
+It introduces controlled changes (mutations) to your code and checks if your tests catch them.
+
+Features:
+- Incremental analysis for fast reruns
+- Git integration for change detection  
+- Parallel execution with goroutines
+- Go-specific mutations (generics, error handling, etc.)`,
+	RunE: runMutationTesting,
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run [packages]",
+	Short: "Run mutation testing on the specified packages",
+	Long: `Run mutation testing on the specified Go packages.
+
+Packages are given as standard Go package patterns (e.g. ./..., ./internal/...,
+or import paths) and resolved with "go list", so build constraints and
+GOOS/GOARCH are respected. Defaults to ./... in the current directory.`,
+	RunE: runMutationTesting,
+}
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version information",
+	Run: func(_ *cobra.Command, _ []string) {
+		fmt.Printf("gomu version %s\n", version)
+		fmt.Printf("  commit: %s\n", commit)
+		fmt.Printf("  built:  %s\n", date)
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+
+	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(versionCmd)
+
+	// Run command flags
+	runCmd.Flags().Bool("ci-mode", false, "enable CI mode with quality gates and reporting")
+	runCmd.Flags().Float64("threshold", 80.0, "minimum mutation score threshold")
+	runCmd.Flags().String("output", "console", "output format (console, json, html, text)")
+	runCmd.Flags().Bool("fail-on-gate", true, "fail build when quality gate is not met")
+	runCmd.Flags().Int("workers", 4, "number of parallel workers")
+	runCmd.Flags().Int("timeout", 30, "test timeout in seconds")
+	runCmd.Flags().Bool("incremental", true, "enable incremental analysis")
+	runCmd.Flags().String("base-branch", "main", "base branch for incremental analysis")
+	runCmd.Flags().String("build-flags", "", `flags passed to go build and go test (e.g. "-tags integration -race")`)
+	runCmd.Flags().String("test-flags", "", `flags passed only to go test (e.g. "-count=1 -short")`)
+	runCmd.Flags().StringArray("env", nil, "extra KEY=VALUE environment variable for go build and go test (repeatable)")
+	runCmd.Flags().StringArray("test-packages", nil, `additional test packages for a source package as "PKG=TESTPKG[,TESTPKG...]" (repeatable)`)
+	runCmd.Flags().Bool("test-reverse-imports", false, "also run the tests of packages that directly import the mutated package")
+	runCmd.Flags().String("call-swaps", "", "JSON file with additional function call swap mutations")
+	runCmd.Flags().Bool("prune-subsumed", false, "drop relational operator mutants subsumed by other mutants at the same site")
+	runCmd.Flags().Int("order", 1, "number of mutations combined into each mutant; 2 or more adds higher-order mutants")
+	runCmd.Flags().Int("order-limit", 100, "maximum number of higher-order mutants sampled per file")
+	runCmd.Flags().String("events", "", `write progress events as NDJSON to this file, or "-" for stderr (not with --tui)`)
+	runCmd.Flags().Bool("tui", false, "show live progress and browse survived mutants in an interactive terminal UI")
+}
+
+func runMutationTesting(cmd *cobra.Command, args []string) error {
+	path := "."
+	patterns := args
+
+	// Read CLI flags
+	ciMode, _ := cmd.Flags().GetBool("ci-mode")
+	workers, _ := cmd.Flags().GetInt("workers")
+	timeout, _ := cmd.Flags().GetInt("timeout")
+	output, _ := cmd.Flags().GetString("output")
+	incremental, _ := cmd.Flags().GetBool("incremental")
+	baseBranch, _ := cmd.Flags().GetString("base-branch")
+	threshold, _ := cmd.Flags().GetFloat64("threshold")
+	failOnGate, _ := cmd.Flags().GetBool("fail-on-gate")
+	buildFlagsValue, _ := cmd.Flags().GetString("build-flags")
+	testFlagsValue, _ := cmd.Flags().GetString("test-flags")
+	env, _ := cmd.Flags().GetStringArray("env")
+	testPackagesValues, _ := cmd.Flags().GetStringArray("test-packages")
+	testReverseImports, _ := cmd.Flags().GetBool("test-reverse-imports")
+	callSwaps, _ := cmd.Flags().GetString("call-swaps")
+	pruneSubsumed, _ := cmd.Flags().GetBool("prune-subsumed")
+	order, _ := cmd.Flags().GetInt("order")
+	orderLimit, _ := cmd.Flags().GetInt("order-limit")
+	events, _ := cmd.Flags().GetString("events")
+	useTUI, _ := cmd.Flags().GetBool("tui")
+
+	if order < 1 {
+		return fmt.Errorf("invalid --order %d: must be at least 1", order)
+	}
+
+	// The terminal UI owns the terminal, so events on stderr would garble it.
+	if useTUI && events == "-" {
+		return errors.New(`invalid --events "-": cannot write events to stderr with --tui, use a file instead`)
+	}
+
+	buildFlags, err := execution.SplitFlags(buildFlagsValue)
+	if err != nil {
+		return fmt.Errorf("invalid --build-flags: %w", err)
+	}
+
+	testFlags, err := execution.SplitFlags(testFlagsValue)
+	if err != nil {
+		return fmt.Errorf("invalid --test-flags: %w", err)
+	}
+
+	for _, kv := range env {
+		if !strings.Contains(kv, "=") {
+			return fmt.Errorf("invalid --env %q: expected KEY=VALUE", kv)
+		}
+	}
+
+	testPackages, err := parseTestPackages(testPackagesValues)
+	if err != nil {
+		return err
+	}
+
+	if verbose {
+		fmt.Printf("Running mutation testing with the following settings:\n")
+		fmt.Printf("  Packages: %s\n", strings.Join(patternsOrDefault(patterns), " "))
+		fmt.Printf("  CI Mode: %t\n", ciMode)
+		fmt.Printf("  Workers: %d\n", workers)
+		fmt.Printf("  Timeout: %d seconds\n", timeout)
+		fmt.Printf("  Output: %s\n", output)
+		fmt.Printf("  Incremental: %t\n", incremental)
+		fmt.Printf("  Base Branch: %s\n", baseBranch)
+
+		if len(buildFlags) > 0 {
+			fmt.Printf("  Build Flags: %s\n", strings.Join(buildFlags, " "))
+		}
+
+		if len(testFlags) > 0 {
+			fmt.Printf("  Test Flags: %s\n", strings.Join(testFlags, " "))
+		}
+
+		for pkg, testPkgs := range testPackages {
+			fmt.Printf("  Test Packages for %s: %s\n", pkg, strings.Join(testPkgs, " "))
+		}
+
+		if testReverseImports {
+			fmt.Printf("  Test Reverse Imports: %t\n", testReverseImports)
+		}
+
+		if callSwaps != "" {
+			fmt.Printf("  Call Swaps: %s\n", callSwaps)
+		}
+
+		if pruneSubsumed {
+			fmt.Printf("  Prune Subsumed: %t\n", pruneSubsumed)
+		}
+
+		if order > 1 {
+			fmt.Printf("  Order: %d (up to %d higher-order mutants per file)\n", order, orderLimit)
+		}
+
+		if ciMode {
+			fmt.Printf("  Threshold: %.1f%%\n", threshold)
+			fmt.Printf("  Fail on Gate: %t\n", failOnGate)
+		}
+
+		fmt.Println()
+	}
+
+	// Create engine options from CLI flags
+	opts := []gomu.Option{
+		gomu.WithWorkers(workers),
+		gomu.WithTimeout(time.Duration(timeout) * time.Second),
+		gomu.WithReport(output),
+		gomu.WithIncremental(incremental, baseBranch),
+		gomu.WithVerbose(verbose),
+		gomu.WithPatterns(patterns...),
+		gomu.WithBuildFlags(buildFlags...),
+		gomu.WithTestFlags(testFlags...),
+		gomu.WithEnv(env...),
+		gomu.WithTestPackages(testPackages),
+		gomu.WithTestReverseImports(testReverseImports),
+		gomu.WithCallSwaps(callSwaps),
+		gomu.WithPruneSubsumed(pruneSubsumed),
+		gomu.WithHigherOrder(order, orderLimit),
+	}
+
+	if ciMode {
+		opts = append(opts, gomu.WithQualityGate(threshold, failOnGate))
+	}
+
+	switch events {
+	case "":
+	case "-":
+		opts = append(opts, gomu.WithEventLog(os.Stderr))
+	default:
+		file, err := os.Create(events)
+		if err != nil {
+			return fmt.Errorf("failed to create events file: %w", err)
+		}
+		defer file.Close()
+
+		opts = append(opts, gomu.WithEventLog(file))
+	}
+
+	// The terminal UI needs a terminal; otherwise the plain output is used.
+	if useTUI && tui.Supported(os.Stdout) {
+		return runWithTUI(cmd, path, workers, opts)
+	}
+
+	engine, err := gomu.New(append(opts, gomu.WithWriter(os.Stdout))...)
+	if err != nil {
+		return fmt.Errorf("failed to create engine: %w", err)
+	}
+
+	if _, err := engine.Run(cmd.Context(), path); err != nil {
+		return fmt.Errorf("mutation testing failed: %w", err)
+	}
+
+	return nil
+}
+
+// runWithTUI runs mutation testing showing its progress in the terminal UI.
+// The plain output is buffered and printed once the UI is closed.
+func runWithTUI(cmd *cobra.Command, path string, workers int, opts []gomu.Option) error {
+	var output bytes.Buffer
+
+	ui := tui.New(os.Stdout, os.Stdin, workers)
+
+	engine, err := gomu.New(append(opts, gomu.WithWriter(&output), gomu.WithEventHandler(ui.Handle))...)
+	if err != nil {
+		return fmt.Errorf("failed to create engine: %w", err)
+	}
+
+	// Cancel the run on an interrupt, so that it cleans up before the screen
+	// is restored.
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	ui.Start()
+
+	_, runErr := engine.Run(ctx, path)
+
+	if ctx.Err() != nil && cmd.Context().Err() == nil {
+		ui.Stop()
+
+		if _, err := io.Copy(os.Stdout, &output); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+
+		return errInterrupted
+	}
+
+	closeErr := ui.Close()
+
+	if _, err := io.Copy(os.Stdout, &output); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if runErr != nil {
+		return fmt.Errorf("mutation testing failed: %w", runErr)
+	}
+
+	if closeErr != nil {
+		return fmt.Errorf("failed to close terminal UI: %w", closeErr)
+	}
+
+	return nil
+}
+
+// patternsOrDefault returns the package patterns to display for a run.
+func patternsOrDefault(patterns []string) []string {
+	if len(patterns) == 0 {
+		return []string{"./..."}
+	}
+
+	return patterns
+}
+
+// parseTestPackages parses --test-packages values of the form
+// "PKG=TESTPKG[,TESTPKG...]" into a map from source package to test packages.
+func parseTestPackages(values []string) (map[string][]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	testPackages := make(map[string][]string, len(values))
+
+	for _, value := range values {
+		pkg, list, ok := strings.Cut(value, "=")
+		if !ok || pkg == "" || list == "" {
+			return nil, fmt.Errorf("invalid --test-packages %q: expected PKG=TESTPKG[,TESTPKG...]", value)
+		}
+
+		for _, testPkg := range strings.Split(list, ",") {
+			if testPkg = strings.TrimSpace(testPkg); testPkg != "" {
+				testPackages[pkg] = append(testPackages[pkg], testPkg)
+			}
+		}
+	}
+
+	return testPackages, nil
+}
+
+// Main runs the gomu command line with the arguments of the process and exits
+// with status 1 if it fails, or 130 if it is interrupted.
+func Main() {
+	if err := rootCmd.Execute(); err != nil {
+		if errors.Is(err, errInterrupted) {
+			os.Exit(exitInterrupted)
+		}
+
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
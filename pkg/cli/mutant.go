@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sivchari/gomu/internal/analysis"
+	"github.com/sivchari/gomu/internal/execution"
+	"github.com/sivchari/gomu/internal/history"
+	"github.com/sivchari/gomu/internal/mutation"
+	"github.com/spf13/cobra"
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show <mutant-id>",
+	Short: "Show a mutant from the last run",
+	Long: `Show the diff, status and test output of a mutant from the last run's history.
+
+The mutant ID is the full ID from a report, or its end after a path separator
+(e.g. calculator.go_12) if that identifies a single mutant.`,
+	Args: cobra.ExactArgs(1),
+	RunE: showMutant,
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply [mutant-id]",
+	Short: "Apply a mutant from the last run to the working tree",
+	Long: `Write a mutant from the last run's history into its source file, so that it
+can be debugged, and restore the file with "gomu apply --revert".
+
+With --dir, the mutated file is written into the given directory instead and
+the working tree is left untouched.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: applyMutant,
+}
+
+func init() {
+	rootCmd.AddCommand(showCmd)
+	rootCmd.AddCommand(applyCmd)
+
+	applyCmd.Flags().String("dir", "", "write the mutated file into this directory instead of the working tree")
+	applyCmd.Flags().Bool("revert", false, "restore the file of the applied mutant")
+}
+
+func showMutant(_ *cobra.Command, args []string) error {
+	result, changed, err := findMutant(args[0])
+	if err != nil {
+		return err
+	}
+
+	mutant := result.Mutant
+
+	fmt.Printf("Mutant:   %s\n", mutant.ID)
+	fmt.Printf("Location: %s:%d:%d\n", mutant.FilePath, mutant.Line, mutant.Column)
+
+	if mutant.Function != "" {
+		fmt.Printf("Function: %s\n", mutant.Function)
+	}
+
+	fmt.Printf("Type:     %s\n", mutant.Type)
+	fmt.Printf("Change:   %s (%s -> %s)\n", mutant.Description, mutant.Original, mutant.Mutated)
+	fmt.Printf("Status:   %s\n", result.Status)
+
+	if result.Error != "" {
+		fmt.Printf("Error:    %s\n", result.Error)
+	}
+
+	if changed {
+		fmt.Printf("Warning:  %s has changed since the run\n", mutant.FilePath)
+	}
+
+	if mutant.Diff != "" {
+		fmt.Printf("\n%s", mutant.Diff)
+	}
+
+	if output := strings.TrimSpace(result.Output); output != "" {
+		fmt.Printf("\nTest output:\n%s\n", output)
+	}
+
+	return nil
+}
+
+func applyMutant(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("dir")
+	revert, _ := cmd.Flags().GetBool("revert")
+
+	if revert {
+		if len(args) > 0 || dir != "" {
+			return errors.New("--revert takes no mutant ID or --dir")
+		}
+
+		applied, err := execution.RevertMutant(execution.AppliedFile)
+		if err != nil {
+			return fmt.Errorf("failed to revert mutant: %w", err)
+		}
+
+		fmt.Printf("Reverted %s in %s\n", applied.ID, applied.FilePath)
+
+		return nil
+	}
+
+	if len(args) == 0 {
+		return errors.New("a mutant ID is required unless --revert is given")
+	}
+
+	if dir == "" {
+		if applied, err := execution.ReadApplied(execution.AppliedFile); err == nil {
+			return fmt.Errorf("%w: %s; run \"gomu apply --revert\" first", execution.ErrAlreadyApplied, applied.ID)
+		}
+	}
+
+	result, changed, err := findMutant(args[0])
+	if err != nil {
+		return err
+	}
+
+	mutant := result.Mutant
+
+	if changed {
+		return fmt.Errorf("%s has changed since the run; rerun gomu to apply its mutants", mutant.FilePath)
+	}
+
+	if dir != "" {
+		path, diff, err := execution.WriteMutant(mutant, dir)
+		if err != nil {
+			return fmt.Errorf("failed to write mutant: %w", err)
+		}
+
+		fmt.Printf("Wrote %s to %s\n\n%s", mutant.ID, path, diff)
+
+		return nil
+	}
+
+	diff, err := execution.ApplyMutant(mutant, execution.AppliedFile)
+	if err != nil {
+		return fmt.Errorf("failed to apply mutant: %w", err)
+	}
+
+	fmt.Printf("Applied %s to %s; restore it with \"gomu apply --revert\"\n\n%s", mutant.ID, mutant.FilePath, diff)
+
+	return nil
+}
+
+// findMutant looks up the result of the mutant with the given ID in the
+// history and reports whether its file has changed since it was recorded.
+func findMutant(id string) (mutation.Result, bool, error) {
+	store, err := history.New(history.DefaultFile)
+	if err != nil {
+		return mutation.Result{}, false, fmt.Errorf("failed to load history: %w", err)
+	}
+
+	file, result, err := store.FindResult(id)
+	if err != nil {
+		return mutation.Result{}, false, fmt.Errorf("failed to find mutant: %w", err)
+	}
+
+	entry, _ := store.GetEntry(file)
+
+	hash, err := analysis.NewFileHasher().HashFile(file)
+	changed := err != nil || (entry.FileHash != "" && hash != entry.FileHash)
+
+	return result, changed, nil
+}
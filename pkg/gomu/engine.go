@@ -4,9 +4,12 @@ package gomu
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/sivchari/gomu/internal/analysis"
@@ -29,63 +32,54 @@ type Engine struct {
 	qualityGate         *ci.QualityGateEvaluator
 	ciReporter          *ci.Reporter
 	github              *ci.GitHubIntegration
+	opts                *options
+	out                 io.Writer
+	// eventsMu serializes calls to the event handlers.
+	eventsMu sync.Mutex
 }
 
-// RunOptions contains options for running mutation testing.
-type RunOptions struct {
-	Workers     int
-	Timeout     int
-	Output      string
-	Incremental bool
-	BaseBranch  string
-	Threshold   float64
-	FailOnGate  bool
-	Verbose     bool
-	CIMode      bool
+// New creates a new mutation testing engine configured by opts.
+func New(opts ...Option) (*Engine, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return newEngine(o)
 }
 
-// NewEngine creates a new mutation testing engine.
-func NewEngine(opts *RunOptions) (*Engine, error) {
-	// Create analyzer without ignore parser - it will be set later in Run
-	analyzer, err := analysis.New()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create analyzer: %w", err)
+// newEngine creates a new mutation testing engine.
+func newEngine(opts *options) (*Engine, error) {
+	engine := &Engine{
+		opts: opts,
+		out:  io.Discard,
 	}
 
-	mutator, err := mutation.New()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create mutator: %w", err)
+	if opts != nil && opts.Writer != nil {
+		engine.out = opts.Writer
 	}
 
-	executor, err := execution.New()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create executor: %w", err)
+	// Create the components up front so that invalid options are reported by
+	// New rather than by the first run.
+	if err := engine.initComponents(opts, nil); err != nil {
+		return nil, err
 	}
 
-	historyFile := ".gomu_history.json"
-
-	historyStore, err := history.New(historyFile)
+	historyStore, err := history.New(history.DefaultFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create history store: %w", err)
 	}
 
-	outputFormat := "console"
-	if opts != nil && opts.Output != "" {
-		outputFormat = opts.Output
-	}
+	engine.history = historyStore
 
-	reporter, err := report.New(outputFormat)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create reporter: %w", err)
-	}
+	if opts != nil && opts.Output != "" {
+		reporter, err := report.New(opts.Output)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create reporter: %w", err)
+		}
 
-	engine := &Engine{
-		analyzer:            analyzer,
-		mutator:             mutator,
-		executor:            executor,
-		history:             historyStore,
-		reporter:            reporter,
-		incrementalAnalyzer: nil,
+		reporter.SetOutput(engine.out)
+		engine.reporter = reporter
 	}
 
 	// Initialize CI components if CI mode is enabled
@@ -96,8 +90,88 @@ func NewEngine(opts *RunOptions) (*Engine, error) {
 	return engine, nil
 }
 
+// initComponents creates the analyzer, mutator and executor used by a run.
+// They cache loaded packages, pruning counters and compiled originals, so they
+// are created afresh for each run of a reused Engine.
+func (e *Engine) initComponents(opts *options, ignoreParser *ignore.Parser) error {
+	if e.executor != nil {
+		if err := e.executor.Close(); err != nil {
+			return fmt.Errorf("failed to cleanup previous executor: %w", err)
+		}
+	}
+
+	analyzerOpts := []analysis.Option{}
+	if opts != nil {
+		analyzerOpts = append(analyzerOpts, analysis.WithBuildFlags(opts.BuildFlags), analysis.WithEnv(opts.Env))
+	}
+
+	if ignoreParser != nil {
+		analyzerOpts = append(analyzerOpts, analysis.WithIgnoreParser(ignoreParser))
+	}
+
+	analyzer, err := analysis.New(analyzerOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to create analyzer: %w", err)
+	}
+
+	// Share the analyzer so each package is loaded and type checked once.
+	mutatorOpts := []mutation.Option{mutation.WithAnalyzer(analyzer)}
+
+	if opts != nil && opts.CallSwaps != "" {
+		swaps, err := mutation.LoadCallSwaps(opts.CallSwaps)
+		if err != nil {
+			return fmt.Errorf("failed to load call swaps: %w", err)
+		}
+
+		mutatorOpts = append(mutatorOpts, mutation.WithCallSwaps(swaps))
+	}
+
+	if opts != nil && opts.PruneSubsumed {
+		mutatorOpts = append(mutatorOpts, mutation.WithSubsumption(true))
+	}
+
+	if opts != nil && opts.Order > 1 {
+		mutatorOpts = append(mutatorOpts, mutation.WithHigherOrder(opts.Order, opts.OrderLimit))
+	}
+
+	if opts != nil && len(opts.Mutators) > 0 {
+		mutatorOpts = append(mutatorOpts, mutation.WithMutators(pluginMutators(opts.Mutators)...))
+	}
+
+	mutator, err := mutation.New(mutatorOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to create mutator: %w", err)
+	}
+
+	var executorOpts []execution.Option
+	if opts != nil {
+		executorOpts = append(executorOpts,
+			execution.WithBuildFlags(opts.BuildFlags),
+			execution.WithTestFlags(opts.TestFlags),
+			execution.WithEnv(opts.Env),
+			execution.WithMutators(mutator.GetMutators()),
+			execution.WithOutput(e.writer()),
+		)
+
+		if len(opts.EventHandlers) > 0 {
+			executorOpts = append(executorOpts, execution.WithObserver(&executionObserver{engine: e}))
+		}
+	}
+
+	executor, err := execution.New(executorOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	e.analyzer = analyzer
+	e.mutator = mutator
+	e.executor = executor
+
+	return nil
+}
+
 // initializeCIComponents initializes CI-specific components.
-func (e *Engine) initializeCIComponents(opts *RunOptions) {
+func (e *Engine) initializeCIComponents(opts *options) {
 	// Set intelligent defaults if opts is nil
 	threshold := 80.0
 	outputFormat := "console"
@@ -118,13 +192,14 @@ func (e *Engine) initializeCIComponents(opts *RunOptions) {
 	// Initialize CI reporter
 	outputDir := "."
 	e.ciReporter = ci.NewReporter(outputDir, outputFormat)
+	e.ciReporter.SetOutput(e.writer())
 
 	// Initialize GitHub integration with environment detection
 	e.initializeGitHubIntegration(opts)
 }
 
 // initializeGitHubIntegration initializes GitHub integration if conditions are met.
-func (e *Engine) initializeGitHubIntegration(opts *RunOptions) {
+func (e *Engine) initializeGitHubIntegration(opts *options) {
 	ciConfig := ci.LoadConfigFromEnv()
 	if !ciConfig.IsCIMode() || ciConfig.PRNumber < 0 {
 		return
@@ -135,37 +210,38 @@ func (e *Engine) initializeGitHubIntegration(opts *RunOptions) {
 
 	if token != "" && repo != "" {
 		e.github = ci.NewGitHubIntegration(token, repo, ciConfig.PRNumber)
+		e.github.SetOutput(e.writer())
 		if opts != nil && opts.Verbose {
-			log.Printf("Initialized GitHub integration for PR #%d", ciConfig.PRNumber)
+			e.logf("Initialized GitHub integration for PR #%d", ciConfig.PRNumber)
 		}
 	} else if opts != nil && opts.Verbose {
-		log.Printf("GitHub integration disabled: missing token or repository")
+		e.logf("GitHub integration disabled: missing token or repository")
 	}
 }
 
 // setDefaultOptions sets default values for options if not provided.
-func (e *Engine) setDefaultOptions(opts *RunOptions) *RunOptions {
+func (e *Engine) setDefaultOptions(opts *options) *options {
 	if opts == nil {
-		return &RunOptions{
-			Workers:     4,
-			Timeout:     30,
-			Output:      "console",
-			Incremental: true,
-			BaseBranch:  "main",
-			Threshold:   80.0,
-			FailOnGate:  true,
-			Verbose:     false,
-		}
+		return defaultOptions()
 	}
 
 	return opts
 }
 
 // logStartupInfo logs startup information if verbose mode is enabled.
-func (e *Engine) logStartupInfo(path string, opts *RunOptions) {
+func (e *Engine) logStartupInfo(path string, opts *options) {
 	if opts.Verbose {
-		log.Printf("Starting mutation testing on path: %s", path)
-		log.Printf("Running with options: workers=%d, timeout=%d, output=%s, incremental=%t",
+		e.logf("Starting mutation testing on path: %s", path)
+
+		if len(opts.Patterns) > 0 {
+			e.logf("Package patterns: %s", strings.Join(opts.Patterns, " "))
+		}
+
+		if len(opts.BuildFlags) > 0 || len(opts.TestFlags) > 0 {
+			e.logf("Build flags: %q, test flags: %q", opts.BuildFlags, opts.TestFlags)
+		}
+
+		e.logf("Running with options: workers=%d, timeout=%d, output=%s, incremental=%t",
 			opts.Workers, opts.Timeout, opts.Output, opts.Incremental)
 	}
 }
@@ -181,7 +257,7 @@ func (e *Engine) getAbsolutePath(path string) (string, error) {
 }
 
 // performIncrementalAnalysis performs incremental analysis and returns results and files to process.
-func (e *Engine) performIncrementalAnalysis(absPath string, opts *RunOptions, ignoreParser analysis.IgnoreParser) ([]analysis.FileAnalysisResult, []string, error) {
+func (e *Engine) performIncrementalAnalysis(absPath string, opts *options, ignoreParser *ignore.Parser) ([]analysis.FileAnalysisResult, []string, error) {
 	// Initialize incremental analyzer
 	historyWrapper := &historyStoreWrapper{store: e.history}
 
@@ -197,6 +273,23 @@ func (e *Engine) performIncrementalAnalysis(absPath string, opts *RunOptions, ig
 		e.incrementalAnalyzer.SetIgnoreParser(ignoreParser)
 	}
 
+	// Resolve package patterns so that only files that build for the target
+	// platform are mutated.
+	packages, err := analysis.ListPackages(absPath, opts.Patterns, opts.Env, opts.BuildFlags...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve packages: %w", err)
+	}
+
+	if opts.Verbose {
+		e.logf("Resolved %d package(s)", len(packages))
+	}
+
+	e.incrementalAnalyzer.SetTargetFiles(analysis.SourceFiles(packages))
+
+	if err := e.configureTestTargets(absPath, opts); err != nil {
+		return nil, nil, err
+	}
+
 	// Perform incremental analysis
 	analysisResults, err := e.incrementalAnalyzer.AnalyzeFiles()
 	if err != nil {
@@ -204,7 +297,7 @@ func (e *Engine) performIncrementalAnalysis(absPath string, opts *RunOptions, ig
 	}
 
 	if opts.Verbose {
-		e.incrementalAnalyzer.PrintAnalysisReport(analysisResults)
+		e.incrementalAnalyzer.PrintAnalysisReport(e.writer(), analysisResults)
 	}
 
 	// Get files that need processing
@@ -214,14 +307,55 @@ func (e *Engine) performIncrementalAnalysis(absPath string, opts *RunOptions, ig
 	}
 
 	if opts.Verbose {
-		log.Printf("Processing %d files", len(files))
+		e.logf("Processing %d files", len(files))
 	}
 
 	return analysisResults, files, nil
 }
 
-// Run executes mutation testing on the specified path.
-func (e *Engine) Run(ctx context.Context, path string, opts *RunOptions) error {
+// configureTestTargets resolves the cross-package test targets for mutants.
+// All packages under absPath are considered, not only the mutated ones, since
+// the tests that exercise a package usually live elsewhere.
+func (e *Engine) configureTestTargets(absPath string, opts *options) error {
+	if len(opts.TestPackages) == 0 && !opts.TestReverseImports {
+		return nil
+	}
+
+	packages, err := analysis.ListPackages(absPath, analysis.DefaultPatterns, opts.Env, opts.BuildFlags...)
+	if err != nil {
+		return fmt.Errorf("failed to resolve test packages: %w", err)
+	}
+
+	targets := analysis.TestTargets(absPath, packages, opts.TestPackages, opts.TestReverseImports)
+
+	if opts.Verbose {
+		for dir, testPackages := range targets {
+			e.logf("Additional tests for %s: %s", dir, strings.Join(testPackages, " "))
+		}
+	}
+
+	e.executor.SetTestPackages(targets)
+
+	return nil
+}
+
+// Run executes mutation testing on the Go packages at path and returns the
+// summary of the run. In CI mode, the summary is also returned when the
+// quality gate fails. Canceling ctx stops the tests of the remaining mutants
+// and makes Run return ctx's error. An Engine can run any number of times, but
+// not concurrently.
+func (e *Engine) Run(ctx context.Context, path string) (*Summary, error) {
+	return e.run(ctx, path, e.opts)
+}
+
+// run executes mutation testing on the specified path with opts. It emits
+// EventRunFinished however it returns, including on errors before
+// EventRunStarted.
+func (e *Engine) run(ctx context.Context, path string, opts *options) (result *Summary, err error) {
+	defer func() {
+		e.finish(result, err)
+	}()
+
 	opts = e.setDefaultOptions(opts)
 	start := time.Now()
 
@@ -229,75 +363,100 @@ func (e *Engine) Run(ctx context.Context, path string, opts *RunOptions) error {
 
 	absPath, err := e.getAbsolutePath(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Load .gomuignore file from the target path
 	ignoreFile, err := ignore.FindIgnoreFile(absPath)
 	if err != nil {
-		return fmt.Errorf("failed to find .gomuignore file: %w", err)
+		return nil, fmt.Errorf("failed to find .gomuignore file: %w", err)
 	}
 
-	var ignoreParser analysis.IgnoreParser
+	var ignoreParser *ignore.Parser
 
 	if ignoreFile != "" {
-		parser := ignore.New()
-		if err := parser.LoadFromFile(ignoreFile); err != nil {
-			return fmt.Errorf("failed to load .gomuignore file: %w", err)
+		ignoreParser = ignore.New()
+		if err := ignoreParser.LoadFromFile(ignoreFile); err != nil {
+			return nil, fmt.Errorf("failed to load .gomuignore file: %w", err)
 		}
 
 		if opts.Verbose {
-			log.Printf("Loaded .gomuignore file from: %s", ignoreFile)
-		}
-
-		// Create new analyzer with ignore parser
-		analyzer, err := analysis.New(analysis.WithIgnoreParser(parser))
-		if err != nil {
-			return fmt.Errorf("failed to create analyzer with ignore parser: %w", err)
+			e.logf("Loaded .gomuignore file from: %s", ignoreFile)
 		}
+	}
 
-		e.analyzer = analyzer
-		ignoreParser = parser
+	if err := e.initComponents(opts, ignoreParser); err != nil {
+		return nil, err
 	}
 
 	analysisResults, files, err := e.performIncrementalAnalysis(absPath, opts, ignoreParser)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	e.emit(Event{Type: EventRunStarted, Path: path, Files: len(files)})
+
 	if len(files) == 0 {
 		if opts.Verbose {
-			log.Println("No files need processing - all files are up to date")
+			e.logf("No files need processing - all files are up to date")
 		}
 
-		return nil
+		return &Summary{TotalFiles: len(analysisResults), Duration: time.Since(start)}, nil
 	}
 
-	allResults, totalMutants, processedFiles := e.processFiles(files, opts)
+	// Type-check the dependencies shared by the packages of files once.
+	if err := e.analyzer.LoadPackages(files); err != nil && opts.Verbose {
+		e.logf("Warning: %v", err)
+	}
 
-	if err := e.cleanupAndSave(opts); err != nil {
-		return err
+	allResults, totalMutants, processedFiles, err := e.processFiles(ctx, files, opts)
+
+	if cleanupErr := e.cleanupAndSave(opts); cleanupErr != nil {
+		return nil, cleanupErr
+	}
+
+	if err != nil {
+		return nil, err
 	}
 
 	summary := e.buildSummary(analysisResults, totalMutants, allResults, processedFiles, start)
+	summary.Statistics = report.CalculateStatistics(summary.Results)
 
-	if err := e.reporter.Generate(summary); err != nil {
-		return fmt.Errorf("failed to generate report: %w", err)
+	if e.reporter != nil {
+		if err := e.reporter.Generate(summary); err != nil {
+			return nil, fmt.Errorf("failed to generate report: %w", err)
+		}
 	}
 
-	if err := e.handleCIWorkflow(ctx, summary, opts); err != nil {
-		return err
+	result = newSummary(summary)
+
+	qualityResult, err := e.handleCIWorkflow(ctx, summary, opts)
+	result.QualityGate = newQualityGate(qualityResult, opts.Threshold)
+
+	if err != nil {
+		return result, err
 	}
 
 	if opts.Verbose {
-		log.Printf("Mutation testing completed in %v", time.Since(start))
+		e.logf("Mutation testing completed in %v", time.Since(start))
 	}
 
-	return nil
+	return result, nil
 }
 
-// processFiles processes all files for mutation testing.
-func (e *Engine) processFiles(files []string, opts *RunOptions) ([]mutation.Result, int, int) {
+// finish emits EventRunFinished for the outcome of a run.
+func (e *Engine) finish(summary *Summary, err error) {
+	event := Event{Type: EventRunFinished, Summary: summary}
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	e.emit(event)
+}
+
+// processFiles processes all files for mutation testing. It stops early with
+// ctx's error if ctx is canceled.
+func (e *Engine) processFiles(ctx context.Context, files []string, opts *options) ([]mutation.Result, int, int, error) {
 	var (
 		allResults     []mutation.Result
 		totalMutants   int
@@ -307,31 +466,43 @@ func (e *Engine) processFiles(files []string, opts *RunOptions) ([]mutation.Resu
 	hasher := analysis.NewFileHasher()
 	totalFiles := len(files)
 
-	fmt.Printf("Processing %d file(s)...\n", totalFiles)
+	e.printf("Processing %d file(s)...\n", totalFiles)
 
 	for i, file := range files {
-		fmt.Printf("[%d/%d] %s ", i+1, totalFiles, filepath.Base(file))
+		if err := ctx.Err(); err != nil {
+			return allResults, totalMutants, processedFiles, fmt.Errorf("mutation testing canceled: %w", err)
+		}
+
+		e.printf("[%d/%d] %s ", i+1, totalFiles, filepath.Base(file))
 
 		if opts.Verbose {
-			log.Printf("Processing file: %s", file)
+			e.logf("Processing file: %s", file)
 		}
 
 		mutants, err := e.mutator.GenerateMutants(file)
 		if err != nil {
-			fmt.Printf("(error: %v)\n", err)
+			e.emit(Event{Type: EventFileAnalyzed, File: file, Error: err.Error()})
+			e.printf("(error: %v)\n", err)
 
 			if opts.Verbose {
-				log.Printf("Warning: failed to generate mutants for %s: %v", file, err)
+				e.logf("Warning: failed to generate mutants for %s: %v", file, err)
 			}
 
 			continue
 		}
 
+		e.emit(Event{Type: EventFileAnalyzed, File: file, Mutants: len(mutants)})
+
+		for _, mutant := range mutants {
+			m := newMutant(mutant)
+			e.emit(Event{Type: EventMutantGenerated, Mutant: &m})
+		}
+
 		if len(mutants) == 0 {
-			fmt.Println("(no mutants)")
+			e.printf("(no mutants)\n")
 
 			if opts.Verbose {
-				log.Printf("No mutants generated for file: %s", file)
+				e.logf("No mutants generated for file: %s", file)
 			}
 
 			continue
@@ -339,18 +510,24 @@ func (e *Engine) processFiles(files []string, opts *RunOptions) ([]mutation.Resu
 
 		totalMutants += len(mutants)
 
-		fmt.Printf("(%d mutants) ", len(mutants))
+		e.printf("(%d mutants) ", len(mutants))
 
 		if opts.Verbose {
-			log.Printf("Generated %d mutants for %s", len(mutants), file)
+			e.logf("Generated %d mutants for %s", len(mutants), file)
+		}
+
+		results, err := e.executor.RunMutationsContext(ctx, mutants, opts.Workers, opts.Timeout)
+		if ctx.Err() != nil {
+			e.printf("(canceled)\n")
+
+			return allResults, totalMutants, processedFiles, fmt.Errorf("mutation testing canceled: %w", ctx.Err())
 		}
 
-		results, err := e.executor.RunMutationsWithOptions(mutants, opts.Workers, opts.Timeout)
 		if err != nil {
-			fmt.Printf("(execution error: %v)\n", err)
+			e.printf("(execution error: %v)\n", err)
 
 			if opts.Verbose {
-				log.Printf("Warning: failed to execute mutations for %s: %v", file, err)
+				e.logf("Warning: failed to execute mutations for %s: %v", file, err)
 			}
 
 			continue
@@ -365,14 +542,14 @@ func (e *Engine) processFiles(files []string, opts *RunOptions) ([]mutation.Resu
 			}
 		}
 
-		fmt.Printf("-> %d/%d killed\n", killed, len(mutants))
+		e.printf("-> %d/%d killed\n", killed, len(mutants))
 
 		allResults = append(allResults, results...)
 
 		fileHash, err := hasher.HashFile(file)
 		if err != nil {
 			if opts.Verbose {
-				log.Printf("Warning: failed to hash file %s: %v", file, err)
+				e.logf("Warning: failed to hash file %s: %v", file, err)
 			}
 
 			fileHash = ""
@@ -384,20 +561,37 @@ func (e *Engine) processFiles(files []string, opts *RunOptions) ([]mutation.Resu
 		processedFiles++
 	}
 
-	return allResults, totalMutants, processedFiles
+	e.warnTypeErrors(opts)
+
+	return allResults, totalMutants, processedFiles, nil
+}
+
+// warnTypeErrors reports the packages whose type errors prevented checking
+// their mutants exactly; those mutants were filtered by heuristics only.
+func (e *Engine) warnTypeErrors(opts *options) {
+	for _, pkg := range e.mutator.TypeErrors() {
+		e.printf("Warning: package %s has %d type error(s); its mutants were not type-checked: %s\n",
+			pkg.Dir, len(pkg.Errors), pkg.Errors[0])
+
+		if opts.Verbose {
+			for _, msg := range pkg.Errors {
+				e.logf("Type error in %s: %s", pkg.Dir, msg)
+			}
+		}
+	}
 }
 
 // cleanupAndSave handles cleanup and saving operations.
-func (e *Engine) cleanupAndSave(opts *RunOptions) error {
+func (e *Engine) cleanupAndSave(opts *options) error {
 	if err := e.executor.Close(); err != nil {
 		if opts.Verbose {
-			log.Printf("Warning: failed to cleanup execution engine: %v", err)
+			e.logf("Warning: failed to cleanup execution engine: %v", err)
 		}
 	}
 
 	if err := e.history.Save(); err != nil {
 		if opts.Verbose {
-			log.Printf("Warning: failed to save history: %v", err)
+			e.logf("Warning: failed to save history: %v", err)
 		}
 	}
 
@@ -407,33 +601,38 @@ func (e *Engine) cleanupAndSave(opts *RunOptions) error {
 // buildSummary builds the mutation testing summary.
 func (e *Engine) buildSummary(analysisResults []analysis.FileAnalysisResult, totalMutants int, allResults []mutation.Result, processedFiles int, start time.Time) *report.Summary {
 	return &report.Summary{
-		TotalFiles:     len(analysisResults),
-		TotalMutants:   totalMutants,
-		Results:        allResults,
-		Duration:       time.Since(start),
-		ProcessedFiles: processedFiles,
+		TotalFiles:       len(analysisResults),
+		TotalMutants:     totalMutants,
+		FilteredMutants:  e.mutator.FilteredMutants(),
+		DuplicateMutants: e.mutator.DuplicateMutants(),
+		SubsumedMutants:  e.mutator.SubsumedMutants(),
+		Results:          allResults,
+		Duration:         time.Since(start),
+		ProcessedFiles:   processedFiles,
 	}
 }
 
-// handleCIWorkflow handles CI-specific processing if CI mode is enabled.
-func (e *Engine) handleCIWorkflow(ctx context.Context, summary *report.Summary, opts *RunOptions) error {
+// handleCIWorkflow handles CI-specific processing if CI mode is enabled and
+// returns the quality gate result.
+func (e *Engine) handleCIWorkflow(ctx context.Context, summary *report.Summary, opts *options) (*ci.QualityGateResult, error) {
 	if opts == nil || !opts.CIMode {
-		return nil
+		return nil, nil
 	}
 
 	e.initializeCIComponents(opts)
 
-	if err := e.processCIWorkflow(ctx, summary, opts); err != nil {
-		return fmt.Errorf("CI workflow failed: %w", err)
+	qualityResult, err := e.processCIWorkflow(ctx, summary, opts)
+	if err != nil {
+		return qualityResult, fmt.Errorf("CI workflow failed: %w", err)
 	}
 
-	return nil
+	return qualityResult, nil
 }
 
 // processCIWorkflow handles CI-specific processing after mutation testing.
-func (e *Engine) processCIWorkflow(ctx context.Context, summary *report.Summary, opts *RunOptions) error {
+func (e *Engine) processCIWorkflow(ctx context.Context, summary *report.Summary, opts *options) (*ci.QualityGateResult, error) {
 	if opts.Verbose {
-		log.Println("Processing CI workflow...")
+		e.logf("Processing CI workflow...")
 	}
 
 	ciSummary := e.convertToCISummary(summary)
@@ -441,34 +640,34 @@ func (e *Engine) processCIWorkflow(ctx context.Context, summary *report.Summary,
 	var qualityResult *ci.QualityGateResult
 	if e.qualityGate != nil {
 		qualityResult = e.qualityGate.Evaluate(ciSummary)
-		fmt.Printf("Quality Gate: %s (Score: %.1f%%)\n",
+		e.printf("Quality Gate: %s (Score: %.1f%%)\n",
 			map[bool]string{true: "PASSED", false: "FAILED"}[qualityResult.Pass],
 			qualityResult.MutationScore)
 
 		if !qualityResult.Pass {
-			fmt.Printf("Reason: %s\n", qualityResult.Reason)
+			e.printf("Reason: %s\n", qualityResult.Reason)
 		}
 	}
 
 	if e.ciReporter != nil {
 		if err := e.ciReporter.Generate(ciSummary, e.qualityGate); err != nil {
-			return fmt.Errorf("failed to generate CI reports: %w", err)
+			return qualityResult, fmt.Errorf("failed to generate CI reports: %w", err)
 		}
 	}
 
 	if e.github != nil && qualityResult != nil {
 		if err := e.github.CreatePRComment(ctx, ciSummary, qualityResult); err != nil {
-			fmt.Printf("Warning: Failed to create PR comment: %v\n", err)
+			e.printf("Warning: Failed to create PR comment: %v\n", err)
 		} else {
-			fmt.Println("Created PR comment with mutation testing results")
+			e.printf("Created PR comment with mutation testing results\n")
 		}
 	}
 
 	if qualityResult != nil && !qualityResult.Pass && opts.FailOnGate {
-		return fmt.Errorf("quality gate failed: %s", qualityResult.Reason)
+		return qualityResult, fmt.Errorf("%w: %s", ErrQualityGateFailed, qualityResult.Reason)
 	}
 
-	return nil
+	return qualityResult, nil
 }
 
 // convertToCISummary converts report.Summary to CI format.
@@ -515,14 +714,37 @@ func (e *Engine) convertToCISummary(summary *report.Summary) *report.Summary {
 	}
 
 	return &report.Summary{
-		Files:         files,
-		TotalMutants:  totalMutants,
-		KilledMutants: killedMutants,
-		Duration:      summary.Duration,
-		Statistics:    summary.Statistics,
+		Files:            files,
+		TotalMutants:     totalMutants,
+		FilteredMutants:  summary.FilteredMutants,
+		DuplicateMutants: summary.DuplicateMutants,
+		SubsumedMutants:  summary.SubsumedMutants,
+		KilledMutants:    killedMutants,
+		Results:          summary.Results,
+		Duration:         summary.Duration,
+		Statistics:       summary.Statistics,
 	}
 }
 
+// writer returns the writer for progress and logs.
+func (e *Engine) writer() io.Writer {
+	if e.out == nil {
+		return io.Discard
+	}
+
+	return e.out
+}
+
+// printf writes progress output.
+func (e *Engine) printf(format string, args ...any) {
+	fmt.Fprintf(e.writer(), format, args...)
+}
+
+// logf writes a log message with a timestamp.
+func (e *Engine) logf(format string, args ...any) {
+	log.New(e.writer(), "", log.LstdFlags).Printf(format, args...)
+}
+
 // calculateTestHash calculates the combined hash of test files related to the given file.
 func calculateTestHash(filePath string, hasher *analysis.FileHasher) string {
 	// Find related test files
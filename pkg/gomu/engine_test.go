@@ -1,7 +1,10 @@
 package gomu
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"math"
 	"os"
 	"path/filepath"
@@ -24,7 +27,7 @@ go 1.21
 func TestNewEngine(t *testing.T) {
 	tests := []struct {
 		name        string
-		opts        *RunOptions
+		opts        *options
 		expectError bool
 		errContains string
 	}{
@@ -35,7 +38,7 @@ func TestNewEngine(t *testing.T) {
 		},
 		{
 			name: "creates engine with CI mode enabled",
-			opts: &RunOptions{
+			opts: &options{
 				CIMode:     true,
 				Threshold:  90.0,
 				Output:     "xml",
@@ -45,7 +48,7 @@ func TestNewEngine(t *testing.T) {
 		},
 		{
 			name: "creates engine with CI mode disabled",
-			opts: &RunOptions{
+			opts: &options{
 				CIMode: false,
 			},
 			expectError: false,
@@ -54,7 +57,7 @@ func TestNewEngine(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			engine, err := NewEngine(tt.opts)
+			engine, err := newEngine(tt.opts)
 
 			if tt.expectError {
 				if err == nil {
@@ -91,8 +94,8 @@ func TestNewEngine(t *testing.T) {
 				t.Error("history should not be nil")
 			}
 
-			if engine.reporter == nil {
-				t.Error("reporter should not be nil")
+			if wantReporter := tt.opts != nil && tt.opts.Output != ""; (engine.reporter != nil) != wantReporter {
+				t.Errorf("expected reporter only when a report is requested, got %v", engine.reporter)
 			}
 
 			// Verify CI components when CI mode is enabled
@@ -117,13 +120,13 @@ func TestNewEngine(t *testing.T) {
 func TestInitializeCIComponents(t *testing.T) {
 	tests := []struct {
 		name       string
-		opts       *RunOptions
+		opts       *options
 		setupEnv   map[string]string
 		verifyFunc func(t *testing.T, e *Engine)
 	}{
 		{
 			name: "initializes with custom threshold",
-			opts: &RunOptions{
+			opts: &options{
 				Threshold: 95.5,
 				Output:    "json",
 			},
@@ -149,7 +152,7 @@ func TestInitializeCIComponents(t *testing.T) {
 		},
 		{
 			name: "initializes with GitHub environment",
-			opts: &RunOptions{
+			opts: &options{
 				Threshold: 85.0,
 				Output:    "xml",
 			},
@@ -167,7 +170,7 @@ func TestInitializeCIComponents(t *testing.T) {
 		},
 		{
 			name: "skips GitHub when token missing",
-			opts: &RunOptions{
+			opts: &options{
 				Threshold: 80.0,
 			},
 			setupEnv: map[string]string{
@@ -184,7 +187,7 @@ func TestInitializeCIComponents(t *testing.T) {
 		},
 		{
 			name: "skips GitHub when repo missing",
-			opts: &RunOptions{
+			opts: &options{
 				Threshold: 80.0,
 			},
 			setupEnv: map[string]string{
@@ -223,7 +226,7 @@ func TestRun(t *testing.T) {
 	tests := []struct {
 		name         string
 		setupFunc    func(t *testing.T) (string, func())
-		opts         *RunOptions
+		opts         *options
 		expectError  bool
 		errContains  string
 		setupContext func() context.Context
@@ -238,7 +241,7 @@ func TestRun(t *testing.T) {
 
 				return tempDir, func() {}
 			},
-			opts: &RunOptions{
+			opts: &options{
 				Workers:     2,
 				Timeout:     10,
 				Output:      "json",
@@ -289,7 +292,7 @@ func TestAdd(t *testing.T) {
 					os.Remove(filepath.Join(tempDir, ".gomu_history.json"))
 				}
 			},
-			opts: &RunOptions{
+			opts: &options{
 				Workers:     1,
 				Timeout:     5,
 				Output:      "json",
@@ -333,7 +336,7 @@ func IsPositive(n int) bool {
 					os.Remove(filepath.Join(tempDir, ".gomu_history.json"))
 				}
 			},
-			opts: &RunOptions{
+			opts: &options{
 				Workers:    1,
 				Timeout:    5,
 				Output:     "json",
@@ -349,7 +352,7 @@ func IsPositive(n int) bool {
 			setupFunc: func(_ *testing.T) (string, func()) {
 				return "/nonexistent/invalid/path", func() {}
 			},
-			opts: &RunOptions{
+			opts: &options{
 				Workers: 1,
 				Timeout: 5,
 			},
@@ -365,7 +368,7 @@ func IsPositive(n int) bool {
 
 				return tempDir, func() {}
 			},
-			opts: &RunOptions{
+			opts: &options{
 				Workers: 1,
 			},
 			setupContext: func() context.Context {
@@ -383,7 +386,7 @@ func IsPositive(n int) bool {
 			path, cleanup := tt.setupFunc(t)
 			defer cleanup()
 
-			engine, err := NewEngine(tt.opts)
+			engine, err := newEngine(tt.opts)
 			if err != nil {
 				t.Fatalf("failed to create engine: %v", err)
 			}
@@ -393,7 +396,7 @@ func IsPositive(n int) bool {
 				ctx = tt.setupContext()
 			}
 
-			err = engine.Run(ctx, path, tt.opts)
+			summary, err := engine.run(ctx, path, tt.opts)
 
 			if tt.expectError {
 				if err == nil {
@@ -405,6 +408,10 @@ func IsPositive(n int) bool {
 				if err != nil {
 					t.Errorf("unexpected error: %v", err)
 				}
+
+				if summary == nil {
+					t.Error("expected a summary")
+				}
 			}
 
 			// Cleanup
@@ -415,12 +422,287 @@ func IsPositive(n int) bool {
 	}
 }
 
+func TestEngine_Run(t *testing.T) {
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod": testModuleContent,
+		"calc.go": `package calc
+
+func Add(a, b int) int {
+	return a + b
+}
+`,
+		"calc_test.go": `package calc
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	if Add(2, 3) != 5 {
+		t.Error("Add failed")
+	}
+}
+`,
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	defer os.Remove(history.DefaultFile)
+
+	var (
+		out      strings.Builder
+		eventLog bytes.Buffer
+		events   []EventType
+	)
+
+	engine, err := New(
+		WithWorkers(2),
+		WithTimeout(10*time.Second),
+		WithIncremental(false, ""),
+		WithWriter(&out),
+		WithEventHandler(func(event Event) { events = append(events, event.Type) }),
+		WithEventLog(&eventLog),
+	)
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	summary, err := engine.Run(context.Background(), tempDir)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if summary.TotalMutants == 0 || len(summary.Results) != summary.TotalMutants {
+		t.Fatalf("expected a result for each of the mutants, got %d results for %d mutants", len(summary.Results), summary.TotalMutants)
+	}
+
+	for _, result := range summary.Results {
+		if result.Mutant.Type == "arithmetic_binary" && result.Status != StatusKilled {
+			t.Errorf("expected %s to be killed, got %s", result.Mutant.ID, result.Status)
+		}
+	}
+
+	if summary.Statistics.Killed == 0 || summary.Statistics.Score == 0 {
+		t.Errorf("expected killed mutants in statistics, got %+v", summary.Statistics)
+	}
+
+	if summary.QualityGate != nil {
+		t.Errorf("expected no quality gate outside of CI mode, got %+v", summary.QualityGate)
+	}
+
+	if !strings.Contains(out.String(), "Processing 1 file(s)...") {
+		t.Errorf("expected progress in output, got:\n%s", out.String())
+	}
+
+	counts := make(map[EventType]int)
+	for _, event := range events {
+		counts[event]++
+	}
+
+	if len(events) == 0 || events[0] != EventRunStarted || events[len(events)-1] != EventRunFinished {
+		t.Errorf("expected events from run_started to run_finished, got %v", events)
+	}
+
+	for _, eventType := range []EventType{EventMutantGenerated, EventMutantStarted, EventMutantFinished} {
+		if counts[eventType] != summary.TotalMutants {
+			t.Errorf("expected %d %s events, got %d", summary.TotalMutants, eventType, counts[eventType])
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(eventLog.String()), "\n")
+	if len(lines) != len(events) {
+		t.Fatalf("expected %d NDJSON lines, got %d", len(events), len(lines))
+	}
+
+	var finished Event
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &finished); err != nil {
+		t.Fatalf("failed to decode the last event: %v", err)
+	}
+
+	if finished.Type != EventRunFinished || finished.Summary == nil || finished.Summary.TotalMutants != summary.TotalMutants {
+		t.Errorf("unexpected run_finished event: %+v", finished)
+	}
+}
+
+func TestEngine_RunReused(t *testing.T) {
+	tempDir := t.TempDir()
+
+	writeFile := func(name, content string) {
+		t.Helper()
+
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	writeFile("go.mod", testModuleContent)
+	writeFile("calc.go", `package calc
+
+func Add(a, b int) int { return a + b }
+`)
+	writeFile("calc_test.go", `package calc
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	if Add(2, 3) != 5 {
+		t.Error("Add failed")
+	}
+}
+`)
+
+	defer os.Remove(history.DefaultFile)
+
+	opts := []Option{WithWorkers(2), WithTimeout(10 * time.Second), WithIncremental(false, "")}
+
+	engine, err := New(opts...)
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	if _, err := engine.Run(context.Background(), tempDir); err != nil {
+		t.Fatalf("first Run failed: %v", err)
+	}
+
+	// Move Add and add code so that cached positions would be stale.
+	writeFile("calc.go", `package calc
+
+// Max returns the larger of a and b.
+func Max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func Add(a, b int) int { return a + b }
+`)
+
+	reused, err := engine.Run(context.Background(), tempDir)
+	if err != nil {
+		t.Fatalf("second Run failed: %v", err)
+	}
+
+	fresh, err := New(opts...)
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	want, err := fresh.Run(context.Background(), tempDir)
+	if err != nil {
+		t.Fatalf("fresh Run failed: %v", err)
+	}
+
+	if reused.TotalMutants != want.TotalMutants || reused.FilteredMutants != want.FilteredMutants {
+		t.Errorf("reused engine found %d mutants (%d filtered), fresh engine %d (%d filtered)",
+			reused.TotalMutants, reused.FilteredMutants, want.TotalMutants, want.FilteredMutants)
+	}
+
+	for _, result := range reused.Results {
+		if result.Status == StatusError {
+			t.Errorf("unexpected error for %s: %s", result.Mutant.ID, result.Error)
+		}
+	}
+}
+
+func TestEngine_RunCanceled(t *testing.T) {
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":  testModuleContent,
+		"calc.go": "package calc\n\nfunc Add(a, b int) int { return a + b }\n",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	defer os.Remove(history.DefaultFile)
+
+	engine, err := New(WithIncremental(false, ""))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := engine.Run(ctx, tempDir); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestEngine_RunFailedEarly(t *testing.T) {
+	var events []Event
+
+	engine, err := New(
+		WithIncremental(false, ""),
+		WithEventHandler(func(event Event) { events = append(events, event) }),
+	)
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	_, err = engine.Run(context.Background(), filepath.Join(t.TempDir(), "missing"))
+	if err == nil {
+		t.Fatal("expected an error for a missing path")
+	}
+
+	if len(events) != 1 || events[0].Type != EventRunFinished || events[0].Error != err.Error() {
+		t.Errorf("expected only run_finished with the error, got %+v", events)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want func(o *options) bool
+	}{
+		{
+			name: "timeout rounds up to seconds",
+			opts: []Option{WithTimeout(1500 * time.Millisecond)},
+			want: func(o *options) bool { return o.Timeout == 2 },
+		},
+		{
+			name: "quality gate enables CI mode",
+			opts: []Option{WithQualityGate(90, false)},
+			want: func(o *options) bool { return o.CIMode && o.Threshold == 90 && !o.FailOnGate },
+		},
+		{
+			name: "no report by default",
+			opts: nil,
+			want: func(o *options) bool { return o.Output == "" && o.Writer == nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := defaultOptions()
+			for _, opt := range tt.opts {
+				opt(o)
+			}
+
+			if !tt.want(o) {
+				t.Errorf("unexpected options: %+v", o)
+			}
+		})
+	}
+}
+
 func TestProcessCIWorkflow(t *testing.T) {
 	tests := []struct {
 		name        string
 		setupEngine func() *Engine
 		summary     *report.Summary
-		opts        *RunOptions
+		opts        *options
 		expectError bool
 		errContains string
 	}{
@@ -450,7 +732,7 @@ func TestProcessCIWorkflow(t *testing.T) {
 					},
 				},
 			},
-			opts: &RunOptions{
+			opts: &options{
 				FailOnGate: true,
 				Verbose:    true,
 			},
@@ -482,7 +764,7 @@ func TestProcessCIWorkflow(t *testing.T) {
 					},
 				},
 			},
-			opts: &RunOptions{
+			opts: &options{
 				FailOnGate: true,
 				Verbose:    false,
 			},
@@ -508,7 +790,7 @@ func TestProcessCIWorkflow(t *testing.T) {
 				},
 				Duration: time.Second,
 			},
-			opts: &RunOptions{
+			opts: &options{
 				FailOnGate: false,
 				Verbose:    true,
 			},
@@ -539,7 +821,7 @@ func TestProcessCIWorkflow(t *testing.T) {
 					},
 				},
 			},
-			opts: &RunOptions{
+			opts: &options{
 				Verbose: false,
 			},
 			expectError: false,
@@ -550,7 +832,7 @@ func TestProcessCIWorkflow(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			engine := tt.setupEngine()
 
-			err := engine.processCIWorkflow(context.Background(), tt.summary, tt.opts)
+			_, err := engine.processCIWorkflow(context.Background(), tt.summary, tt.opts)
 
 			if tt.expectError {
 				if err == nil {
@@ -705,7 +987,7 @@ func TestConvertToCISummary(t *testing.T) {
 func TestSetDefaultOptions(t *testing.T) {
 	tests := []struct {
 		name            string
-		input           *RunOptions
+		input           *options
 		expectWorkers   int
 		expectTimeout   int
 		expectOutput    string
@@ -716,12 +998,12 @@ func TestSetDefaultOptions(t *testing.T) {
 			input:           nil,
 			expectWorkers:   4,
 			expectTimeout:   30,
-			expectOutput:    "console",
+			expectOutput:    "",
 			expectThreshold: 80.0,
 		},
 		{
 			name: "non-nil options returns as is",
-			input: &RunOptions{
+			input: &options{
 				Workers:   2,
 				Timeout:   10,
 				Output:    "html",
@@ -758,6 +1040,71 @@ func TestSetDefaultOptions(t *testing.T) {
 	}
 }
 
+func TestPerformIncrementalAnalysis_Patterns(t *testing.T) {
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":         testModuleContent,
+		"root.go":        "package main\n\nfunc Root() int { return 1 }\n",
+		"constrained.go": "//go:build gomu_never\n\npackage main\n\nfunc Never() int { return 2 }\n",
+		"sub/sub.go":     "package sub\n\nfunc Sub() int { return 3 }\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+	}{
+		{
+			name:     "default pattern skips constrained files",
+			patterns: nil,
+			want:     []string{"root.go", "sub/sub.go"},
+		},
+		{
+			name:     "single package pattern",
+			patterns: []string{"./sub"},
+			want:     []string{"sub/sub.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := newEngine(nil)
+			if err != nil {
+				t.Fatalf("failed to create engine: %v", err)
+			}
+			defer engine.executor.Close()
+
+			opts := &options{Incremental: false, Patterns: tt.patterns}
+
+			_, got, err := engine.performIncrementalAnalysis(tempDir, opts, nil)
+			if err != nil {
+				t.Fatalf("performIncrementalAnalysis() error = %v", err)
+			}
+
+			want := make([]string, 0, len(tt.want))
+			for _, name := range tt.want {
+				want = append(want, filepath.Join(tempDir, name))
+			}
+
+			if strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Errorf("expected files %v, got %v", want, got)
+			}
+		})
+	}
+}
+
 func TestGetAbsolutePath(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -829,13 +1176,13 @@ func TestLogStartupInfo(t *testing.T) {
 	tests := []struct {
 		name      string
 		path      string
-		opts      *RunOptions
+		opts      *options
 		expectLog bool
 	}{
 		{
 			name: "verbose mode logs info",
 			path: "/test/path",
-			opts: &RunOptions{
+			opts: &options{
 				Verbose:     true,
 				Workers:     2,
 				Timeout:     10,
@@ -847,7 +1194,7 @@ func TestLogStartupInfo(t *testing.T) {
 		{
 			name: "non-verbose mode doesn't log",
 			path: "/test/path",
-			opts: &RunOptions{
+			opts: &options{
 				Verbose: false,
 			},
 			expectLog: false,
@@ -1093,7 +1440,7 @@ func TestHandleCIWorkflow(t *testing.T) {
 	tests := []struct {
 		name        string
 		summary     *report.Summary
-		opts        *RunOptions
+		opts        *options
 		expectError bool
 		errContains string
 	}{
@@ -1106,7 +1453,7 @@ func TestHandleCIWorkflow(t *testing.T) {
 		{
 			name:    "CI mode disabled returns nil",
 			summary: &report.Summary{},
-			opts: &RunOptions{
+			opts: &options{
 				CIMode: false,
 			},
 			expectError: false,
@@ -1123,7 +1470,7 @@ func TestHandleCIWorkflow(t *testing.T) {
 					{Mutant: mutation.Mutant{FilePath: "test.go"}, Status: mutation.StatusKilled},
 				},
 			},
-			opts: &RunOptions{
+			opts: &options{
 				CIMode:     true,
 				Threshold:  80.0,
 				FailOnGate: true,
@@ -1143,7 +1490,7 @@ func TestHandleCIWorkflow(t *testing.T) {
 					{Mutant: mutation.Mutant{FilePath: "test.go"}, Status: mutation.StatusSurvived},
 				},
 			},
-			opts: &RunOptions{
+			opts: &options{
 				CIMode:     true,
 				Threshold:  80.0,
 				FailOnGate: true,
@@ -1164,7 +1511,7 @@ func TestHandleCIWorkflow(t *testing.T) {
 					{Mutant: mutation.Mutant{FilePath: "test.go"}, Status: mutation.StatusSurvived},
 				},
 			},
-			opts: &RunOptions{
+			opts: &options{
 				CIMode:     true,
 				Threshold:  80.0,
 				FailOnGate: false,
@@ -1180,7 +1527,7 @@ func TestHandleCIWorkflow(t *testing.T) {
 				reporter: reporter,
 			}
 
-			err := engine.handleCIWorkflow(context.Background(), tt.summary, tt.opts)
+			_, err := engine.handleCIWorkflow(context.Background(), tt.summary, tt.opts)
 
 			if tt.expectError {
 				if err == nil {
@@ -1208,7 +1555,7 @@ func TestProcessCIWorkflowDetailed(t *testing.T) {
 			{Mutant: mutation.Mutant{FilePath: "test.go"}, Status: mutation.StatusKilled},
 		},
 	}
-	opts := &RunOptions{
+	opts := &options{
 		Verbose:    true,
 		Threshold:  80.0,
 		FailOnGate: false,
@@ -1221,7 +1568,7 @@ func TestProcessCIWorkflowDetailed(t *testing.T) {
 		qualityGate: ci.NewQualityGateEvaluator(true, 80.0),
 	}
 
-	err := engine.processCIWorkflow(context.Background(), summary, opts)
+	_, err := engine.processCIWorkflow(context.Background(), summary, opts)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -0,0 +1,97 @@
+package gomu
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/sivchari/gomu/internal/mutation"
+)
+
+// EventType identifies the kind of an Event.
+type EventType string
+
+// Event types, in the order they occur during a run.
+const (
+	EventRunStarted      EventType = "run_started"      // The files to mutate are known
+	EventFileAnalyzed    EventType = "file_analyzed"    // Mutants were generated for a file
+	EventMutantGenerated EventType = "mutant_generated" // A mutant was generated
+	EventMutantStarted   EventType = "mutant_started"   // The tests of a mutant started
+	EventMutantFinished  EventType = "mutant_finished"  // The tests of a mutant finished
+	EventRunFinished     EventType = "run_finished"     // The run finished
+)
+
+// Event reports the progress of a run. Only the fields of its Type are set.
+type Event struct {
+	Type EventType `json:"type"`
+	Time time.Time `json:"time"`
+	// Path is the run path and Files the number of files to mutate, set for
+	// EventRunStarted.
+	Path  string `json:"path,omitempty"`
+	Files int    `json:"files,omitempty"`
+	// File is the analyzed file and Mutants the number of mutants generated
+	// for it, set for EventFileAnalyzed.
+	File    string `json:"file,omitempty"`
+	Mutants int    `json:"mutants,omitempty"`
+	// Mutant is set for EventMutantGenerated and EventMutantStarted.
+	Mutant *Mutant `json:"mutant,omitempty"`
+	// Result is set for EventMutantFinished.
+	Result *Result `json:"result,omitempty"`
+	// Summary is set for EventRunFinished, unless the run failed.
+	Summary *Summary `json:"summary,omitempty"`
+	// Error is set for EventFileAnalyzed if generating the mutants of File
+	// failed, and for EventRunFinished if the run failed.
+	Error string `json:"error,omitempty"`
+}
+
+// WithEventHandler adds a handler called with each event of a run. Handlers
+// are called one at a time, in the order the events occur, from the
+// goroutines running the mutants, so they should return quickly.
+func WithEventHandler(handler func(Event)) Option {
+	return func(o *options) {
+		o.EventHandlers = append(o.EventHandlers, handler)
+	}
+}
+
+// WithEventLog writes each event of a run to w as a line of JSON (NDJSON).
+// Write errors are ignored so that they do not interrupt the run.
+func WithEventLog(w io.Writer) Option {
+	encoder := json.NewEncoder(w)
+
+	return WithEventHandler(func(event Event) {
+		_ = encoder.Encode(event)
+	})
+}
+
+// emit sends event to the event handlers.
+func (e *Engine) emit(event Event) {
+	if e.opts == nil || len(e.opts.EventHandlers) == 0 {
+		return
+	}
+
+	event.Time = time.Now()
+
+	e.eventsMu.Lock()
+	defer e.eventsMu.Unlock()
+
+	for _, handler := range e.opts.EventHandlers {
+		handler(event)
+	}
+}
+
+// executionObserver emits the events of mutants being executed.
+type executionObserver struct {
+	engine *Engine
+}
+
+// MutantStarted emits EventMutantStarted.
+func (o *executionObserver) MutantStarted(mutant mutation.Mutant) {
+	m := newMutant(mutant)
+	o.engine.emit(Event{Type: EventMutantStarted, Mutant: &m})
+}
+
+// MutantFinished emits EventMutantFinished.
+func (o *executionObserver) MutantFinished(result mutation.Result) {
+	r := newResult(result)
+	o.engine.emit(Event{Type: EventMutantFinished, Result: &r})
+}
@@ -0,0 +1,132 @@
+package gomu
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/sivchari/gomu/internal/mutation"
+)
+
+// Mutator generates and applies one kind of mutation. Mutators are added to an
+// Engine with WithMutators, or to every Engine with RegisterMutator, and run
+// alongside the built-in ones.
+type Mutator interface {
+	// Name returns the unique name of the mutator.
+	Name() string
+	// CanMutate reports whether Mutate may generate mutants for node.
+	CanMutate(node ast.Node) bool
+	// Mutate returns the mutants of node. Only the Line, Column, Type,
+	// Original, Mutated and Description fields are used; the others are set
+	// by gomu.
+	Mutate(node ast.Node, fset *token.FileSet) []Mutant
+	// Apply applies mutant to node in place and reports whether it did. It
+	// should check the Type of mutant, since nodes that cannot be located
+	// exactly are offered to every mutator.
+	Apply(node ast.Node, mutant Mutant) bool
+}
+
+// CursorApplier is an optional interface for mutators that replace the node
+// in its parent instead of modifying it in place. Calling replace with nil
+// deletes the node from the list containing it, such as a statement of a
+// block. ApplyWithCursor is tried before Apply.
+type CursorApplier interface {
+	ApplyWithCursor(node ast.Node, replace func(ast.Node), mutant Mutant) bool
+}
+
+// TypeAwareMutator is an optional interface for mutators that need type
+// information. Prepare is called with each file before it is walked; info is
+// nil when type checking failed.
+type TypeAwareMutator interface {
+	Prepare(file *ast.File, info *types.Info)
+}
+
+// WithMutators adds mutators to the Engine, after the built-in ones and the
+// ones added with RegisterMutator. They are used concurrently while mutants
+// are tested, so they must be safe for concurrent use. New fails if the name
+// of a mutator is empty or already taken by another mutator.
+func WithMutators(mutators ...Mutator) Option {
+	return func(o *options) {
+		o.Mutators = append(o.Mutators, mutators...)
+	}
+}
+
+// RegisterMutator adds m to the mutators of every Engine created afterwards,
+// typically from the init function of the package defining it. Registration
+// is global and cannot be undone; prefer WithMutators to configure a single
+// Engine. The same m is used by all engines and concurrently while mutants are
+// tested, so it must be safe for concurrent use. RegisterMutator panics if the
+// name of m is empty or already taken by another mutator.
+func RegisterMutator(m Mutator) {
+	mutation.Register(&pluginMutator{mutator: m})
+}
+
+// pluginMutators adapts mutators to the internal mutator interfaces.
+func pluginMutators(mutators []Mutator) []mutation.Mutator {
+	adapted := make([]mutation.Mutator, len(mutators))
+	for i, m := range mutators {
+		adapted[i] = &pluginMutator{mutator: m}
+	}
+
+	return adapted
+}
+
+// pluginMutator adapts a Mutator to the internal mutator interfaces.
+type pluginMutator struct {
+	mutator Mutator
+}
+
+// Name returns the name of the mutator.
+func (p *pluginMutator) Name() string {
+	return p.mutator.Name()
+}
+
+// CanMutate returns true if the node can be mutated by this mutator.
+func (p *pluginMutator) CanMutate(node ast.Node) bool {
+	return p.mutator.CanMutate(node)
+}
+
+// Mutate generates mutants for the given node.
+func (p *pluginMutator) Mutate(node ast.Node, fset *token.FileSet) []mutation.Mutant {
+	mutants := p.mutator.Mutate(node, fset)
+	if len(mutants) == 0 {
+		return nil
+	}
+
+	converted := make([]mutation.Mutant, len(mutants))
+	for i, mutant := range mutants {
+		converted[i] = mutation.Mutant{
+			Line:        mutant.Line,
+			Column:      mutant.Column,
+			Type:        mutant.Type,
+			Original:    mutant.Original,
+			Mutated:     mutant.Mutated,
+			Description: mutant.Description,
+		}
+	}
+
+	return converted
+}
+
+// Apply applies the mutation to the given AST node.
+func (p *pluginMutator) Apply(node ast.Node, mutant mutation.Mutant) bool {
+	return p.mutator.Apply(node, newMutant(mutant))
+}
+
+// ApplyWithCursor applies the mutation through the node's parent if the
+// mutator supports it.
+func (p *pluginMutator) ApplyWithCursor(node ast.Node, replaceFunc func(ast.Node), mutant mutation.Mutant) bool {
+	ca, ok := p.mutator.(CursorApplier)
+	if !ok {
+		return false
+	}
+
+	return ca.ApplyWithCursor(node, replaceFunc, newMutant(mutant))
+}
+
+// Prepare passes the type information of file to the mutator if it needs it.
+func (p *pluginMutator) Prepare(file *ast.File, info *types.Info) {
+	if tam, ok := p.mutator.(TypeAwareMutator); ok {
+		tam.Prepare(file, info)
+	}
+}
@@ -0,0 +1,147 @@
+package gomu
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sivchari/gomu/internal/history"
+)
+
+const centsScaleType = "cents_scale"
+
+// centsMutator turns the cents-per-unit factor 100 into 1000.
+type centsMutator struct{}
+
+func (m *centsMutator) Name() string {
+	return "cents"
+}
+
+func (m *centsMutator) CanMutate(node ast.Node) bool {
+	lit, ok := node.(*ast.BasicLit)
+
+	return ok && lit.Kind == token.INT && lit.Value == "100"
+}
+
+func (m *centsMutator) Mutate(node ast.Node, fset *token.FileSet) []Mutant {
+	pos := fset.Position(node.Pos())
+
+	return []Mutant{{
+		Line:        pos.Line,
+		Column:      pos.Column,
+		Type:        centsScaleType,
+		Original:    "100",
+		Mutated:     "1000",
+		Description: "Scale cents by 1000 instead of 100",
+	}}
+}
+
+func (m *centsMutator) Apply(node ast.Node, mutant Mutant) bool {
+	lit, ok := node.(*ast.BasicLit)
+	if !ok || mutant.Type != centsScaleType || lit.Value != mutant.Original {
+		return false
+	}
+
+	lit.Value = mutant.Mutated
+
+	return true
+}
+
+// nopMutator never mutates anything.
+type nopMutator struct{}
+
+func (m *nopMutator) Name() string                                 { return "test_nop" }
+func (m *nopMutator) CanMutate(_ ast.Node) bool                    { return false }
+func (m *nopMutator) Mutate(_ ast.Node, _ *token.FileSet) []Mutant { return nil }
+func (m *nopMutator) Apply(_ ast.Node, _ Mutant) bool              { return false }
+
+// registerNop registers nopMutator once per test binary, since registration
+// cannot be undone and tests may run several times with -count.
+var registerNop sync.Once
+
+func TestRegisterMutator(t *testing.T) {
+	registerNop.Do(func() { RegisterMutator(&nopMutator{}) })
+
+	engine, err := New(WithMutators(&centsMutator{}))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	mutators := engine.mutator.GetMutators()
+	if len(mutators) < 2 || mutators[len(mutators)-2].Name() != "test_nop" || mutators[len(mutators)-1].Name() != "cents" {
+		t.Errorf("expected the registered mutator before the engine's one, got %v", mutators)
+	}
+
+	if _, err := New(WithMutators(&nopMutator{})); err == nil {
+		t.Error("expected New to reject a mutator named like a registered one")
+	}
+}
+
+func TestWithMutators(t *testing.T) {
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod": testModuleContent,
+		"money.go": `package money
+
+func Cents(units int) int {
+	return units * 100
+}
+`,
+		"money_test.go": `package money
+
+import "testing"
+
+func TestCents(t *testing.T) {
+	if Cents(2) != 200 {
+		t.Error("Cents failed")
+	}
+}
+`,
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	defer os.Remove(history.DefaultFile)
+
+	engine, err := New(WithWorkers(1), WithTimeout(10*time.Second), WithIncremental(false, ""), WithMutators(&centsMutator{}))
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	summary, err := engine.Run(context.Background(), tempDir)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	var found bool
+
+	for _, result := range summary.Results {
+		if result.Mutant.Type != centsScaleType {
+			continue
+		}
+
+		found = true
+
+		if result.Status != StatusKilled {
+			t.Errorf("expected the cents mutant to be killed, got %s: %s", result.Status, result.Error)
+		}
+
+		if result.Mutant.Function != "Cents" || result.Mutant.Diff == "" {
+			t.Errorf("expected the mutant to be located and diffed, got %+v", result.Mutant)
+		}
+	}
+
+	if !found {
+		t.Error("expected a mutant from the added mutator")
+	}
+}
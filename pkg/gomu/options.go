@@ -0,0 +1,204 @@
+package gomu
+
+import (
+	"io"
+	"math"
+	"time"
+)
+
+// Option configures an Engine created with New.
+type Option func(*options)
+
+// options contains the settings of a mutation testing run.
+type options struct {
+	Workers     int
+	Timeout     int
+	Output      string
+	Incremental bool
+	BaseBranch  string
+	Threshold   float64
+	FailOnGate  bool
+	Verbose     bool
+	CIMode      bool
+	// Writer receives progress, console reports and verbose logs. Nothing is
+	// written when it is nil.
+	Writer io.Writer
+	// EventHandlers are called with each event of a run.
+	EventHandlers []func(Event)
+	// Mutators are added to the built-in and registered mutators.
+	Mutators []Mutator
+	// Patterns are Go package patterns (e.g. "./...", "./internal/...", or
+	// import paths) resolved with `go list` relative to the run path.
+	// Defaults to "./..." when empty.
+	Patterns []string
+	// BuildFlags are passed to go list, go build and go test (e.g. -tags, -race).
+	BuildFlags []string
+	// TestFlags are passed only to go test (e.g. -count=1, -short, -p).
+	TestFlags []string
+	// Env contains extra KEY=VALUE environment variables for go build and go test.
+	Env []string
+	// TestPackages maps a source package (import path or directory relative
+	// to the run path) to additional test packages that run for its mutants,
+	// e.g. {"./internal/store": {"./internal/api/..."}}.
+	TestPackages map[string][]string
+	// TestReverseImports also runs the tests of every package that directly
+	// imports the mutated package.
+	TestReverseImports bool
+	// CallSwaps is the path of a JSON file with additional call swap
+	// mutations, added to the built-in standard library swaps.
+	CallSwaps string
+	// PruneSubsumed drops relational operator mutants that are subsumed by
+	// other mutants at the same site.
+	PruneSubsumed bool
+	// Order is the number of first-order mutations combined into each
+	// higher-order mutant. Orders below 2 generate first-order mutants only.
+	Order int
+	// OrderLimit caps the higher-order mutants sampled per file.
+	OrderLimit int
+}
+
+// defaultOptions returns the options used when none are given.
+func defaultOptions() *options {
+	return &options{
+		Workers:     4,
+		Timeout:     30,
+		Incremental: true,
+		BaseBranch:  "main",
+		Threshold:   80.0,
+		FailOnGate:  true,
+	}
+}
+
+// WithWorkers sets the number of mutants tested in parallel. Defaults to 4.
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		o.Workers = n
+	}
+}
+
+// WithTimeout sets the timeout of the tests run against each mutant, rounded
+// up to whole seconds. Defaults to 30 seconds.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.Timeout = int(math.Ceil(d.Seconds()))
+	}
+}
+
+// WithPatterns sets the Go package patterns to mutate (e.g. "./...",
+// "./internal/...", or import paths), resolved relative to the run path.
+// Defaults to "./...".
+func WithPatterns(patterns ...string) Option {
+	return func(o *options) {
+		o.Patterns = patterns
+	}
+}
+
+// WithBuildFlags sets flags passed to go list, go build and go test (e.g.
+// -tags, -race).
+func WithBuildFlags(flags ...string) Option {
+	return func(o *options) {
+		o.BuildFlags = flags
+	}
+}
+
+// WithTestFlags sets flags passed only to go test (e.g. -count=1, -short).
+func WithTestFlags(flags ...string) Option {
+	return func(o *options) {
+		o.TestFlags = flags
+	}
+}
+
+// WithEnv sets extra KEY=VALUE environment variables for go build and go test.
+func WithEnv(env ...string) Option {
+	return func(o *options) {
+		o.Env = env
+	}
+}
+
+// WithTestPackages maps source packages (import paths or directories relative
+// to the run path) to additional test packages that run for their mutants.
+func WithTestPackages(testPackages map[string][]string) Option {
+	return func(o *options) {
+		o.TestPackages = testPackages
+	}
+}
+
+// WithTestReverseImports also runs the tests of every package that directly
+// imports the mutated package.
+func WithTestReverseImports(enabled bool) Option {
+	return func(o *options) {
+		o.TestReverseImports = enabled
+	}
+}
+
+// WithCallSwaps loads additional call swap mutations from the JSON file at
+// path.
+func WithCallSwaps(path string) Option {
+	return func(o *options) {
+		o.CallSwaps = path
+	}
+}
+
+// WithPruneSubsumed drops relational operator mutants that are subsumed by
+// other mutants at the same site.
+func WithPruneSubsumed(enabled bool) Option {
+	return func(o *options) {
+		o.PruneSubsumed = enabled
+	}
+}
+
+// WithHigherOrder adds mutants combining order first-order mutations, sampling
+// at most limit of them per file.
+func WithHigherOrder(order, limit int) Option {
+	return func(o *options) {
+		o.Order = order
+		o.OrderLimit = limit
+	}
+}
+
+// WithIncremental enables or disables incremental analysis, which skips files
+// unchanged since the last run compared to baseBranch. Enabled against "main"
+// by default.
+func WithIncremental(enabled bool, baseBranch string) Option {
+	return func(o *options) {
+		o.Incremental = enabled
+		o.BaseBranch = baseBranch
+	}
+}
+
+// WithReport generates a report in format ("console", "json", "html" or
+// "text") after each run. Console reports are written to the writer set with
+// WithWriter; the others are written to files in the current directory. No
+// report is generated by default.
+func WithReport(format string) Option {
+	return func(o *options) {
+		o.Output = format
+	}
+}
+
+// WithQualityGate enables CI mode: the mutation score is evaluated against
+// threshold, CI reports are generated and, when running on a GitHub pull
+// request, a comment with the results is posted. With failOnGate, Run returns
+// an error wrapping ErrQualityGateFailed if the score is below threshold.
+func WithQualityGate(threshold float64, failOnGate bool) Option {
+	return func(o *options) {
+		o.CIMode = true
+		o.Threshold = threshold
+		o.FailOnGate = failOnGate
+	}
+}
+
+// WithWriter sets the writer for progress, console reports and verbose logs.
+// Nothing is written by default.
+func WithWriter(w io.Writer) Option {
+	return func(o *options) {
+		o.Writer = w
+	}
+}
+
+// WithVerbose enables verbose logs to the writer set with WithWriter.
+func WithVerbose(enabled bool) Option {
+	return func(o *options) {
+		o.Verbose = enabled
+	}
+}
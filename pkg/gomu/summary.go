@@ -0,0 +1,173 @@
+package gomu
+
+import (
+	"errors"
+	"time"
+
+	"github.com/sivchari/gomu/internal/ci"
+	"github.com/sivchari/gomu/internal/mutation"
+	"github.com/sivchari/gomu/internal/report"
+)
+
+// ErrQualityGateFailed is returned by Run when the mutation score is below
+// the threshold of WithQualityGate and failOnGate is set.
+var ErrQualityGateFailed = errors.New("quality gate failed")
+
+// Status is the outcome of testing a mutant.
+type Status string
+
+// Mutant statuses.
+const (
+	StatusKilled     Status = "KILLED"     // Mutant was detected by tests
+	StatusSurvived   Status = "SURVIVED"   // Mutant was not detected by tests
+	StatusTimedOut   Status = "TIMED_OUT"  // Tests timed out
+	StatusError      Status = "ERROR"      // Build or runtime error
+	StatusNotViable  Status = "NOT_VIABLE" // Mutant causes compilation failure
+	StatusEquivalent Status = "EQUIVALENT" // Mutant compiles to the same code as the original
+)
+
+// Mutant is a change made to the source code.
+type Mutant struct {
+	ID          string `json:"id"`
+	FilePath    string `json:"filePath"`
+	Line        int    `json:"line"`
+	Column      int    `json:"column"`
+	Type        string `json:"type"`
+	Original    string `json:"original"`
+	Mutated     string `json:"mutated"`
+	Description string `json:"description"`
+	// Function is the name of the enclosing function, with its receiver
+	// type for methods (e.g. "Calc.Add").
+	Function string `json:"function,omitempty"`
+	// Diff is the unified diff of the mutated file, set for executed mutants.
+	Diff string `json:"diff,omitempty"`
+	// Components are the first-order mutants combined into a higher-order
+	// mutant.
+	Components []Mutant `json:"components,omitempty"`
+}
+
+// Result is the outcome of testing a mutant.
+type Result struct {
+	Mutant Mutant `json:"mutant"`
+	Status Status `json:"status"`
+	// Output is the output of the failed build or tests.
+	Output   string        `json:"output,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// Statistics counts the results of a run by status.
+type Statistics struct {
+	Killed     int `json:"killed"`
+	Survived   int `json:"survived"`
+	TimedOut   int `json:"timedOut"`
+	Errors     int `json:"errors"`
+	NotViable  int `json:"notViable"`
+	Equivalent int `json:"equivalent"`
+	// Score is the percentage of killed mutants among those that can be
+	// killed, i.e. excluding not viable and equivalent ones.
+	Score float64 `json:"mutationScore"`
+}
+
+// QualityGate is the evaluation of the quality gate in CI mode.
+type QualityGate struct {
+	Pass      bool    `json:"pass"`
+	Threshold float64 `json:"threshold"`
+	Reason    string  `json:"reason"`
+}
+
+// Summary contains the results of a mutation testing run.
+type Summary struct {
+	TotalFiles     int `json:"totalFiles"`
+	ProcessedFiles int `json:"processedFiles"`
+	TotalMutants   int `json:"totalMutants"`
+	// FilteredMutants were discarded by type checking before execution.
+	FilteredMutants int `json:"filteredMutants"`
+	// DuplicateMutants were pruned as producing the same source as another
+	// mutant.
+	DuplicateMutants int `json:"duplicateMutants"`
+	// SubsumedMutants were pruned as subsumed by other mutants at the same
+	// site.
+	SubsumedMutants int           `json:"subsumedMutants"`
+	Results         []Result      `json:"results"`
+	Statistics      Statistics    `json:"statistics"`
+	Duration        time.Duration `json:"duration"`
+	// QualityGate is set in CI mode.
+	QualityGate *QualityGate `json:"qualityGate,omitempty"`
+}
+
+// newSummary converts an internal report summary.
+func newSummary(summary *report.Summary) *Summary {
+	results := make([]Result, len(summary.Results))
+	for i, result := range summary.Results {
+		results[i] = newResult(result)
+	}
+
+	stats := summary.Statistics
+
+	return &Summary{
+		TotalFiles:       summary.TotalFiles,
+		ProcessedFiles:   summary.ProcessedFiles,
+		TotalMutants:     summary.TotalMutants,
+		FilteredMutants:  summary.FilteredMutants,
+		DuplicateMutants: summary.DuplicateMutants,
+		SubsumedMutants:  summary.SubsumedMutants,
+		Results:          results,
+		Statistics: Statistics{
+			Killed:     stats.Killed,
+			Survived:   stats.Survived,
+			TimedOut:   stats.TimedOut,
+			Errors:     stats.Errors,
+			NotViable:  stats.NotViable,
+			Equivalent: stats.Equivalent,
+			Score:      stats.Score,
+		},
+		Duration: summary.Duration,
+	}
+}
+
+// newResult converts an internal mutation result.
+func newResult(result mutation.Result) Result {
+	return Result{
+		Mutant:   newMutant(result.Mutant),
+		Status:   Status(result.Status),
+		Output:   result.Output,
+		Error:    result.Error,
+		Duration: time.Duration(result.ExecutionTime) * time.Millisecond,
+	}
+}
+
+// newMutant converts an internal mutant.
+func newMutant(mutant mutation.Mutant) Mutant {
+	var components []Mutant
+	for _, component := range mutant.Components {
+		components = append(components, newMutant(component))
+	}
+
+	return Mutant{
+		ID:          mutant.ID,
+		FilePath:    mutant.FilePath,
+		Line:        mutant.Line,
+		Column:      mutant.Column,
+		Type:        mutant.Type,
+		Original:    mutant.Original,
+		Mutated:     mutant.Mutated,
+		Description: mutant.Description,
+		Function:    mutant.Function,
+		Diff:        mutant.Diff,
+		Components:  components,
+	}
+}
+
+// newQualityGate converts an internal quality gate result.
+func newQualityGate(result *ci.QualityGateResult, threshold float64) *QualityGate {
+	if result == nil {
+		return nil
+	}
+
+	return &QualityGate{
+		Pass:      result.Pass,
+		Threshold: threshold,
+		Reason:    result.Reason,
+	}
+}